@@ -3,12 +3,16 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
+	// Встроенная база часовых поясов для проверки timezone профиля: в образе alpine её нет
+	_ "time/tzdata"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -16,10 +20,15 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/StepanK17/pr-reviewer-service/internal/config"
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	"github.com/StepanK17/pr-reviewer-service/internal/eventsink"
 	"github.com/StepanK17/pr-reviewer-service/internal/repository/postgres"
 	httpTransport "github.com/StepanK17/pr-reviewer-service/internal/transport/http"
 	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/handler"
 	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+	"github.com/StepanK17/pr-reviewer-service/internal/vcs"
+	"github.com/StepanK17/pr-reviewer-service/internal/webhook"
+	"github.com/StepanK17/pr-reviewer-service/internal/worker"
 )
 
 func main() {
@@ -56,13 +65,56 @@ func main() {
 	userRepo := postgres.NewUserRepository(pool)
 	prRepo := postgres.NewPullRequestRepository(pool)
 	statsRepo := postgres.NewStatisticsRepository(pool)
+	sizePolicyRepo := postgres.NewSizePolicyRepository(pool)
+	escalationRepo := postgres.NewEscalationRepository(pool)
+	historyRepo := postgres.NewPRHistoryRepository(pool)
+	ruleRepo := postgres.NewReviewRuleRepository(pool)
+	membershipRepo := postgres.NewMembershipRepository(pool)
+	activationScheduleRepo := postgres.NewActivationScheduleRepository(pool)
+	activationAuditRepo := postgres.NewActivationAuditRepository(pool)
+	vcsIdentityRepo := postgres.NewVCSIdentityRepository(pool)
+	vcsDeliveryRepo := postgres.NewVCSDeliveryRepository(pool)
+	vcsPullRequestRepo := postgres.NewVCSPullRequestRepository(pool)
+	vcsReviewerRequestRepo := postgres.NewVCSReviewerRequestRepository(pool)
+	webhookSubscriptionRepo := postgres.NewWebhookSubscriptionRepository(pool)
+	webhookDeliveryRepo := postgres.NewWebhookDeliveryRepository(pool)
+	outboxRepo := postgres.NewOutboxRepository(pool)
 	txManager := postgres.NewTransactionManager(pool)
 
 	// Инициализируем use cases
-	teamUseCase := usecase.NewTeamUseCase(teamRepo, userRepo, txManager, prRepo)
-	userUseCase := usecase.NewUserUseCase(userRepo, prRepo)
-	prUseCase := usecase.NewPullRequestUseCase(prRepo, userRepo, txManager)
-	statsUseCase := usecase.NewStatisticsUseCase(statsRepo)
+	webhookUseCase := usecase.NewWebhookUseCase(webhookSubscriptionRepo, webhookDeliveryRepo, teamRepo, txManager, webhook.NewHTTPSender(10*time.Second), cfg.WebhookRetryBackoff, cfg.WebhookMaxAttempts)
+	sinks, err := buildEventSinks(cfg, webhookUseCase)
+	if err != nil {
+		log.Fatalf("Failed to configure event sinks: %v", err)
+	}
+	for _, sink := range sinks {
+		if closer, ok := sink.(io.Closer); ok {
+			defer closer.Close()
+		}
+	}
+	outboxUseCase := usecase.NewOutboxUseCase(outboxRepo, txManager, sinks, cfg.OutboxRetryBackoff, cfg.OutboxMaxAttempts)
+	teamUseCase := usecase.NewTeamUseCase(teamRepo, userRepo, txManager, prRepo, sizePolicyRepo, escalationRepo, historyRepo, ruleRepo, membershipRepo, vcsPullRequestRepo, vcsReviewerRequestRepo, outboxUseCase)
+	userUseCase := usecase.NewUserUseCase(userRepo, prRepo, teamRepo, txManager, teamUseCase)
+	prUseCase := usecase.NewPullRequestUseCase(prRepo, userRepo, txManager, sizePolicyRepo, escalationRepo, historyRepo, ruleRepo, teamRepo, membershipRepo, vcsPullRequestRepo, vcsReviewerRequestRepo, outboxUseCase)
+	statsUseCase := usecase.NewStatisticsUseCase(statsRepo, teamRepo)
+	ruleUseCase := usecase.NewReviewRuleUseCase(ruleRepo, userRepo, txManager)
+	activationScheduleUseCase := usecase.NewActivationScheduleUseCase(activationScheduleRepo, activationAuditRepo, userRepo, txManager, userUseCase)
+	vcsUseCase := usecase.NewVCSUseCase(vcsIdentityRepo, vcsDeliveryRepo, vcsPullRequestRepo, vcsReviewerRequestRepo, userRepo, prRepo, txManager, prUseCase)
+
+	// Клиенты хостингов кода для отправки ревьюверов; провайдеры без клиента пропускаются
+	vcsClients := map[entity.VCSProvider]vcs.Client{}
+	if cfg.GitHubToken != "" {
+		vcsClients[entity.VCSProviderGitHub] = vcs.NewGitHubClient(cfg.GitHubAPIURL, cfg.GitHubToken, 10*time.Second)
+	}
+	vcsReviewerSyncUseCase := usecase.NewVCSReviewerSyncUseCase(vcsReviewerRequestRepo, vcsPullRequestRepo, vcsIdentityRepo, txManager, vcsClients, cfg.VCSSyncRetryBackoff, cfg.VCSSyncMaxAttempts)
+
+	// Подкоманда sync-org выполняется вместо запуска сервера
+	if len(os.Args) > 1 && os.Args[1] == "sync-org" {
+		if err := runSyncOrg(ctx, teamUseCase, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("Org sync failed: %v", err)
+		}
+		return
+	}
 
 	// Инициализируем handlers
 	teamHandler := handler.NewTeamHandler(teamUseCase)
@@ -70,15 +122,40 @@ func main() {
 	prHandler := handler.NewPullRequestHandler(prUseCase)
 	healthHandler := handler.NewHealthHandler()
 	statsHandler := handler.NewStatisticsHandler(statsUseCase)
+	ruleHandler := handler.NewReviewRuleHandler(ruleUseCase)
+	activationScheduleHandler := handler.NewActivationScheduleHandler(activationScheduleUseCase)
+	scimHandler := handler.NewSCIMHandler(userUseCase, teamUseCase)
+	vcsHandler := handler.NewVCSHandler(vcsUseCase, vcsReviewerSyncUseCase)
+	webhookHandler := handler.NewWebhookHandler(webhookUseCase)
+	outboxHandler := handler.NewOutboxHandler(outboxUseCase)
+
+	var gitHubWebhookHandler *handler.GitHubWebhookHandler
+	if cfg.GitHubWebhookSecret != "" {
+		gitHubWebhookHandler = handler.NewGitHubWebhookHandler(vcsUseCase, cfg.GitHubWebhookSecret)
+	}
+
+	var gitLabWebhookHandler *handler.GitLabWebhookHandler
+	if cfg.GitLabWebhookToken != "" {
+		gitLabWebhookHandler = handler.NewGitLabWebhookHandler(vcsUseCase, cfg.GitLabWebhookToken)
+	}
 
 	// Создаем роутер
 	router := httpTransport.NewRouter(httpTransport.RouterConfig{
-		TeamHandler:        teamHandler,
-		UserHandler:        userHandler,
-		PullRequestHandler: prHandler,
-		HealthHandler:      healthHandler,
-		StatisticsHandler:  statsHandler,
-		AdminToken:         cfg.AdminToken,
+		TeamHandler:               teamHandler,
+		UserHandler:               userHandler,
+		PullRequestHandler:        prHandler,
+		HealthHandler:             healthHandler,
+		StatisticsHandler:         statsHandler,
+		ReviewRuleHandler:         ruleHandler,
+		ActivationScheduleHandler: activationScheduleHandler,
+		SCIMHandler:               scimHandler,
+		VCSHandler:                vcsHandler,
+		WebhookHandler:            webhookHandler,
+		OutboxHandler:             outboxHandler,
+		GitHubWebhookHandler:      gitHubWebhookHandler,
+		GitLabWebhookHandler:      gitLabWebhookHandler,
+		AdminToken:                cfg.AdminToken,
+		SCIMToken:                 cfg.GetSCIMToken(),
 	})
 
 	// Создаем HTTP сервер
@@ -98,12 +175,27 @@ func main() {
 		}
 	}()
 
+	// Запускаем планировщик изменений активности
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go worker.NewActivationScheduler(activationScheduleUseCase, cfg.ActivationSchedulerInterval).Run(schedulerCtx)
+
+	// Запускаем отправку ревьюверов на хостинги кода
+	go worker.NewVCSReviewerSync(vcsReviewerSyncUseCase, cfg.VCSSyncInterval).Run(schedulerCtx)
+
+	// Запускаем публикацию событий из outbox
+	go worker.NewOutboxDispatcher(outboxUseCase, cfg.OutboxDispatchInterval).Run(schedulerCtx)
+
+	// Запускаем доставку исходящих вебхуков
+	go worker.NewWebhookDispatcher(webhookUseCase, cfg.WebhookDeliveryInterval).Run(schedulerCtx)
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Println("Shutting down server...")
+	stopScheduler()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -115,6 +207,36 @@ func main() {
 	log.Println("Server exited")
 }
 
+// buildEventSinks создает получателей событий outbox в порядке OUTBOX_SINKS
+func buildEventSinks(cfg *config.Config, webhookUseCase *usecase.WebhookUseCase) ([]usecase.EventSink, error) {
+	sinks := make([]usecase.EventSink, 0, len(cfg.OutboxSinks))
+	seen := make(map[string]bool, len(cfg.OutboxSinks))
+
+	for _, name := range cfg.OutboxSinks {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		switch name {
+		case "webhook":
+			sinks = append(sinks, eventsink.NewWebhookSink(webhookUseCase))
+		case "log":
+			sinks = append(sinks, eventsink.NewLogSink())
+		case "redis":
+			if cfg.OutboxRedisAddr == "" {
+				return nil, fmt.Errorf("OUTBOX_REDIS_ADDR is required for the redis sink")
+			}
+			sinks = append(sinks, eventsink.NewRedisStreamSink(cfg.OutboxRedisAddr, cfg.OutboxRedisPassword, cfg.OutboxRedisStream, 5*time.Second))
+		default:
+			return nil, fmt.Errorf("unknown event sink %q", name)
+		}
+	}
+
+	return sinks, nil
+}
+
 // Применяем миграции базы данных
 func runMigrations(dsn string) error {
 	m, err := migrate.New(
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/orgsync"
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// runSyncOrg выполняет подкоманду sync-org: синхронизирует команды с файлом оргструктуры.
+// Формат по умолчанию определяется по расширению файла.
+func runSyncOrg(ctx context.Context, teamUseCase *usecase.TeamUseCase, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("sync-org", flag.ContinueOnError)
+	file := flags.String("file", "", "path to the org description (YAML or CSV)")
+	formatName := flags.String("format", "", "yaml or csv, detected from the file extension by default")
+	dryRun := flags.Bool("dry-run", false, "print the plan without applying it")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+
+	if *formatName == "" {
+		*formatName = strings.TrimPrefix(filepath.Ext(*file), ".")
+	}
+
+	format, err := orgsync.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return fmt.Errorf("failed to open org description: %w", err)
+	}
+	defer f.Close()
+
+	teams, err := orgsync.Parse(format, f)
+	if err != nil {
+		return err
+	}
+
+	result, err := teamUseCase.SyncOrg(ctx, teams, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, action := range result.Actions {
+		line := string(action.Type) + " team=" + action.TeamName
+		if action.UserID != "" {
+			line += " user=" + action.UserID
+		}
+		if action.ParentTeamName != "" {
+			line += " parent=" + action.ParentTeamName
+		}
+		if action.FromTeam != "" {
+			line += " from=" + action.FromTeam
+		}
+		if len(action.Fields) > 0 {
+			line += " fields=" + strings.Join(action.Fields, ",")
+		}
+		fmt.Fprintln(out, line)
+	}
+
+	for _, handOff := range result.HandOffs {
+		fmt.Fprintf(out, "HAND_OFF pr=%s from=%s action=%s to=%s\n",
+			handOff.PullRequestID, handOff.OldReviewerID, handOff.Action, handOff.NewReviewerID)
+	}
+
+	if result.DryRun {
+		fmt.Fprintf(out, "dry run: %d changes planned, nothing applied\n", len(result.Actions))
+	} else {
+		fmt.Fprintf(out, "%d changes applied\n", len(result.Actions))
+	}
+
+	return nil
+}
@@ -2,10 +2,18 @@ package e2e_tests
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -637,3 +645,2698 @@ func TestTeamDeactivation(t *testing.T) {
 	}
 
 }
+
+// TestSizeAwareReviewers проверяет количество ревьюверов в зависимости от размера PR
+func TestSizeAwareReviewers(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	// 1. Создаём команду из четырёх активных пользователей
+	teamReq := map[string]interface{}{
+		"team_name": "size_team",
+		"members": []map[string]interface{}{
+			{"user_id": "size_user1", "username": "SizeUser1", "is_active": true},
+			{"user_id": "size_user2", "username": "SizeUser2", "is_active": true},
+			{"user_id": "size_user3", "username": "SizeUser3", "is_active": true},
+			{"user_id": "size_user4", "username": "SizeUser4", "is_active": true},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// 2. Настраиваем таблицу классов размера (только с admin токеном)
+	policyReq := map[string]interface{}{
+		"team_name": "size_team",
+		"buckets": []map[string]interface{}{
+			{"size_class": "TINY", "max_lines": 20, "reviewer_count": 1, "sla_hours": 2},
+			{"size_class": "HUGE", "reviewer_count": 3, "sla_hours": 72},
+		},
+	}
+
+	resp2, err := client.doRequest("POST", "/team/sizePolicy", policyReq, false)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp2.StatusCode)
+
+	resp3, err := client.doRequest("POST", "/team/sizePolicy", policyReq, true)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	assert.Equal(t, http.StatusOK, resp3.StatusCode)
+
+	// 3. Маленький PR получает одного ревьювера
+	smallReq := map[string]interface{}{
+		"pull_request_id":   "size_pr_small",
+		"pull_request_name": "Fix typo",
+		"author_id":         "size_user1",
+		"lines_added":       3,
+		"lines_deleted":     2,
+		"files_changed":     1,
+	}
+
+	resp4, err := client.doRequest("POST", "/pullRequest/create", smallReq, false)
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp4.StatusCode)
+
+	var smallResult map[string]interface{}
+	err = json.NewDecoder(resp4.Body).Decode(&smallResult)
+	require.NoError(t, err)
+
+	smallPR := smallResult["pr"].(map[string]interface{})
+	assert.Equal(t, "TINY", smallPR["size_class"])
+	assert.Len(t, smallPR["assigned_reviewers"], 1)
+	assert.NotNil(t, smallPR["review_due_at"])
+
+	// 4. Большой PR получает трёх ревьюверов
+	bigReq := map[string]interface{}{
+		"pull_request_id":   "size_pr_big",
+		"pull_request_name": "Big refactoring",
+		"author_id":         "size_user1",
+		"lines_added":       2500,
+		"lines_deleted":     500,
+		"files_changed":     80,
+	}
+
+	resp5, err := client.doRequest("POST", "/pullRequest/create", bigReq, false)
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp5.StatusCode)
+
+	var bigResult map[string]interface{}
+	err = json.NewDecoder(resp5.Body).Decode(&bigResult)
+	require.NoError(t, err)
+
+	bigPR := bigResult["pr"].(map[string]interface{})
+	assert.Equal(t, "HUGE", bigPR["size_class"])
+	assert.Len(t, bigPR["assigned_reviewers"], 3)
+
+	// 5. Статистика содержит распределение по классам размера
+	resp6, err := client.httpClient.Get(baseURL + "/statistics")
+	require.NoError(t, err)
+	defer resp6.Body.Close()
+
+	var stats map[string]interface{}
+	err = json.NewDecoder(resp6.Body).Decode(&stats)
+	require.NoError(t, err)
+
+	bySize := stats["prs_by_size_class"].(map[string]interface{})
+	assert.Equal(t, float64(1), bySize["TINY"])
+	assert.Equal(t, float64(1), bySize["HUGE"])
+}
+
+// TestBotAuthor проверяет PR от ботов: ревьюверы из команды-владельца, бот не ревьювер
+func TestBotAuthor(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	// 1. Регистрация бота требует admin токен
+	botReq := map[string]interface{}{
+		"user_id":     "e2e_dependabot",
+		"username":    "dependabot",
+		"owning_team": "engineering",
+	}
+
+	resp, err := client.doRequest("POST", "/users/registerBot", botReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp2, err := client.doRequest("POST", "/users/registerBot", botReq, true)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	assert.Equal(t, http.StatusOK, resp2.StatusCode)
+
+	var botResult map[string]interface{}
+	err = json.NewDecoder(resp2.Body).Decode(&botResult)
+	require.NoError(t, err)
+
+	bot := botResult["user"].(map[string]interface{})
+	assert.Equal(t, "BOT", bot["kind"])
+	assert.Equal(t, "engineering", bot["team_name"])
+
+	// 2. PR бота получает ревьюверов из команды-владельца
+	prReq := map[string]interface{}{
+		"pull_request_id":   "e2e_bot_pr",
+		"pull_request_name": "Bump dependency",
+		"author_id":         "e2e_dependabot",
+	}
+
+	resp3, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp3.StatusCode)
+
+	var prResult map[string]interface{}
+	err = json.NewDecoder(resp3.Body).Decode(&prResult)
+	require.NoError(t, err)
+
+	pr := prResult["pr"].(map[string]interface{})
+	reviewers := pr["assigned_reviewers"].([]interface{})
+	assert.Len(t, reviewers, 2)
+	for _, reviewer := range reviewers {
+		assert.NotEqual(t, "e2e_dependabot", reviewer)
+	}
+
+	// 3. Бот не назначается ревьювером на PR людей
+	for i := 0; i < 5; i++ {
+		humanReq := map[string]interface{}{
+			"pull_request_id":   fmt.Sprintf("e2e_bot_check_%d", i),
+			"pull_request_name": "Human PR",
+			"author_id":         "e2e_user_1",
+		}
+
+		resp4, err := client.doRequest("POST", "/pullRequest/create", humanReq, false)
+		require.NoError(t, err)
+
+		var humanResult map[string]interface{}
+		err = json.NewDecoder(resp4.Body).Decode(&humanResult)
+		resp4.Body.Close()
+		require.NoError(t, err)
+
+		humanPR := humanResult["pr"].(map[string]interface{})
+		for _, reviewer := range humanPR["assigned_reviewers"].([]interface{}) {
+			assert.NotEqual(t, "e2e_dependabot", reviewer)
+		}
+	}
+
+	// 4. PR ботов учитываются в статистике отдельно
+	resp5, err := client.httpClient.Get(baseURL + "/statistics")
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+
+	var stats map[string]interface{}
+	err = json.NewDecoder(resp5.Body).Decode(&stats)
+	require.NoError(t, err)
+
+	assert.GreaterOrEqual(t, stats["bot_prs"].(float64), float64(1))
+	botPRsByAuthor := stats["bot_prs_by_author"].(map[string]interface{})
+	assert.Equal(t, float64(1), botPRsByAuthor["dependabot"])
+
+	// Повторное добавление бота в команду без kind не делает его человеком
+	resp6, err := client.doRequest("POST", "/team/addMembers", map[string]interface{}{
+		"team_name": "engineering",
+		"members": []map[string]interface{}{
+			{"user_id": "e2e_dependabot", "username": "dependabot", "is_active": true},
+		},
+	}, true)
+	require.NoError(t, err)
+	defer resp6.Body.Close()
+	require.Equal(t, http.StatusOK, resp6.StatusCode)
+
+	resp7, err := client.httpClient.Get(baseURL + "/users/get?user_id=e2e_dependabot")
+	require.NoError(t, err)
+	defer resp7.Body.Close()
+	require.Equal(t, http.StatusOK, resp7.StatusCode)
+
+	var botAfter map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp7.Body).Decode(&botAfter))
+	assert.Equal(t, "BOT", botAfter["user"].(map[string]interface{})["kind"])
+}
+
+// TestTeamRebalance проверяет перераспределение открытых ревью в команде
+func TestTeamRebalance(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	// 1. Команда, в которой один участник пока неактивен
+	teamReq := map[string]interface{}{
+		"team_name": "rebalance_team",
+		"members": []map[string]interface{}{
+			{"user_id": "reb_user1", "username": "RebUser1", "is_active": true},
+			{"user_id": "reb_user2", "username": "RebUser2", "is_active": true},
+			{"user_id": "reb_user3", "username": "RebUser3", "is_active": true},
+			{"user_id": "reb_user4", "username": "RebUser4", "is_active": false},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// 2. Все PR достаются reb_user2 и reb_user3
+	for i := 0; i < 4; i++ {
+		prReq := map[string]interface{}{
+			"pull_request_id":   fmt.Sprintf("reb_pr_%d", i),
+			"pull_request_name": "Rebalance PR",
+			"author_id":         "reb_user1",
+		}
+
+		resp2, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+		require.NoError(t, err)
+		resp2.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp2.StatusCode)
+	}
+
+	// 3. Активируем reb_user4
+	setActiveReq := map[string]interface{}{
+		"user_id":   "reb_user4",
+		"is_active": true,
+	}
+
+	resp3, err := client.doRequest("POST", "/users/setIsActive", setActiveReq, true)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	assert.Equal(t, http.StatusOK, resp3.StatusCode)
+
+	rebalance := func(dryRun bool) map[string]interface{} {
+		req := map[string]interface{}{
+			"team_name": "rebalance_team",
+			"dry_run":   dryRun,
+		}
+
+		resp, err := client.doRequest("POST", "/team/rebalance", req, true)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		require.NoError(t, err)
+		return result
+	}
+
+	// 4. Dry-run показывает план, но ничего не меняет
+	plan := rebalance(true)
+	planMoves := plan["moves"].([]interface{})
+	assert.Len(t, planMoves, 2)
+	for _, move := range planMoves {
+		m := move.(map[string]interface{})
+		assert.Equal(t, "reb_user4", m["to_user_id"])
+	}
+
+	plan2 := rebalance(true)
+	assert.Len(t, plan2["moves"], 2)
+
+	// 5. Применение выполняет те же переносы
+	applied := rebalance(false)
+	assert.Len(t, applied["moves"], 2)
+
+	loadAfter := applied["load_after"].(map[string]interface{})
+	assert.Equal(t, float64(3), loadAfter["reb_user2"])
+	assert.Equal(t, float64(3), loadAfter["reb_user3"])
+	assert.Equal(t, float64(2), loadAfter["reb_user4"])
+
+	// 6. После применения нагрузка уже выровнена
+	plan3 := rebalance(true)
+	assert.Empty(t, plan3["moves"])
+}
+
+// TestEscalationToLead проверяет назначение лида, когда в команде нет кандидатов
+func TestEscalationToLead(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	// 1. Команда из двух человек: после переназначения кандидатов не остаётся
+	teamReq := map[string]interface{}{
+		"team_name": "escalation_team",
+		"members": []map[string]interface{}{
+			{"user_id": "esc_author", "username": "EscAuthor", "is_active": true},
+			{"user_id": "esc_reviewer", "username": "EscReviewer", "is_active": true},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	prReq := map[string]interface{}{
+		"pull_request_id":   "esc_pr",
+		"pull_request_name": "Escalation PR",
+		"author_id":         "esc_author",
+	}
+
+	resp2, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp2.StatusCode)
+
+	reassignReq := map[string]interface{}{
+		"pull_request_id": "esc_pr",
+		"old_user_id":     "esc_reviewer",
+	}
+
+	// 2. Без лидов переназначение невозможно
+	resp3, err := client.doRequest("POST", "/pullRequest/reassign", reassignReq, false)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp3.StatusCode)
+
+	// 3. Назначаем лида команды
+	escalationReq := map[string]interface{}{
+		"team_name":           "escalation_team",
+		"leads":               []string{"e2e_user_2"},
+		"escalation_contacts": []string{},
+	}
+
+	resp4, err := client.doRequest("POST", "/team/escalation", escalationReq, true)
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	assert.Equal(t, http.StatusOK, resp4.StatusCode)
+
+	// 4. Теперь ревью уходит лиду с пометкой об эскалации
+	resp5, err := client.doRequest("POST", "/pullRequest/reassign", reassignReq, false)
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	require.Equal(t, http.StatusOK, resp5.StatusCode)
+
+	var reassignResult map[string]interface{}
+	err = json.NewDecoder(resp5.Body).Decode(&reassignResult)
+	require.NoError(t, err)
+
+	assert.Equal(t, "e2e_user_2", reassignResult["replaced_by"])
+	assert.Equal(t, true, reassignResult["escalated"])
+
+	// 5. Эскалация отражена в истории PR
+	resp6, err := client.httpClient.Get(baseURL + "/pullRequest/history?pull_request_id=esc_pr")
+	require.NoError(t, err)
+	defer resp6.Body.Close()
+	assert.Equal(t, http.StatusOK, resp6.StatusCode)
+
+	var historyResult map[string]interface{}
+	err = json.NewDecoder(resp6.Body).Decode(&historyResult)
+	require.NoError(t, err)
+
+	history := historyResult["history"].([]interface{})
+	require.Len(t, history, 2)
+
+	created := history[0].(map[string]interface{})
+	assert.Equal(t, "PR_CREATED", created["event_type"])
+
+	escalated := history[1].(map[string]interface{})
+	assert.Equal(t, "REVIEWER_REASSIGNED", escalated["event_type"])
+	assert.Equal(t, "esc_reviewer", escalated["old_reviewer_id"])
+	assert.Equal(t, "e2e_user_2", escalated["new_reviewer_id"])
+	assert.Equal(t, true, escalated["escalated"])
+}
+
+func TestReviewRules(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	teamReq := map[string]interface{}{
+		"team_name": "rules_team",
+		"members": []map[string]interface{}{
+			{"user_id": "rule_author", "username": "RuleAuthor", "is_active": true},
+			{"user_id": "rule_conflict", "username": "RuleConflict", "is_active": true},
+			{"user_id": "rule_partner", "username": "RulePartner", "is_active": true},
+			{"user_id": "rule_other", "username": "RuleOther", "is_active": true},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// 1. rule_conflict никогда не ревьюит PR rule_author
+	neverReq := map[string]interface{}{
+		"rule_type":     "NEVER_REVIEWS",
+		"user_id":       "rule_conflict",
+		"other_user_id": "rule_author",
+		"reason":        "same household",
+	}
+
+	resp2, err := client.doRequest("POST", "/reviewRules/create", neverReq, true)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusCreated, resp2.StatusCode)
+
+	var created map[string]interface{}
+	err = json.NewDecoder(resp2.Body).Decode(&created)
+	require.NoError(t, err)
+	neverRule := created["rule"].(map[string]interface{})
+	neverRuleID := neverRule["rule_id"]
+
+	// 2. Повторное правило отклоняется
+	resp3, err := client.doRequest("POST", "/reviewRules/create", neverReq, true)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp3.StatusCode)
+
+	// 3. Противоречащее правило отклоняется
+	contradictReq := map[string]interface{}{
+		"rule_type":     "ALWAYS_PAIR",
+		"user_id":       "rule_author",
+		"other_user_id": "rule_conflict",
+	}
+
+	resp4, err := client.doRequest("POST", "/reviewRules/create", contradictReq, true)
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp4.StatusCode)
+
+	// 4. rule_partner всегда ревьюит PR rule_author
+	pairReq := map[string]interface{}{
+		"rule_type":     "ALWAYS_PAIR",
+		"user_id":       "rule_author",
+		"other_user_id": "rule_partner",
+	}
+
+	resp5, err := client.doRequest("POST", "/reviewRules/create", pairReq, true)
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	require.Equal(t, http.StatusCreated, resp5.StatusCode)
+
+	// 5. Правила без токена недоступны
+	resp6, err := client.doRequest("GET", "/reviewRules/list", nil, false)
+	require.NoError(t, err)
+	defer resp6.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp6.StatusCode)
+
+	// 6. Ревьюверы PR учитывают оба правила
+	prReq := map[string]interface{}{
+		"pull_request_id":   "rules_pr",
+		"pull_request_name": "Rules PR",
+		"author_id":         "rule_author",
+	}
+
+	resp7, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp7.Body.Close()
+	require.Equal(t, http.StatusCreated, resp7.StatusCode)
+
+	var prResult map[string]interface{}
+	err = json.NewDecoder(resp7.Body).Decode(&prResult)
+	require.NoError(t, err)
+
+	pr := prResult["pr"].(map[string]interface{})
+	reviewers := pr["assigned_reviewers"].([]interface{})
+	assert.Contains(t, reviewers, "rule_partner")
+	assert.NotContains(t, reviewers, "rule_conflict")
+
+	// 7. Удаление правила
+	deleteReq := map[string]interface{}{
+		"rule_id": neverRuleID,
+	}
+
+	resp8, err := client.doRequest("POST", "/reviewRules/delete", deleteReq, true)
+	require.NoError(t, err)
+	defer resp8.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp8.StatusCode)
+
+	resp9, err := client.doRequest("POST", "/reviewRules/delete", deleteReq, true)
+	require.NoError(t, err)
+	defer resp9.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp9.StatusCode)
+}
+
+func TestTeamMembership(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	for _, teamReq := range []map[string]interface{}{
+		{
+			"team_name": "membership_team",
+			"members": []map[string]interface{}{
+				{"user_id": "member_author", "username": "MemberAuthor", "is_active": true},
+				{"user_id": "member_rev_1", "username": "MemberRev1", "is_active": true},
+				{"user_id": "member_rev_2", "username": "MemberRev2", "is_active": true},
+			},
+		},
+		{
+			"team_name": "membership_target",
+			"members": []map[string]interface{}{
+				{"user_id": "member_target", "username": "MemberTarget", "is_active": true},
+			},
+		},
+	} {
+		resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	prReq := map[string]interface{}{
+		"pull_request_id":   "membership_pr",
+		"pull_request_name": "Membership PR",
+		"author_id":         "member_author",
+	}
+
+	resp, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// 1. Добавляем нового участника в существующую команду
+	addReq := map[string]interface{}{
+		"team_name": "membership_team",
+		"members": []map[string]interface{}{
+			{"user_id": "member_new", "username": "MemberNew", "is_active": true},
+		},
+	}
+
+	resp2, err := client.doRequest("POST", "/team/addMembers", addReq, true)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusOK, resp2.StatusCode)
+
+	var addResult map[string]interface{}
+	err = json.NewDecoder(resp2.Body).Decode(&addResult)
+	require.NoError(t, err)
+	team := addResult["team"].(map[string]interface{})
+	assert.Len(t, team["members"].([]interface{}), 4)
+
+	// 2. Участника другой команды добавить нельзя
+	conflictReq := map[string]interface{}{
+		"team_name": "membership_team",
+		"members": []map[string]interface{}{
+			{"user_id": "member_target", "username": "MemberTarget", "is_active": true},
+		},
+	}
+
+	resp3, err := client.doRequest("POST", "/team/addMembers", conflictReq, true)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp3.StatusCode)
+
+	// 3. Перевод передаёт ревью PR старой команды новому участнику
+	moveReq := map[string]interface{}{
+		"user_id":          "member_rev_1",
+		"team_name":        "membership_team",
+		"target_team_name": "membership_target",
+	}
+
+	resp4, err := client.doRequest("POST", "/team/moveMember", moveReq, true)
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	require.Equal(t, http.StatusOK, resp4.StatusCode)
+
+	var moveResult map[string]interface{}
+	err = json.NewDecoder(resp4.Body).Decode(&moveResult)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), moveResult["reassigned_prs"])
+	assert.Equal(t, "membership_target", moveResult["to_team"])
+
+	// 4. Исключение последнего свободного ревьювера снимает его с PR
+	removeReq := map[string]interface{}{
+		"team_name": "membership_team",
+		"user_id":   "member_rev_2",
+	}
+
+	resp5, err := client.doRequest("POST", "/team/removeMember", removeReq, true)
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	require.Equal(t, http.StatusOK, resp5.StatusCode)
+
+	var removeResult map[string]interface{}
+	err = json.NewDecoder(resp5.Body).Decode(&removeResult)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), removeResult["unassigned_prs"])
+
+	// 5. Повторное исключение — пользователь уже не в команде
+	resp6, err := client.doRequest("POST", "/team/removeMember", removeReq, true)
+	require.NoError(t, err)
+	defer resp6.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp6.StatusCode)
+
+	// 6. На PR остался только новый участник
+	resp7, err := client.httpClient.Get(baseURL + "/team/get?team_name=membership_team")
+	require.NoError(t, err)
+	defer resp7.Body.Close()
+	require.Equal(t, http.StatusOK, resp7.StatusCode)
+
+	var teamResult map[string]interface{}
+	err = json.NewDecoder(resp7.Body).Decode(&teamResult)
+	require.NoError(t, err)
+	assert.Len(t, teamResult["members"].([]interface{}), 2)
+
+	resp8, err := client.httpClient.Get(baseURL + "/users/getReview?user_id=member_new")
+	require.NoError(t, err)
+	defer resp8.Body.Close()
+	require.Equal(t, http.StatusOK, resp8.StatusCode)
+
+	var reviewResult map[string]interface{}
+	err = json.NewDecoder(resp8.Body).Decode(&reviewResult)
+	require.NoError(t, err)
+	assert.Len(t, reviewResult["pull_requests"].([]interface{}), 1)
+}
+
+func TestUserTransfer(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	for _, teamReq := range []map[string]interface{}{
+		{
+			"team_name": "transfer_src",
+			"members": []map[string]interface{}{
+				{"user_id": "transfer_author", "username": "TransferAuthor", "is_active": true},
+				{"user_id": "transfer_rev_1", "username": "TransferRev1", "is_active": true},
+				{"user_id": "transfer_rev_2", "username": "TransferRev2", "is_active": true},
+				{"user_id": "transfer_rev_3", "username": "TransferRev3", "is_active": true},
+			},
+		},
+		{
+			"team_name": "transfer_dst",
+			"members": []map[string]interface{}{
+				{"user_id": "transfer_dst_user", "username": "TransferDst", "is_active": true},
+			},
+		},
+	} {
+		resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	prReq := map[string]interface{}{
+		"pull_request_id":   "transfer_pr",
+		"pull_request_name": "Transfer PR",
+		"author_id":         "transfer_author",
+	}
+
+	resp, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var prResult map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&prResult)
+	require.NoError(t, err)
+	reviewers := prResult["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+	require.Len(t, reviewers, 2)
+	kept := reviewers[0].(string)
+	reassigned := reviewers[1].(string)
+
+	// 1. Новая команда не может молча забрать участника другой команды
+	stealReq := map[string]interface{}{
+		"team_name": "transfer_thief",
+		"members": []map[string]interface{}{
+			{"user_id": kept, "username": "Stolen", "is_active": true},
+		},
+	}
+
+	resp2, err := client.doRequest("POST", "/team/add", stealReq, false)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp2.StatusCode)
+
+	// 2. Перевод с сохранением ревью
+	keepReq := map[string]interface{}{
+		"user_id":          kept,
+		"target_team_name": "transfer_dst",
+		"review_policy":    "KEEP",
+	}
+
+	resp3, err := client.doRequest("POST", "/users/transfer", keepReq, true)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusOK, resp3.StatusCode)
+
+	var keepResult map[string]interface{}
+	err = json.NewDecoder(resp3.Body).Decode(&keepResult)
+	require.NoError(t, err)
+	assert.Equal(t, "transfer_src", keepResult["from_team"])
+	assert.Equal(t, float64(1), keepResult["kept_prs"])
+	keptHandOffs := keepResult["hand_offs"].([]interface{})
+	require.Len(t, keptHandOffs, 1)
+	assert.Equal(t, "KEPT", keptHandOffs[0].(map[string]interface{})["action"])
+
+	// 3. Перевод с передачей ревью оставшемуся участнику старой команды
+	reassignReq := map[string]interface{}{
+		"user_id":          reassigned,
+		"target_team_name": "transfer_dst",
+		"review_policy":    "REASSIGN",
+	}
+
+	resp4, err := client.doRequest("POST", "/users/transfer", reassignReq, true)
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	require.Equal(t, http.StatusOK, resp4.StatusCode)
+
+	var reassignResult map[string]interface{}
+	err = json.NewDecoder(resp4.Body).Decode(&reassignResult)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), reassignResult["reassigned_prs"])
+	handOffs := reassignResult["hand_offs"].([]interface{})
+	require.Len(t, handOffs, 1)
+	handOff := handOffs[0].(map[string]interface{})
+	assert.Equal(t, "REASSIGNED", handOff["action"])
+	assert.NotEqual(t, kept, handOff["new_reviewer_id"])
+	assert.NotEqual(t, reassigned, handOff["new_reviewer_id"])
+
+	// 4. Неизвестная политика отклоняется
+	badReq := map[string]interface{}{
+		"user_id":          "transfer_rev_3",
+		"target_team_name": "transfer_dst",
+		"review_policy":    "DROP",
+	}
+
+	resp5, err := client.doRequest("POST", "/users/transfer", badReq, true)
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp5.StatusCode)
+
+	// 5. Оба перевода записаны в историю PR
+	resp6, err := client.httpClient.Get(baseURL + "/pullRequest/history?pull_request_id=transfer_pr")
+	require.NoError(t, err)
+	defer resp6.Body.Close()
+	require.Equal(t, http.StatusOK, resp6.StatusCode)
+
+	var historyResult map[string]interface{}
+	err = json.NewDecoder(resp6.Body).Decode(&historyResult)
+	require.NoError(t, err)
+
+	history := historyResult["history"].([]interface{})
+	require.Len(t, history, 3)
+	assert.Equal(t, "REVIEWER_KEPT", history[1].(map[string]interface{})["event_type"])
+	assert.Equal(t, "REVIEWER_REASSIGNED", history[2].(map[string]interface{})["event_type"])
+}
+
+func TestTeamLifecycle(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	teamReq := map[string]interface{}{
+		"team_name": "lifecycle_team",
+		"members": []map[string]interface{}{
+			{"user_id": "lifecycle_author", "username": "LifecycleAuthor", "is_active": true},
+			{"user_id": "lifecycle_rev_1", "username": "LifecycleRev1", "is_active": true},
+			{"user_id": "lifecycle_rev_2", "username": "LifecycleRev2", "is_active": true},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	prReq := map[string]interface{}{
+		"pull_request_id":   "lifecycle_pr_1",
+		"pull_request_name": "Lifecycle PR",
+		"author_id":         "lifecycle_author",
+	}
+
+	resp2, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusCreated, resp2.StatusCode)
+
+	// 1. Переименование переносит участников
+	renameReq := map[string]interface{}{
+		"team_name":     "lifecycle_team",
+		"new_team_name": "lifecycle_renamed",
+	}
+
+	resp3, err := client.doRequest("POST", "/team/rename", renameReq, true)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusOK, resp3.StatusCode)
+
+	var renameResult map[string]interface{}
+	err = json.NewDecoder(resp3.Body).Decode(&renameResult)
+	require.NoError(t, err)
+	renamed := renameResult["team"].(map[string]interface{})
+	assert.Equal(t, "lifecycle_renamed", renamed["team_name"])
+	assert.Len(t, renamed["members"].([]interface{}), 3)
+
+	resp4, err := client.httpClient.Get(baseURL + "/team/get?team_name=lifecycle_team")
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp4.StatusCode)
+
+	// 2. Удаление с открытыми PR отклоняется
+	deleteReq := map[string]interface{}{"team_name": "lifecycle_renamed"}
+
+	resp5, err := client.doRequest("POST", "/team/delete", deleteReq, true)
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp5.StatusCode)
+
+	// 3. Архивная команда только для чтения и не выбирается в ревьюверы
+	archiveReq := map[string]interface{}{"team_name": "lifecycle_renamed"}
+
+	resp6, err := client.doRequest("POST", "/team/archive", archiveReq, true)
+	require.NoError(t, err)
+	defer resp6.Body.Close()
+	require.Equal(t, http.StatusOK, resp6.StatusCode)
+
+	var archiveResult map[string]interface{}
+	err = json.NewDecoder(resp6.Body).Decode(&archiveResult)
+	require.NoError(t, err)
+	assert.Equal(t, true, archiveResult["team"].(map[string]interface{})["archived"])
+
+	addReq := map[string]interface{}{
+		"team_name": "lifecycle_renamed",
+		"members": []map[string]interface{}{
+			{"user_id": "lifecycle_late", "username": "LifecycleLate", "is_active": true},
+		},
+	}
+
+	resp7, err := client.doRequest("POST", "/team/addMembers", addReq, true)
+	require.NoError(t, err)
+	defer resp7.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp7.StatusCode)
+
+	archivedPRReq := map[string]interface{}{
+		"pull_request_id":   "lifecycle_pr_2",
+		"pull_request_name": "Archived PR",
+		"author_id":         "lifecycle_author",
+	}
+
+	resp8, err := client.doRequest("POST", "/pullRequest/create", archivedPRReq, false)
+	require.NoError(t, err)
+	defer resp8.Body.Close()
+	require.Equal(t, http.StatusCreated, resp8.StatusCode)
+
+	var archivedPR map[string]interface{}
+	err = json.NewDecoder(resp8.Body).Decode(&archivedPR)
+	require.NoError(t, err)
+	assert.Empty(t, archivedPR["pr"].(map[string]interface{})["assigned_reviewers"])
+
+	resp9, err := client.doRequest("POST", "/team/unarchive", archiveReq, true)
+	require.NoError(t, err)
+	defer resp9.Body.Close()
+	assert.Equal(t, http.StatusOK, resp9.StatusCode)
+
+	// 4. Принудительное удаление сохраняет пользователей и PR; участник из дополнительного членства
+	// сохраняет основную команду и не считается открепленным
+	otherReq := map[string]interface{}{
+		"team_name": "lifecycle_other",
+		"members": []map[string]interface{}{
+			{"user_id": "lifecycle_guest", "username": "LifecycleGuest", "is_active": true},
+		},
+	}
+
+	respOther, err := client.doRequest("POST", "/team/add", otherReq, false)
+	require.NoError(t, err)
+	defer respOther.Body.Close()
+	require.Equal(t, http.StatusCreated, respOther.StatusCode)
+
+	respGuest, err := client.doRequest("POST", "/team/setMembership", map[string]interface{}{
+		"team_name": "lifecycle_renamed",
+		"user_id":   "lifecycle_guest",
+	}, true)
+	require.NoError(t, err)
+	defer respGuest.Body.Close()
+	require.Equal(t, http.StatusOK, respGuest.StatusCode)
+
+	forceReq := map[string]interface{}{"team_name": "lifecycle_renamed", "force": true}
+
+	resp10, err := client.doRequest("POST", "/team/delete", forceReq, true)
+	require.NoError(t, err)
+	defer resp10.Body.Close()
+	require.Equal(t, http.StatusOK, resp10.StatusCode)
+
+	var deleteResult map[string]interface{}
+	err = json.NewDecoder(resp10.Body).Decode(&deleteResult)
+	require.NoError(t, err)
+	assert.Equal(t, float64(3), deleteResult["detached_users"])
+	assert.Equal(t, float64(2), deleteResult["open_prs"])
+
+	resp11, err := client.httpClient.Get(baseURL + "/pullRequest/history?pull_request_id=lifecycle_pr_1")
+	require.NoError(t, err)
+	defer resp11.Body.Close()
+	assert.Equal(t, http.StatusOK, resp11.StatusCode)
+
+	resp12, err := client.httpClient.Get(baseURL + "/users/getReview?user_id=lifecycle_rev_1")
+	require.NoError(t, err)
+	defer resp12.Body.Close()
+	assert.Equal(t, http.StatusOK, resp12.StatusCode)
+}
+
+func TestTeamHierarchy(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	for _, teamReq := range []map[string]interface{}{
+		{
+			"team_name": "org_root",
+			"members": []map[string]interface{}{
+				{"user_id": "org_root_user", "username": "OrgRootUser", "is_active": true},
+			},
+		},
+		{
+			"team_name":        "org_child",
+			"parent_team_name": "org_root",
+			"members": []map[string]interface{}{
+				{"user_id": "org_child_author", "username": "OrgChildAuthor", "is_active": true},
+				{"user_id": "org_child_rev", "username": "OrgChildRev", "is_active": true},
+			},
+		},
+	} {
+		resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	// 1. Таблица размеров корня наследуется дочерней командой
+	policyReq := map[string]interface{}{
+		"team_name": "org_root",
+		"buckets": []map[string]interface{}{
+			{"size_class": "ANY", "reviewer_count": 2, "sla_hours": 8},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/sizePolicy", policyReq, true)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp2, err := client.httpClient.Get(baseURL + "/team/sizePolicy?team_name=org_child")
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusOK, resp2.StatusCode)
+
+	var policyResult map[string]interface{}
+	err = json.NewDecoder(resp2.Body).Decode(&policyResult)
+	require.NoError(t, err)
+	assert.Equal(t, "org_root", policyResult["inherited_from"])
+
+	// 2. Недостающий ревьювер берётся из родительской команды
+	prReq := map[string]interface{}{
+		"pull_request_id":   "org_child_pr",
+		"pull_request_name": "Child PR",
+		"author_id":         "org_child_author",
+		"lines_added":       5,
+	}
+
+	resp3, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusCreated, resp3.StatusCode)
+
+	var prResult map[string]interface{}
+	err = json.NewDecoder(resp3.Body).Decode(&prResult)
+	require.NoError(t, err)
+	pr := prResult["pr"].(map[string]interface{})
+	assert.Equal(t, "ANY", pr["size_class"])
+	assert.ElementsMatch(t, []interface{}{"org_child_rev", "org_root_user"}, pr["assigned_reviewers"])
+
+	// 3. Дерево команд видно в /team/get
+	resp4, err := client.httpClient.Get(baseURL + "/team/get?team_name=org_child")
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	require.Equal(t, http.StatusOK, resp4.StatusCode)
+
+	var childTeam map[string]interface{}
+	err = json.NewDecoder(resp4.Body).Decode(&childTeam)
+	require.NoError(t, err)
+	assert.Equal(t, "org_root", childTeam["parent_team_name"])
+	assert.Equal(t, []interface{}{"org_root"}, childTeam["ancestors"])
+
+	resp5, err := client.httpClient.Get(baseURL + "/team/get?team_name=org_root")
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	require.Equal(t, http.StatusOK, resp5.StatusCode)
+
+	var rootTeam map[string]interface{}
+	err = json.NewDecoder(resp5.Body).Decode(&rootTeam)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"org_child"}, rootTeam["children"])
+
+	// 4. Циклы в иерархии запрещены
+	cycleReq := map[string]interface{}{
+		"team_name":        "org_root",
+		"parent_team_name": "org_child",
+	}
+
+	resp6, err := client.doRequest("POST", "/team/setParent", cycleReq, true)
+	require.NoError(t, err)
+	defer resp6.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp6.StatusCode)
+
+	// 5. Сводная статистика корня включает PR дочерней команды
+	resp7, err := client.httpClient.Get(baseURL + "/statistics/team?team_name=org_root")
+	require.NoError(t, err)
+	defer resp7.Body.Close()
+	require.Equal(t, http.StatusOK, resp7.StatusCode)
+
+	var stats map[string]interface{}
+	err = json.NewDecoder(resp7.Body).Decode(&stats)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []interface{}{"org_root", "org_child"}, stats["teams"])
+	assert.Equal(t, float64(1), stats["total_prs"])
+	assert.Equal(t, float64(3), stats["total_users"])
+	assert.Equal(t, float64(1), stats["prs_by_team"].(map[string]interface{})["org_child"])
+}
+
+func TestMultiTeamMembership(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	for _, teamReq := range []map[string]interface{}{
+		{
+			"team_name": "mt_core",
+			"members": []map[string]interface{}{
+				{"user_id": "mt_core_author", "username": "MtCoreAuthor", "is_active": true},
+			},
+		},
+		{
+			"team_name": "mt_platform",
+			"members": []map[string]interface{}{
+				{"user_id": "mt_platform_author", "username": "MtPlatformAuthor", "is_active": true},
+				{"user_id": "mt_staff", "username": "MtStaff", "is_active": true},
+			},
+		},
+	} {
+		resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	// 1. Staff-инженер добавляется во вторую команду лидом
+	membershipReq := map[string]interface{}{
+		"team_name":     "mt_core",
+		"user_id":       "mt_staff",
+		"role":          "LEAD",
+		"review_weight": 1.5,
+	}
+
+	resp, err := client.doRequest("POST", "/team/setMembership", membershipReq, true)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var teamResult map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&teamResult)
+	require.NoError(t, err)
+
+	var staff map[string]interface{}
+	for _, m := range teamResult["team"].(map[string]interface{})["members"].([]interface{}) {
+		member := m.(map[string]interface{})
+		if member["user_id"] == "mt_staff" {
+			staff = member
+		}
+	}
+	require.NotNil(t, staff)
+	assert.Equal(t, "LEAD", staff["role"])
+	assert.Equal(t, 1.5, staff["review_weight"])
+	assert.Nil(t, staff["primary"])
+
+	// 2. Некорректная роль отклоняется
+	badReq := map[string]interface{}{
+		"team_name": "mt_core",
+		"user_id":   "mt_staff",
+		"role":      "OWNER",
+	}
+
+	resp2, err := client.doRequest("POST", "/team/setMembership", badReq, true)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp2.StatusCode)
+
+	// 3. PR второй команды получает staff-инженера ревьювером
+	prReq := map[string]interface{}{
+		"pull_request_id":   "mt_core_pr",
+		"pull_request_name": "Core PR",
+		"author_id":         "mt_core_author",
+	}
+
+	resp3, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusCreated, resp3.StatusCode)
+
+	var prResult map[string]interface{}
+	err = json.NewDecoder(resp3.Body).Decode(&prResult)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"mt_staff"}, prResult["pr"].(map[string]interface{})["assigned_reviewers"])
+
+	// Нагрузка в статистике команды учитывает дополнительное членство и его вес
+	statsResp, err := client.httpClient.Get(baseURL + "/statistics/team?team_name=mt_core")
+	require.NoError(t, err)
+	defer statsResp.Body.Close()
+	require.Equal(t, http.StatusOK, statsResp.StatusCode)
+
+	var coreStats map[string]interface{}
+	err = json.NewDecoder(statsResp.Body).Decode(&coreStats)
+	require.NoError(t, err)
+	assert.InDelta(t, 1/1.5, coreStats["weighted_load_by_user"].(map[string]interface{})["MtStaff"], 0.001)
+
+	// 4. Исключение из дополнительной команды снимает её ревью, основная команда сохраняется
+	removeReq := map[string]interface{}{
+		"team_name": "mt_core",
+		"user_id":   "mt_staff",
+	}
+
+	resp4, err := client.doRequest("POST", "/team/removeMember", removeReq, true)
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	require.Equal(t, http.StatusOK, resp4.StatusCode)
+
+	var removeResult map[string]interface{}
+	err = json.NewDecoder(resp4.Body).Decode(&removeResult)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), removeResult["unassigned_prs"])
+
+	resp5, err := client.httpClient.Get(baseURL + "/team/get?team_name=mt_platform")
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	require.Equal(t, http.StatusOK, resp5.StatusCode)
+
+	var platform map[string]interface{}
+	err = json.NewDecoder(resp5.Body).Decode(&platform)
+	require.NoError(t, err)
+
+	primary := false
+	for _, m := range platform["members"].([]interface{}) {
+		member := m.(map[string]interface{})
+		if member["user_id"] == "mt_staff" {
+			primary = member["primary"] == true
+		}
+	}
+	assert.True(t, primary)
+}
+
+func TestReviewWeight(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	teamReq := map[string]interface{}{
+		"team_name": "rw_team",
+		"members": []map[string]interface{}{
+			{"user_id": "rw_author", "username": "RwAuthor", "is_active": true},
+			{"user_id": "rw_full", "username": "RwFull", "is_active": true},
+			{"user_id": "rw_half", "username": "RwHalf", "is_active": true, "review_weight": 0.5},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// 1. Нулевой вес отклоняется
+	badReq := map[string]interface{}{
+		"team_name":     "rw_team",
+		"user_id":       "rw_full",
+		"review_weight": 0,
+	}
+
+	resp2, err := client.doRequest("POST", "/team/setMembership", badReq, true)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp2.StatusCode)
+
+	// 2. PR получает обоих ревьюверов
+	prReq := map[string]interface{}{
+		"pull_request_id":   "rw_pr",
+		"pull_request_name": "Weighted PR",
+		"author_id":         "rw_author",
+	}
+
+	resp3, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusCreated, resp3.StatusCode)
+
+	// 3. Нагрузка участника с половинным весом в статистике удваивается
+	resp4, err := client.httpClient.Get(baseURL + "/statistics/team?team_name=rw_team")
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	require.Equal(t, http.StatusOK, resp4.StatusCode)
+
+	var stats map[string]interface{}
+	err = json.NewDecoder(resp4.Body).Decode(&stats)
+	require.NoError(t, err)
+
+	weighted := stats["weighted_load_by_user"].(map[string]interface{})
+	assert.Equal(t, float64(1), weighted["RwFull"])
+	assert.Equal(t, float64(2), weighted["RwHalf"])
+
+	// 4. После мержа PR перестаёт входить в текущую нагрузку
+	resp5, err := client.doRequest("POST", "/pullRequest/merge", map[string]interface{}{
+		"pull_request_id": "rw_pr",
+	}, false)
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	require.Equal(t, http.StatusOK, resp5.StatusCode)
+
+	resp6, err := client.httpClient.Get(baseURL + "/statistics/team?team_name=rw_team")
+	require.NoError(t, err)
+	defer resp6.Body.Close()
+	require.Equal(t, http.StatusOK, resp6.StatusCode)
+
+	var merged map[string]interface{}
+	err = json.NewDecoder(resp6.Body).Decode(&merged)
+	require.NoError(t, err)
+
+	weighted = merged["weighted_load_by_user"].(map[string]interface{})
+	assert.Equal(t, float64(0), weighted["RwFull"])
+	assert.Equal(t, float64(0), weighted["RwHalf"])
+}
+
+func TestSelectiveActivation(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	teamReq := map[string]interface{}{
+		"team_name": "sa_team",
+		"members": []map[string]interface{}{
+			{"user_id": "sa_author", "username": "SaAuthor", "is_active": true},
+			{"user_id": "sa_rev1", "username": "SaRev1", "is_active": true},
+			{"user_id": "sa_rev2", "username": "SaRev2", "is_active": true},
+			{"user_id": "sa_rev3", "username": "SaRev3", "is_active": true},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	prReq := map[string]interface{}{
+		"pull_request_id":   "sa_pr",
+		"pull_request_name": "Selective PR",
+		"author_id":         "sa_author",
+	}
+
+	resp2, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusCreated, resp2.StatusCode)
+
+	var prResult map[string]interface{}
+	err = json.NewDecoder(resp2.Body).Decode(&prResult)
+	require.NoError(t, err)
+	reviewers := prResult["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+	require.Len(t, reviewers, 2)
+
+	// 1. Деактивация одного ревьювера по списку
+	deactivated := reviewers[0].(string)
+	resp3, err := client.doRequest("POST", "/users/deactivate", map[string]interface{}{
+		"user_ids": []string{deactivated},
+	}, true)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusOK, resp3.StatusCode)
+
+	var deactivateResult map[string]interface{}
+	err = json.NewDecoder(resp3.Body).Decode(&deactivateResult)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), deactivateResult["deactivated_count"])
+	assert.Equal(t, float64(1), deactivateResult["reassigned_prs"])
+
+	handOffs := deactivateResult["hand_offs"].([]interface{})
+	require.Len(t, handOffs, 1)
+	handOff := handOffs[0].(map[string]interface{})
+	assert.Equal(t, "sa_pr", handOff["pull_request_id"])
+	assert.Equal(t, deactivated, handOff["old_reviewer_id"])
+	assert.Equal(t, "REASSIGNED", handOff["action"])
+
+	// 2. Деактивация команды с исключением автора
+	resp4, err := client.doRequest("POST", "/team/deactivateMembers", map[string]interface{}{
+		"team_name":        "sa_team",
+		"exclude_user_ids": []string{"sa_author"},
+	}, true)
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	require.Equal(t, http.StatusOK, resp4.StatusCode)
+
+	var teamResult map[string]interface{}
+	err = json.NewDecoder(resp4.Body).Decode(&teamResult)
+	require.NoError(t, err)
+	assert.Equal(t, float64(2), teamResult["deactivated_count"])
+
+	actions := make(map[string]string)
+	for _, u := range teamResult["users"].([]interface{}) {
+		change := u.(map[string]interface{})
+		actions[change["user_id"].(string)] = change["action"].(string)
+	}
+	assert.Equal(t, "EXCLUDED", actions["sa_author"])
+	assert.Equal(t, "UNCHANGED", actions[deactivated])
+
+	// 3. Реактивация команды
+	resp5, err := client.doRequest("POST", "/team/reactivateMembers", map[string]interface{}{
+		"team_name": "sa_team",
+	}, true)
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	require.Equal(t, http.StatusOK, resp5.StatusCode)
+
+	var reactivateResult map[string]interface{}
+	err = json.NewDecoder(resp5.Body).Decode(&reactivateResult)
+	require.NoError(t, err)
+	assert.Equal(t, float64(3), reactivateResult["reactivated_count"])
+
+	// 4. Неизвестный пользователь откатывает всю операцию
+	resp6, err := client.doRequest("POST", "/users/deactivate", map[string]interface{}{
+		"user_ids": []string{"sa_rev1", "sa_missing"},
+	}, true)
+	require.NoError(t, err)
+	defer resp6.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp6.StatusCode)
+}
+
+func TestDeactivationReport(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	teamReq := map[string]interface{}{
+		"team_name": "dr_team",
+		"members": []map[string]interface{}{
+			{"user_id": "dr_author", "username": "DrAuthor", "is_active": true},
+			{"user_id": "dr_rev", "username": "DrRev", "is_active": true},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	prReq := map[string]interface{}{
+		"pull_request_id":   "dr_pr",
+		"pull_request_name": "Report PR",
+		"author_id":         "dr_author",
+	}
+
+	resp2, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusCreated, resp2.StatusCode)
+
+	// Заменить ревьювера некем: PR попадает в отчёт как UNASSIGNED, строгий режим не откатывает операцию
+	resp3, err := client.doRequest("POST", "/team/deactivateMembers", map[string]interface{}{
+		"team_name":        "dr_team",
+		"exclude_user_ids": []string{"dr_author"},
+		"strict":           true,
+	}, true)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusOK, resp3.StatusCode)
+
+	var result map[string]interface{}
+	err = json.NewDecoder(resp3.Body).Decode(&result)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), result["unassigned_prs"])
+	assert.Equal(t, float64(0), result["failed_prs"])
+
+	handOffs := result["hand_offs"].([]interface{})
+	require.Len(t, handOffs, 1)
+	handOff := handOffs[0].(map[string]interface{})
+	assert.Equal(t, "dr_pr", handOff["pull_request_id"])
+	assert.Equal(t, "dr_rev", handOff["old_reviewer_id"])
+	assert.Equal(t, "UNASSIGNED", handOff["action"])
+	assert.Nil(t, handOff["error_code"])
+}
+
+func TestDeactivationDryRun(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	teamReq := map[string]interface{}{
+		"team_name": "dry_team",
+		"members": []map[string]interface{}{
+			{"user_id": "dry_author", "username": "DryAuthor", "is_active": true},
+			{"user_id": "dry_rev", "username": "DryRev", "is_active": true},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	prReq := map[string]interface{}{
+		"pull_request_id":   "dry_pr",
+		"pull_request_name": "Dry Run PR",
+		"author_id":         "dry_author",
+	}
+
+	resp2, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusCreated, resp2.StatusCode)
+
+	// 1. План показывает PR, который останется без ревьюверов
+	resp3, err := client.doRequest("POST", "/team/deactivateMembers", map[string]interface{}{
+		"team_name": "dry_team",
+		"dry_run":   true,
+	}, true)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusOK, resp3.StatusCode)
+
+	var plan map[string]interface{}
+	err = json.NewDecoder(resp3.Body).Decode(&plan)
+	require.NoError(t, err)
+	assert.Equal(t, true, plan["dry_run"])
+	assert.Equal(t, float64(2), plan["deactivated_count"])
+	assert.Equal(t, []interface{}{"dry_pr"}, plan["unreviewed_prs"])
+
+	// 2. Ничего не изменилось
+	resp4, err := client.httpClient.Get(baseURL + "/team/get?team_name=dry_team")
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	require.Equal(t, http.StatusOK, resp4.StatusCode)
+
+	var team map[string]interface{}
+	err = json.NewDecoder(resp4.Body).Decode(&team)
+	require.NoError(t, err)
+	for _, m := range team["members"].([]interface{}) {
+		assert.Equal(t, true, m.(map[string]interface{})["is_active"])
+	}
+
+	resp5, err := client.httpClient.Get(baseURL + "/users/getReview?user_id=dry_rev")
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	require.Equal(t, http.StatusOK, resp5.StatusCode)
+
+	var reviews map[string]interface{}
+	err = json.NewDecoder(resp5.Body).Decode(&reviews)
+	require.NoError(t, err)
+	assert.Len(t, reviews["pull_requests"].([]interface{}), 1)
+}
+
+func TestSetIsActiveHandOff(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	teamReq := map[string]interface{}{
+		"team_name": "ho_team",
+		"members": []map[string]interface{}{
+			{"user_id": "ho_author", "username": "HoAuthor", "is_active": true},
+			{"user_id": "ho_rev", "username": "HoRev", "is_active": true},
+			{"user_id": "ho_successor", "username": "HoSuccessor", "is_active": false},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	prReq := map[string]interface{}{
+		"pull_request_id":   "ho_pr",
+		"pull_request_name": "Hand-off PR",
+		"author_id":         "ho_author",
+	}
+
+	resp2, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusCreated, resp2.StatusCode)
+
+	// 1. Неактивный преемник отклоняется
+	resp3, err := client.doRequest("POST", "/users/setIsActive", map[string]interface{}{
+		"user_id":      "ho_rev",
+		"is_active":    false,
+		"successor_id": "ho_successor",
+	}, true)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp3.StatusCode)
+
+	resp4, err := client.doRequest("POST", "/users/setIsActive", map[string]interface{}{
+		"user_id":   "ho_successor",
+		"is_active": true,
+	}, true)
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	require.Equal(t, http.StatusOK, resp4.StatusCode)
+
+	// 2. Ревью передаётся преемнику
+	resp5, err := client.doRequest("POST", "/users/setIsActive", map[string]interface{}{
+		"user_id":      "ho_rev",
+		"is_active":    false,
+		"successor_id": "ho_successor",
+	}, true)
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	require.Equal(t, http.StatusOK, resp5.StatusCode)
+
+	var result map[string]interface{}
+	err = json.NewDecoder(resp5.Body).Decode(&result)
+	require.NoError(t, err)
+	assert.Equal(t, false, result["user"].(map[string]interface{})["is_active"])
+
+	handOff := result["hand_off"].(map[string]interface{})
+	assert.Equal(t, float64(1), handOff["reassigned_prs"])
+	prHandOff := handOff["hand_offs"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "ho_pr", prHandOff["pull_request_id"])
+	assert.Equal(t, "ho_successor", prHandOff["new_reviewer_id"])
+}
+
+func TestScheduledActivation(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	teamReq := map[string]interface{}{
+		"team_name": "sched_team",
+		"members": []map[string]interface{}{
+			{"user_id": "sched_user", "username": "SchedUser", "is_active": true},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// 1. Отпуск: деактивация уже наступила, реактивация — в будущем
+	resp2, err := client.doRequest("POST", "/users/scheduleActivation", map[string]interface{}{
+		"user_id":   "sched_user",
+		"is_active": false,
+		"from":      time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+		"until":     time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339),
+	}, true)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusCreated, resp2.StatusCode)
+
+	var scheduled map[string]interface{}
+	err = json.NewDecoder(resp2.Body).Decode(&scheduled)
+	require.NoError(t, err)
+	changes := scheduled["changes"].([]interface{})
+	require.Len(t, changes, 2)
+	reactivation := changes[1].(map[string]interface{})
+	assert.Equal(t, true, reactivation["is_active"])
+	assert.Equal(t, "PENDING", reactivation["status"])
+
+	// 2. Планировщик применяет наступившую деактивацию и пишет аудит
+	var audit map[string]interface{}
+	require.Eventually(t, func() bool {
+		resp3, err := client.doRequest("GET", "/users/activationAudit?user_id=sched_user", nil, true)
+		if err != nil {
+			return false
+		}
+		defer resp3.Body.Close()
+
+		audit = nil
+		if err := json.NewDecoder(resp3.Body).Decode(&audit); err != nil {
+			return false
+		}
+		entries, ok := audit["entries"].([]interface{})
+		return ok && len(entries) == 1
+	}, 10*time.Second, 500*time.Millisecond)
+
+	entry := audit["entries"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "APPLIED", entry["outcome"])
+	assert.Equal(t, false, entry["is_active"])
+
+	// 3. Отмена ожидающей реактивации
+	resp4, err := client.doRequest("POST", "/users/cancelScheduledActivation", map[string]interface{}{
+		"change_id": reactivation["change_id"],
+	}, true)
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	require.Equal(t, http.StatusOK, resp4.StatusCode)
+
+	// Деактивация уже применена, поэтому отменяется только реактивация
+	var cancelled map[string]interface{}
+	err = json.NewDecoder(resp4.Body).Decode(&cancelled)
+	require.NoError(t, err)
+	assert.Len(t, cancelled["changes"], 1)
+
+	resp5, err := client.doRequest("GET", "/users/scheduledActivations?user_id=sched_user&status=PENDING", nil, true)
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	require.Equal(t, http.StatusOK, resp5.StatusCode)
+
+	var pending map[string]interface{}
+	err = json.NewDecoder(resp5.Body).Decode(&pending)
+	require.NoError(t, err)
+	assert.Empty(t, pending["changes"])
+
+	// 4. Повторная отмена невозможна
+	resp6, err := client.doRequest("POST", "/users/cancelScheduledActivation", map[string]interface{}{
+		"change_id": reactivation["change_id"],
+	}, true)
+	require.NoError(t, err)
+	defer resp6.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp6.StatusCode)
+
+	// 5. Отмена начала будущего отпуска отменяет и связанную с ним реактивацию
+	resp7, err := client.doRequest("POST", "/users/scheduleActivation", map[string]interface{}{
+		"user_id":   "sched_user",
+		"is_active": false,
+		"from":      time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339),
+		"until":     time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339),
+	}, true)
+	require.NoError(t, err)
+	defer resp7.Body.Close()
+	require.Equal(t, http.StatusCreated, resp7.StatusCode)
+
+	var vacation map[string]interface{}
+	err = json.NewDecoder(resp7.Body).Decode(&vacation)
+	require.NoError(t, err)
+	vacationChanges := vacation["changes"].([]interface{})
+	require.Len(t, vacationChanges, 2)
+	start := vacationChanges[0].(map[string]interface{})
+	assert.Equal(t, start["change_id"], vacationChanges[1].(map[string]interface{})["reverse_of_id"])
+
+	resp8, err := client.doRequest("POST", "/users/cancelScheduledActivation", map[string]interface{}{
+		"change_id": start["change_id"],
+	}, true)
+	require.NoError(t, err)
+	defer resp8.Body.Close()
+	require.Equal(t, http.StatusOK, resp8.StatusCode)
+
+	cancelled = nil
+	err = json.NewDecoder(resp8.Body).Decode(&cancelled)
+	require.NoError(t, err)
+	assert.Len(t, cancelled["changes"], 2)
+
+	resp9, err := client.doRequest("GET", "/users/scheduledActivations?user_id=sched_user&status=PENDING", nil, true)
+	require.NoError(t, err)
+	defer resp9.Body.Close()
+	require.Equal(t, http.StatusOK, resp9.StatusCode)
+
+	pending = nil
+	err = json.NewDecoder(resp9.Body).Decode(&pending)
+	require.NoError(t, err)
+	assert.Empty(t, pending["changes"])
+}
+
+func TestUserProfile(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	teamReq := map[string]interface{}{
+		"team_name": "profile_team",
+		"members": []map[string]interface{}{
+			{
+				"user_id":      "profile_user",
+				"username":     "ProfileUser",
+				"is_active":    true,
+				"display_name": "Profile User",
+				"email":        "profile@example.com",
+				"timezone":     "Europe/Moscow",
+			},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// 1. Некорректный часовой пояс отклоняется
+	resp2, err := client.doRequest("POST", "/users/update", map[string]interface{}{
+		"user_id":  "profile_user",
+		"timezone": "Mars/Olympus",
+	}, true)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp2.StatusCode)
+
+	// 2. Частичное обновление: email очищается, остальное сохраняется
+	resp3, err := client.doRequest("POST", "/users/update", map[string]interface{}{
+		"user_id":     "profile_user",
+		"email":       "",
+		"chat_handle": "@profile",
+	}, true)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusOK, resp3.StatusCode)
+
+	resp4, err := client.httpClient.Get(baseURL + "/users/get?user_id=profile_user")
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	require.Equal(t, http.StatusOK, resp4.StatusCode)
+
+	var result map[string]interface{}
+	err = json.NewDecoder(resp4.Body).Decode(&result)
+	require.NoError(t, err)
+
+	user := result["user"].(map[string]interface{})
+	assert.Equal(t, "Profile User", user["display_name"])
+	assert.Equal(t, "@profile", user["chat_handle"])
+	assert.Equal(t, "Europe/Moscow", user["timezone"])
+	assert.Nil(t, user["email"])
+}
+
+func TestListUsers(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	teamReq := map[string]interface{}{
+		"team_name": "list_team",
+		"members": []map[string]interface{}{
+			{"user_id": "list_author", "username": "ListAuthor", "is_active": true},
+			{"user_id": "list_rev1", "username": "ListRev1", "is_active": true},
+			{"user_id": "list_rev2", "username": "ListRev2", "is_active": true},
+			{"user_id": "list_idle", "username": "ListIdle", "is_active": false},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	prReq := map[string]interface{}{
+		"pull_request_id":   "list_pr",
+		"pull_request_name": "List PR",
+		"author_id":         "list_author",
+	}
+
+	resp2, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusCreated, resp2.StatusCode)
+
+	// 1. Активные участники команды, первая страница из двух
+	resp3, err := client.httpClient.Get(baseURL + "/users/list?team_name=list_team&is_active=true&limit=2")
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusOK, resp3.StatusCode)
+
+	var page map[string]interface{}
+	err = json.NewDecoder(resp3.Body).Decode(&page)
+	require.NoError(t, err)
+	assert.Equal(t, float64(3), page["total"])
+	assert.Len(t, page["users"].([]interface{}), 2)
+
+	// 2. Поиск по префиксу имени без учёта регистра
+	resp4, err := client.httpClient.Get(baseURL + "/users/list?team_name=list_team&name_prefix=listrev")
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	require.Equal(t, http.StatusOK, resp4.StatusCode)
+
+	var reviewers map[string]interface{}
+	err = json.NewDecoder(resp4.Body).Decode(&reviewers)
+	require.NoError(t, err)
+	assert.Equal(t, float64(2), reviewers["total"])
+	for _, u := range reviewers["users"].([]interface{}) {
+		assert.Equal(t, float64(1), u.(map[string]interface{})["open_reviews"])
+	}
+
+	// 3. Некорректная пагинация
+	resp5, err := client.httpClient.Get(baseURL + "/users/list?limit=1000")
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp5.StatusCode)
+}
+
+func TestOrgSync(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	teamReq := map[string]interface{}{
+		"team_name": "sync_old",
+		"members": []map[string]interface{}{
+			{"user_id": "sync_author", "username": "SyncAuthor", "is_active": true},
+			{"user_id": "sync_mover", "username": "SyncMover", "is_active": true},
+			{"user_id": "sync_gone", "username": "SyncGone", "is_active": true},
+		},
+	}
+
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	org := `
+teams:
+  - name: sync_old
+    members:
+      - user_id: sync_author
+        username: SyncAuthor
+  - name: sync_new
+    members:
+      - user_id: sync_mover
+        username: SyncMover
+        role: LEAD
+      - user_id: sync_fresh
+        username: SyncFresh
+        email: fresh@example.com
+`
+
+	sync := func(dryRun bool) map[string]interface{} {
+		req, err := http.NewRequest("POST", fmt.Sprintf("%s/team/sync?format=yaml&dry_run=%t", baseURL, dryRun), bytes.NewBufferString(org))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+
+		resp, err := client.httpClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return result
+	}
+
+	actionsOf := func(result map[string]interface{}) map[string]int {
+		counts := make(map[string]int)
+		for _, a := range result["actions"].([]interface{}) {
+			counts[a.(map[string]interface{})["action"].(string)]++
+		}
+		return counts
+	}
+
+	// 1. Пробный запуск возвращает план и ничего не меняет
+	plan := sync(true)
+	assert.Equal(t, true, plan["dry_run"])
+	counts := actionsOf(plan)
+	assert.Equal(t, 1, counts["CREATE_TEAM"])
+	assert.Equal(t, 1, counts["CREATE_USER"])
+	assert.Equal(t, 1, counts["MOVE_USER"])
+	assert.Equal(t, 1, counts["DEACTIVATE_USER"])
+
+	resp2, err := client.httpClient.Get(baseURL + "/team/get?team_name=sync_new")
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp2.StatusCode)
+
+	// 2. Применение
+	applied := sync(false)
+	assert.Equal(t, false, applied["dry_run"])
+
+	resp3, err := client.httpClient.Get(baseURL + "/users/get?user_id=sync_mover")
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusOK, resp3.StatusCode)
+
+	var mover map[string]interface{}
+	err = json.NewDecoder(resp3.Body).Decode(&mover)
+	require.NoError(t, err)
+	assert.Equal(t, "sync_new", mover["user"].(map[string]interface{})["team_name"])
+
+	resp4, err := client.httpClient.Get(baseURL + "/users/get?user_id=sync_gone")
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+
+	var gone map[string]interface{}
+	err = json.NewDecoder(resp4.Body).Decode(&gone)
+	require.NoError(t, err)
+	assert.Equal(t, false, gone["user"].(map[string]interface{})["is_active"])
+
+	// 3. Повторная синхронизация ничего не меняет
+	again := sync(false)
+	assert.Empty(t, again["actions"])
+}
+
+func TestSCIM(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	scim := func(method, path string, body interface{}, token string) (int, map[string]interface{}) {
+		var reader io.Reader
+		if body != nil {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(data)
+		}
+
+		req, err := http.NewRequest(method, baseURL+"/scim/v2"+path, reader)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/scim+json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := client.httpClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result map[string]interface{}
+		if resp.StatusCode != http.StatusNoContent {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		}
+		return resp.StatusCode, result
+	}
+
+	// 1. Создание пользователя
+	status, user := scim("POST", "/Users", map[string]interface{}{
+		"schemas":    []string{"urn:ietf:params:scim:schemas:core:2.0:User"},
+		"externalId": "scim_u1",
+		"userName":   "ScimAlice",
+		"emails":     []map[string]interface{}{{"value": "scim.alice@example.com", "primary": true}},
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "scim_u1", user["id"])
+	assert.Equal(t, true, user["active"])
+
+	status, _ = scim("POST", "/Users", map[string]interface{}{"externalId": "scim_u1", "userName": "ScimAlice"}, adminToken)
+	assert.Equal(t, http.StatusConflict, status)
+
+	// 2. Поиск по фильтру
+	status, list := scim("GET", "/Users?filter="+url.QueryEscape(`userName eq "scimalice"`), nil, adminToken)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, float64(1), list["totalResults"])
+
+	// 3. Команда из существующего пользователя
+	status, group := scim("POST", "/Groups", map[string]interface{}{
+		"displayName": "scim_team",
+		"members":     []map[string]string{{"value": "scim_u1"}},
+	}, adminToken)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Len(t, group["members"], 1)
+
+	// Ошибка в одной операции PATCH откатывает и переименование
+	status, _ = scim("PATCH", "/Groups/scim_team", map[string]interface{}{
+		"schemas": []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+		"Operations": []map[string]interface{}{
+			{"op": "replace", "path": "displayName", "value": "scim_team_renamed"},
+			{"op": "add", "path": "members", "value": []map[string]string{{"value": "scim_missing"}}},
+		},
+	}, adminToken)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, group = scim("GET", "/Groups/scim_team", nil, adminToken)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "scim_team", group["displayName"])
+
+	// 4. Деактивация через PATCH
+	status, user = scim("PATCH", "/Users/scim_u1", map[string]interface{}{
+		"schemas":    []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+		"Operations": []map[string]interface{}{{"op": "replace", "path": "active", "value": "False"}},
+	}, adminToken)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, false, user["active"])
+
+	resp, err := client.httpClient.Get(baseURL + "/users/get?user_id=scim_u1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var fetched map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&fetched))
+	assert.Equal(t, false, fetched["user"].(map[string]interface{})["is_active"])
+
+	// 5. Ошибки в формате SCIM
+	status, scimErr := scim("GET", "/Users/scim_missing", nil, adminToken)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, []interface{}{"urn:ietf:params:scim:api:messages:2.0:Error"}, scimErr["schemas"])
+	assert.Equal(t, "404", scimErr["status"])
+
+	status, _ = scim("GET", "/Users", nil, "wrong_token")
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
+func TestGitHubWebhook(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	const secret = "e2e_github_secret"
+
+	deliver := func(event, delivery, fixture, signSecret string) (int, map[string]interface{}) {
+		body, err := os.ReadFile(filepath.Join("testdata", "github", fixture))
+		require.NoError(t, err)
+
+		mac := hmac.New(sha256.New, []byte(signSecret))
+		mac.Write(body)
+
+		req, err := http.NewRequest("POST", baseURL+"/webhooks/github", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-GitHub-Event", event)
+		req.Header.Set("X-GitHub-Delivery", delivery)
+		req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+		resp, err := client.httpClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, result
+	}
+
+	teamReq := map[string]interface{}{
+		"team_name": "github_team",
+		"members": []map[string]interface{}{
+			{"user_id": "gh_author", "username": "GhAuthor", "is_active": true},
+			{"user_id": "gh_rev1", "username": "GhRev1", "is_active": true},
+			{"user_id": "gh_rev2", "username": "GhRev2", "is_active": true},
+		},
+	}
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// 1. Без привязки логина автор не найден, доставка не записывается
+	status, _ := deliver("pull_request", "gh-d1", "pull_request_opened.json", secret)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	resp2, err := client.doRequest("POST", "/vcs/identities", map[string]interface{}{
+		"provider": "github",
+		"login":    "Octo-Author",
+		"user_id":  "gh_author",
+	}, true)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusOK, resp2.StatusCode)
+
+	// 2. Открытие PR создаёт его с ревьюверами, повтор доставки не обрабатывается
+	status, result := deliver("pull_request", "gh-d1", "pull_request_opened.json", secret)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "CREATED", result["outcome"])
+	assert.Equal(t, "github:acme/backend#7", result["pull_request_id"])
+	pr := result["pr"].(map[string]interface{})
+	assert.Equal(t, "gh_author", pr["author_id"])
+	assert.Len(t, pr["assigned_reviewers"], 2)
+
+	_, result = deliver("pull_request", "gh-d1", "pull_request_opened.json", secret)
+	assert.Equal(t, "DUPLICATE", result["outcome"])
+
+	// 3. Неверная подпись отклоняется
+	status, _ = deliver("pull_request", "gh-d2", "pull_request_closed.json", "wrong_secret")
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	// 4. Закрытие, повторное открытие и merge
+	_, result = deliver("pull_request", "gh-d2", "pull_request_closed.json", secret)
+	assert.Equal(t, "CLOSED", result["outcome"])
+	assert.Equal(t, "CLOSED", result["pr"].(map[string]interface{})["status"])
+
+	_, result = deliver("pull_request", "gh-d3", "pull_request_reopened.json", secret)
+	assert.Equal(t, "REOPENED", result["outcome"])
+
+	_, result = deliver("pull_request", "gh-d4", "pull_request_merged.json", secret)
+	assert.Equal(t, "MERGED", result["outcome"])
+	assert.Equal(t, "MERGED", result["pr"].(map[string]interface{})["status"])
+
+	// 5. Черновик создаётся только после ready_for_review
+	_, result = deliver("pull_request", "gh-d5", "pull_request_draft_opened.json", secret)
+	assert.Equal(t, "IGNORED", result["outcome"])
+
+	_, result = deliver("pull_request", "gh-d6", "pull_request_ready_for_review.json", secret)
+	assert.Equal(t, "CREATED", result["outcome"])
+	assert.Equal(t, "github:acme/backend#8", result["pull_request_id"])
+
+	// 6. Прочие события игнорируются
+	_, result = deliver("ping", "gh-d7", "ping.json", secret)
+	assert.Equal(t, "IGNORED", result["outcome"])
+}
+
+func TestGitLabWebhook(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	deliver := func(key, fixture, token string) (int, map[string]interface{}) {
+		body, err := os.ReadFile(filepath.Join("testdata", "gitlab", fixture))
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("POST", baseURL+"/webhooks/gitlab", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+		req.Header.Set("X-Gitlab-Token", token)
+		req.Header.Set("Idempotency-Key", key)
+
+		resp, err := client.httpClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, result
+	}
+
+	mapLogin := func(login string) {
+		resp, err := client.doRequest("POST", "/vcs/identities", map[string]interface{}{
+			"provider": "GITLAB",
+			"login":    login,
+			"user_id":  "gl_author",
+		}, true)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	teamReq := map[string]interface{}{
+		"team_name": "gitlab_team",
+		"members": []map[string]interface{}{
+			{"user_id": "gl_author", "username": "GlAuthor", "is_active": true},
+			{"user_id": "gl_rev1", "username": "GlRev1", "is_active": true},
+			{"user_id": "gl_rev2", "username": "GlRev2", "is_active": true},
+		},
+	}
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	mapLogin("gl.author")
+
+	// 1. Неверный токен отклоняется
+	status, _ := deliver("gl-1", "merge_request_open.json", "wrong_token")
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	// 2. Открытие MR создаёт PR, повтор доставки не обрабатывается
+	status, result := deliver("gl-1", "merge_request_open.json", "e2e_gitlab_token")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "CREATED", result["outcome"])
+	assert.Equal(t, "gitlab:acme/payments/billing#12", result["pull_request_id"])
+	assert.Equal(t, "gl_author", result["pr"].(map[string]interface{})["author_id"])
+
+	_, result = deliver("gl-1", "merge_request_open.json", "e2e_gitlab_token")
+	assert.Equal(t, "DUPLICATE", result["outcome"])
+
+	// 3. Обновление без снятия черновика игнорируется
+	_, result = deliver("gl-2", "merge_request_update_title.json", "e2e_gitlab_token")
+	assert.Equal(t, "IGNORED", result["outcome"])
+
+	// 4. Закрытие, повторное открытие и merge
+	_, result = deliver("gl-3", "merge_request_close.json", "e2e_gitlab_token")
+	assert.Equal(t, "CLOSED", result["outcome"])
+
+	_, result = deliver("gl-4", "merge_request_reopen.json", "e2e_gitlab_token")
+	assert.Equal(t, "REOPENED", result["outcome"])
+
+	_, result = deliver("gl-5", "merge_request_merge.json", "e2e_gitlab_token")
+	assert.Equal(t, "MERGED", result["outcome"])
+
+	// 5. Черновик создаётся при снятии признака; событие вызвал не автор, поэтому автор ищется по числовому id
+	_, result = deliver("gl-6", "merge_request_draft_open.json", "e2e_gitlab_token")
+	assert.Equal(t, "IGNORED", result["outcome"])
+
+	status, _ = deliver("gl-7", "merge_request_update_ready.json", "e2e_gitlab_token")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	mapLogin("4021")
+
+	status, result = deliver("gl-7", "merge_request_update_ready.json", "e2e_gitlab_token")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "CREATED", result["outcome"])
+	assert.Equal(t, "gitlab:acme/payments/billing#13", result["pull_request_id"])
+}
+
+// githubStubCall вызов заглушки GitHub API
+type githubStubCall struct {
+	Method    string
+	Path      string
+	Reviewers []string
+}
+
+func TestGitHubReviewerSync(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	// Заглушка GitHub API: приложение ходит на неё через GITHUB_API_URL, первый POST отвечает 502
+	var (
+		mu    sync.Mutex
+		calls []githubStubCall
+		posts int
+	)
+	stub := &http.Server{
+		Addr: ":9090",
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Reviewers []string `json:"reviewers"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if r.Method == http.MethodPost {
+				posts++
+				if posts == 1 {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+			}
+
+			calls = append(calls, githubStubCall{Method: r.Method, Path: r.URL.Path, Reviewers: body.Reviewers})
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{}`))
+		}),
+	}
+	go func() { _ = stub.ListenAndServe() }()
+	defer stub.Close()
+
+	waitForCall := func(method string, reviewers ...string) {
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, call := range calls {
+				if call.Method == method && call.Path == "/repos/acme/backend/pulls/21/requested_reviewers" &&
+					assert.ObjectsAreEqual(reviewers, call.Reviewers) {
+					return true
+				}
+			}
+			return false
+		}, 20*time.Second, 200*time.Millisecond, "%s %v не дошёл до GitHub", method, reviewers)
+	}
+
+	login := func(userID string) string {
+		return strings.ReplaceAll(userID, "_", "-")
+	}
+
+	teamReq := map[string]interface{}{
+		"team_name": "github_sync_team",
+		"members": []map[string]interface{}{
+			{"user_id": "sync_author", "username": "SyncAuthor", "is_active": true},
+			{"user_id": "sync_rev1", "username": "SyncRev1", "is_active": true},
+			{"user_id": "sync_rev2", "username": "SyncRev2", "is_active": true},
+			{"user_id": "sync_rev3", "username": "SyncRev3", "is_active": true},
+		},
+	}
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	for _, userID := range []string{"sync_author", "sync_rev1", "sync_rev2", "sync_rev3"} {
+		resp, err := client.doRequest("POST", "/vcs/identities", map[string]interface{}{
+			"provider": "github",
+			"login":    login(userID),
+			"user_id":  userID,
+		}, true)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// 1. PR из вебхука: назначенные ревьюверы запрашиваются на GitHub, после 502 — повтор
+	body, err := os.ReadFile(filepath.Join("testdata", "github", "pull_request_opened_sync.json"))
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte("e2e_github_secret"))
+	mac.Write(body)
+
+	req, err := http.NewRequest("POST", baseURL+"/webhooks/github", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", "pull_request")
+	req.Header.Set("X-GitHub-Delivery", "gh-sync-1")
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp2, err := client.httpClient.Do(req)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusOK, resp2.StatusCode)
+
+	var created map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp2.Body).Decode(&created))
+	assert.Equal(t, "CREATED", created["outcome"])
+
+	var reviewers []string
+	for _, reviewer := range created["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{}) {
+		reviewers = append(reviewers, login(reviewer.(string)))
+	}
+	require.Len(t, reviewers, 2)
+
+	waitForCall(http.MethodPost, reviewers...)
+
+	// 2. Переназначение запрашивает нового ревьювера и отзывает старого
+	resp3, err := client.doRequest("POST", "/pullRequest/reassign", map[string]interface{}{
+		"pull_request_id": "github:acme/backend#21",
+		"old_user_id":     strings.ReplaceAll(reviewers[0], "-", "_"),
+	}, false)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusOK, resp3.StatusCode)
+
+	var reassigned map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp3.Body).Decode(&reassigned))
+
+	waitForCall(http.MethodPost, login(reassigned["replaced_by"].(string)))
+	waitForCall(http.MethodDelete, reviewers[0])
+
+	// 3. Журнал отправок: обе отправки выполнены, первая со второй попытки
+	var requests []interface{}
+	require.Eventually(t, func() bool {
+		resp, err := client.doRequest("GET", "/vcs/reviewerRequests?pull_request_id="+url.QueryEscape("github:acme/backend#21"), nil, true)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+
+		var result map[string]interface{}
+		if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&result) != nil {
+			return false
+		}
+
+		requests = result["requests"].([]interface{})
+		for _, request := range requests {
+			if request.(map[string]interface{})["status"] != "SENT" {
+				return false
+			}
+		}
+		return len(requests) == 2
+	}, 10*time.Second, 200*time.Millisecond)
+
+	assert.Equal(t, float64(2), requests[0].(map[string]interface{})["attempts"])
+	assert.Equal(t, float64(1), requests[1].(map[string]interface{})["attempts"])
+
+	// 4. Передача ревью при деактивации тоже доходит до GitHub: ревью возвращается к снятому ранее ревьюверу
+	replacedBy := reassigned["replaced_by"].(string)
+	resp5, err := client.doRequest("POST", "/users/deactivate", map[string]interface{}{
+		"user_ids": []string{replacedBy},
+	}, true)
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	require.Equal(t, http.StatusOK, resp5.StatusCode)
+
+	var deactivated map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp5.Body).Decode(&deactivated))
+	assert.Equal(t, float64(1), deactivated["reassigned_prs"])
+
+	waitForCall(http.MethodPost, reviewers[0])
+	waitForCall(http.MethodDelete, login(replacedBy))
+
+	// 5. Неизвестный статус отклоняется
+	resp4, err := client.doRequest("GET", "/vcs/reviewerRequests?status=DONE", nil, true)
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp4.StatusCode)
+}
+
+// webhookStubRequest вебхук, принятый заглушкой подписчика
+type webhookStubRequest struct {
+	Event     string
+	EventID   string
+	Signature string
+	Payload   map[string]interface{}
+}
+
+func TestOutgoingWebhooks(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	const secret = "e2e_subscriber_secret"
+
+	// Заглушка подписчика: проверяет подпись, первый запрос отвечает 500
+	var (
+		mu       sync.Mutex
+		received []webhookStubRequest
+		hits     int
+	)
+	stub := &http.Server{
+		Addr: ":9091",
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			hits++
+			if hits == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write(body)
+			if r.Header.Get("X-Webhook-Signature-256") != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			var payload map[string]interface{}
+			_ = json.Unmarshal(body, &payload)
+			received = append(received, webhookStubRequest{
+				Event:     r.Header.Get("X-Webhook-Event"),
+				EventID:   r.Header.Get("X-Webhook-Event-Id"),
+				Signature: r.Header.Get("X-Webhook-Signature-256"),
+				Payload:   payload,
+			})
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	}
+	go func() { _ = stub.ListenAndServe() }()
+	defer stub.Close()
+
+	countEvents := func(event string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		count := 0
+		for _, req := range received {
+			if req.Event == event {
+				count++
+			}
+		}
+		return count
+	}
+
+	waitForEvents := func(event string, count int) {
+		require.Eventually(t, func() bool {
+			return countEvents(event) >= count
+		}, 20*time.Second, 200*time.Millisecond, "ожидалось %d событий %s", count, event)
+	}
+
+	teamReq := map[string]interface{}{
+		"team_name": "webhook_team",
+		"members": []map[string]interface{}{
+			{"user_id": "wh_author", "username": "WhAuthor", "is_active": true},
+			{"user_id": "wh_rev1", "username": "WhRev1", "is_active": true},
+			{"user_id": "wh_rev2", "username": "WhRev2", "is_active": true},
+			{"user_id": "wh_rev3", "username": "WhRev3", "is_active": true},
+		},
+	}
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// 1. Неизвестный тип события и URL без схемы отклоняются
+	for _, bad := range []map[string]interface{}{
+		{"url": "http://e2e_tests:9091/hook", "event_types": []string{"pr.unknown"}},
+		{"url": "e2e_tests:9091/hook", "event_types": []string{"pr.created"}},
+	} {
+		resp, err := client.doRequest("POST", "/webhooks/subscriptions", bad, true)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	// 2. Подписка на события PR команды и на деактивацию любых команд
+	resp2, err := client.doRequest("POST", "/webhooks/subscriptions", map[string]interface{}{
+		"url":         "http://e2e_tests:9091/hook",
+		"secret":      secret,
+		"event_types": []string{"pr.created", "reviewer.assigned", "reviewer.reassigned", "pr.merged"},
+		"team_name":   "webhook_team",
+	}, true)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusCreated, resp2.StatusCode)
+
+	var created map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp2.Body).Decode(&created))
+	subscription := created["subscription"].(map[string]interface{})
+	assert.Equal(t, secret, subscription["secret"])
+	subscriptionID := subscription["subscription_id"].(float64)
+
+	resp3, err := client.doRequest("POST", "/webhooks/subscriptions", map[string]interface{}{
+		"url":         "http://e2e_tests:9091/hook",
+		"secret":      secret,
+		"event_types": []string{"team.deactivated"},
+	}, true)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusCreated, resp3.StatusCode)
+
+	resp4, err := client.doRequest("GET", "/webhooks/subscriptions", nil, true)
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+
+	var list map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp4.Body).Decode(&list))
+	for _, item := range list["subscriptions"].([]interface{}) {
+		assert.Nil(t, item.(map[string]interface{})["secret"], "секрет не возвращается в списке")
+	}
+
+	// 3. Создание PR: pr.created и reviewer.assigned на каждого ревьювера, первая доставка повторяется после 500
+	resp5, err := client.doRequest("POST", "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "wh_pr_1",
+		"pull_request_name": "Webhook PR",
+		"author_id":         "wh_author",
+	}, false)
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	require.Equal(t, http.StatusCreated, resp5.StatusCode)
+
+	var prResult map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp5.Body).Decode(&prResult))
+	reviewers := prResult["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+	require.NotEmpty(t, reviewers)
+
+	waitForEvents("pr.created", 1)
+	waitForEvents("reviewer.assigned", len(reviewers))
+
+	// События PR приходят по порядку: назначения ждут повтора pr.created
+	mu.Lock()
+	assert.Equal(t, "pr.created", received[0].Event)
+	mu.Unlock()
+
+	// 4. Переназначение и merge
+	resp6, err := client.doRequest("POST", "/pullRequest/reassign", map[string]interface{}{
+		"pull_request_id": "wh_pr_1",
+		"old_user_id":     reviewers[0],
+	}, false)
+	require.NoError(t, err)
+	defer resp6.Body.Close()
+	require.Equal(t, http.StatusOK, resp6.StatusCode)
+
+	waitForEvents("reviewer.reassigned", 1)
+
+	resp7, err := client.doRequest("POST", "/pullRequest/merge", map[string]interface{}{
+		"pull_request_id": "wh_pr_1",
+	}, false)
+	require.NoError(t, err)
+	defer resp7.Body.Close()
+	require.Equal(t, http.StatusOK, resp7.StatusCode)
+
+	waitForEvents("pr.merged", 1)
+
+	mu.Lock()
+	for _, req := range received {
+		if req.Event == "pr.merged" {
+			assert.Equal(t, "wh_pr_1", req.Payload["pull_request_id"])
+			assert.Equal(t, "webhook_team", req.Payload["team_name"])
+			assert.Equal(t, "MERGED", req.Payload["data"].(map[string]interface{})["status"])
+		}
+	}
+	mu.Unlock()
+
+	// 5. Деактивация команды
+	resp8, err := client.doRequest("POST", "/team/deactivateMembers", map[string]interface{}{
+		"team_name":        "webhook_team",
+		"exclude_user_ids": []string{"wh_author"},
+	}, true)
+	require.NoError(t, err)
+	defer resp8.Body.Close()
+	require.Equal(t, http.StatusOK, resp8.StatusCode)
+
+	waitForEvents("team.deactivated", 1)
+
+	// 6. Журнал доставок: первая доставка прошла со второй попытки
+	path := fmt.Sprintf("/webhooks/deliveries?subscription_id=%d", int64(subscriptionID))
+	resp9, err := client.doRequest("GET", path, nil, true)
+	require.NoError(t, err)
+	defer resp9.Body.Close()
+	require.Equal(t, http.StatusOK, resp9.StatusCode)
+
+	var deliveries map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp9.Body).Decode(&deliveries))
+	items := deliveries["deliveries"].([]interface{})
+	require.Len(t, items, 3+len(reviewers))
+
+	first := items[len(items)-1].(map[string]interface{})
+	assert.Equal(t, "pr.created", first["event_type"])
+	assert.Equal(t, "DELIVERED", first["status"])
+	assert.Equal(t, float64(2), first["attempts"])
+
+	// 7. Повторная доставка отправляет то же событие ещё раз
+	before := countEvents("pr.created")
+	resp10, err := client.doRequest("POST", "/webhooks/deliveries/redeliver", map[string]interface{}{
+		"delivery_id": first["delivery_id"],
+	}, true)
+	require.NoError(t, err)
+	defer resp10.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp10.StatusCode)
+
+	var redelivered map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp10.Body).Decode(&redelivered))
+	assert.NotEqual(t, first["delivery_id"], redelivered["delivery"].(map[string]interface{})["delivery_id"])
+	assert.Equal(t, first["event_id"], redelivered["delivery"].(map[string]interface{})["event_id"])
+
+	waitForEvents("pr.created", before+1)
+
+	resp11, err := client.doRequest("POST", "/webhooks/deliveries/redeliver", map[string]interface{}{
+		"delivery_id": 999999999,
+	}, true)
+	require.NoError(t, err)
+	defer resp11.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp11.StatusCode)
+}
+
+func TestOutbox(t *testing.T) {
+	waitForService(t)
+	client := NewClient()
+
+	teamReq := map[string]interface{}{
+		"team_name": "outbox_team",
+		"members": []map[string]interface{}{
+			{"user_id": "ob_author", "username": "ObAuthor", "is_active": true},
+			{"user_id": "ob_rev1", "username": "ObRev1", "is_active": true},
+			{"user_id": "ob_rev2", "username": "ObRev2", "is_active": true},
+			{"user_id": "ob_rev3", "username": "ObRev3", "is_active": true},
+		},
+	}
+	resp, err := client.doRequest("POST", "/team/add", teamReq, false)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	prReq := map[string]interface{}{
+		"pull_request_id":   "ob_pr_1",
+		"pull_request_name": "Outbox PR",
+		"author_id":         "ob_author",
+	}
+	resp2, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusCreated, resp2.StatusCode)
+
+	var created map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp2.Body).Decode(&created))
+	reviewers := created["pr"].(map[string]interface{})["assigned_reviewers"].([]interface{})
+	require.NotEmpty(t, reviewers)
+
+	// Откатившаяся операция не оставляет событий
+	resp3, err := client.doRequest("POST", "/pullRequest/create", prReq, false)
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+	require.Equal(t, http.StatusConflict, resp3.StatusCode)
+
+	resp4, err := client.doRequest("POST", "/pullRequest/reassign", map[string]interface{}{
+		"pull_request_id": "ob_pr_1",
+		"old_user_id":     reviewers[0],
+	}, false)
+	require.NoError(t, err)
+	defer resp4.Body.Close()
+	require.Equal(t, http.StatusOK, resp4.StatusCode)
+
+	resp5, err := client.doRequest("POST", "/pullRequest/merge", map[string]interface{}{
+		"pull_request_id": "ob_pr_1",
+	}, false)
+	require.NoError(t, err)
+	defer resp5.Body.Close()
+	require.Equal(t, http.StatusOK, resp5.StatusCode)
+
+	// События PR публикуются всеми получателями в порядке записи
+	var messages []interface{}
+	require.Eventually(t, func() bool {
+		resp, err := client.doRequest("GET", "/outbox/messages?pull_request_id=ob_pr_1", nil, true)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+
+		var result map[string]interface{}
+		if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&result) != nil {
+			return false
+		}
+
+		messages = result["messages"].([]interface{})
+		for _, message := range messages {
+			if message.(map[string]interface{})["status"] != "PUBLISHED" {
+				return false
+			}
+		}
+		return len(messages) > 0
+	}, 20*time.Second, 200*time.Millisecond)
+
+	expected := []string{"pr.created"}
+	for range reviewers {
+		expected = append(expected, "reviewer.assigned")
+	}
+	expected = append(expected, "reviewer.reassigned", "pr.merged")
+
+	require.Len(t, messages, len(expected))
+	var lastID float64
+	for i, item := range messages {
+		message := item.(map[string]interface{})
+		assert.Equal(t, expected[i], message["event_type"])
+		assert.Equal(t, "outbox_team", message["team_name"])
+		assert.ElementsMatch(t, []interface{}{"webhook", "log"}, message["published_sinks"])
+		assert.Greater(t, message["message_id"].(float64), lastID)
+		lastID = message["message_id"].(float64)
+	}
+
+	resp6, err := client.doRequest("GET", "/outbox/messages?status=DONE", nil, true)
+	require.NoError(t, err)
+	defer resp6.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp6.StatusCode)
+
+	// Исчерпавшие попытки события доступны по статусу FAILED
+	resp7, err := client.doRequest("GET", "/outbox/messages?pull_request_id=ob_pr_1&status=FAILED", nil, true)
+	require.NoError(t, err)
+	defer resp7.Body.Close()
+	require.Equal(t, http.StatusOK, resp7.StatusCode)
+
+	var failed map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp7.Body).Decode(&failed))
+	assert.Empty(t, failed["messages"])
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 )
@@ -18,7 +19,64 @@ type Config struct {
 
 	AdminToken string `envconfig:"ADMIN_TOKEN" required:"true"`
 
+	// SCIMToken токен клиента SCIM; если не задан, используется AdminToken
+	SCIMToken string `envconfig:"SCIM_TOKEN"`
+
+	// GitHubWebhookSecret секрет вебхука GitHub; без него /webhooks/github не регистрируется
+	GitHubWebhookSecret string `envconfig:"GITHUB_WEBHOOK_SECRET"`
+
+	// GitLabWebhookToken секретный токен вебхука GitLab; без него /webhooks/gitlab не регистрируется
+	GitLabWebhookToken string `envconfig:"GITLAB_WEBHOOK_TOKEN"`
+
+	// GitHubToken токен GitHub API для запроса ревьюверов; без него ревьюверы на GitHub не отправляются
+	GitHubToken string `envconfig:"GITHUB_TOKEN"`
+
+	// GitHubAPIURL базовый адрес GitHub API (GitHub Enterprise или тестовая заглушка)
+	GitHubAPIURL string `envconfig:"GITHUB_API_URL" default:"https://api.github.com"`
+
+	// VCSSyncInterval период отправки ревьюверов на хостинг кода
+	VCSSyncInterval time.Duration `envconfig:"VCS_SYNC_INTERVAL" default:"10s"`
+
+	// VCSSyncRetryBackoff пауза перед первым повтором отправки; удваивается с каждой попыткой
+	VCSSyncRetryBackoff time.Duration `envconfig:"VCS_SYNC_RETRY_BACKOFF" default:"30s"`
+
+	// VCSSyncMaxAttempts число попыток отправки, после которого запрос получает статус FAILED
+	VCSSyncMaxAttempts int `envconfig:"VCS_SYNC_MAX_ATTEMPTS" default:"5"`
+
+	// WebhookDeliveryInterval период отправки исходящих вебхуков
+	WebhookDeliveryInterval time.Duration `envconfig:"WEBHOOK_DELIVERY_INTERVAL" default:"5s"`
+
+	// WebhookRetryBackoff пауза перед первым повтором доставки; удваивается с каждой попыткой
+	WebhookRetryBackoff time.Duration `envconfig:"WEBHOOK_RETRY_BACKOFF" default:"30s"`
+
+	// WebhookMaxAttempts число попыток доставки, после которого она получает статус FAILED
+	WebhookMaxAttempts int `envconfig:"WEBHOOK_MAX_ATTEMPTS" default:"8"`
+
+	// OutboxSinks получатели событий из outbox: webhook, log, redis
+	OutboxSinks []string `envconfig:"OUTBOX_SINKS" default:"webhook"`
+
+	// OutboxDispatchInterval период публикации событий из outbox
+	OutboxDispatchInterval time.Duration `envconfig:"OUTBOX_DISPATCH_INTERVAL" default:"1s"`
+
+	// OutboxRetryBackoff пауза перед первым повтором публикации; удваивается с каждой попыткой
+	OutboxRetryBackoff time.Duration `envconfig:"OUTBOX_RETRY_BACKOFF" default:"5s"`
+
+	// OutboxMaxAttempts число попыток публикации, после которого событие получает статус FAILED
+	OutboxMaxAttempts int `envconfig:"OUTBOX_MAX_ATTEMPTS" default:"10"`
+
+	// OutboxRedisAddr адрес Redis для получателя redis
+	OutboxRedisAddr string `envconfig:"OUTBOX_REDIS_ADDR"`
+
+	// OutboxRedisPassword пароль Redis; пустой отключает AUTH
+	OutboxRedisPassword string `envconfig:"OUTBOX_REDIS_PASSWORD"`
+
+	// OutboxRedisStream поток Redis, в который публикуются события
+	OutboxRedisStream string `envconfig:"OUTBOX_REDIS_STREAM" default:"pr-reviewer-events"`
+
 	LogLevel string `envconfig:"LOG_LEVEL" default:"info"`
+
+	// ActivationSchedulerInterval период проверки запланированных изменений активности
+	ActivationSchedulerInterval time.Duration `envconfig:"ACTIVATION_SCHEDULER_INTERVAL" default:"1m"`
 }
 
 // Load загружает конфигурацию из переменных окружения
@@ -30,6 +88,14 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
+// GetSCIMToken возвращает токен для эндпоинтов SCIM
+func (c *Config) GetSCIMToken() string {
+	if c.SCIMToken != "" {
+		return c.SCIMToken
+	}
+	return c.AdminToken
+}
+
 // GetDSN возвращает строку подключения к базе данных
 func (c *Config) GetDSN() string {
 	return fmt.Sprintf(
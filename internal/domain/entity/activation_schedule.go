@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+// ActivationChangeStatus статус запланированного изменения активности
+type ActivationChangeStatus string
+
+const (
+	ActivationChangePending   ActivationChangeStatus = "PENDING"
+	ActivationChangeApplied   ActivationChangeStatus = "APPLIED"
+	ActivationChangeFailed    ActivationChangeStatus = "FAILED"
+	ActivationChangeCancelled ActivationChangeStatus = "CANCELLED"
+)
+
+// ScheduledActivationChange запланированное изменение активности пользователя.
+// HandOffReviews и SuccessorID применяются только при деактивации.
+// ReverseOfID связывает обратное изменение (until) с исходным; 0 — изменение без пары.
+type ScheduledActivationChange struct {
+	ID             int64
+	UserID         string
+	IsActive       bool
+	ApplyAt        time.Time
+	HandOffReviews bool
+	SuccessorID    string
+	ReverseOfID    int64
+	Status         ActivationChangeStatus
+	CreatedAt      time.Time
+	ProcessedAt    *time.Time
+}
+
+// ActivationAuditEntry запись аудита о применении запланированного изменения активности
+type ActivationAuditEntry struct {
+	ID            int64
+	ChangeID      int64
+	UserID        string
+	IsActive      bool
+	Outcome       ActivationChangeStatus
+	ReassignedPRs int
+	UnassignedPRs int
+	FailedPRs     int
+	ErrorCode     string
+	ErrorMessage  string
+	CreatedAt     time.Time
+}
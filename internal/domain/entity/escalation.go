@@ -0,0 +1,17 @@
+package entity
+
+type EscalationRole string
+
+const (
+	EscalationRoleLead       EscalationRole = "LEAD"
+	EscalationRoleEscalation EscalationRole = "ESCALATION"
+)
+
+// EscalationContact лид или контакт эскалации команды.
+// Используется как ревьювер последней надежды, когда в команде не осталось кандидатов.
+type EscalationContact struct {
+	TeamName string
+	UserID   string
+	Role     EscalationRole
+	Position int
+}
@@ -0,0 +1,59 @@
+package entity
+
+import "time"
+
+// EventType тип доменного события
+type EventType string
+
+const (
+	EventPRCreated          EventType = "pr.created"
+	EventReviewerAssigned   EventType = "reviewer.assigned"
+	EventReviewerReassigned EventType = "reviewer.reassigned"
+	EventPRMerged           EventType = "pr.merged"
+	EventTeamDeactivated    EventType = "team.deactivated"
+)
+
+// IsValid сообщает, что тип события поддерживается
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventPRCreated, EventReviewerAssigned, EventReviewerReassigned, EventPRMerged, EventTeamDeactivated:
+		return true
+	default:
+		return false
+	}
+}
+
+// DomainEvent событие, происходящее при изменении PR или команды.
+// TeamName — команда автора PR или деактивированная команда; PullRequestID пуст для событий команд.
+type DomainEvent struct {
+	ID            string
+	Type          EventType
+	TeamName      string
+	PullRequestID string
+	OccurredAt    time.Time
+	Data          map[string]interface{}
+}
+
+// OutboxStatus статус события в outbox
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "PENDING"
+	OutboxPublished OutboxStatus = "PUBLISHED"
+	// OutboxFailed событие исчерпало попытки и больше не задерживает события своего PR
+	OutboxFailed OutboxStatus = "FAILED"
+)
+
+// OutboxMessage событие, записанное в outbox в транзакции изменения.
+// PublishedSinks — получатели, уже принявшие событие; при повторе они пропускаются.
+type OutboxMessage struct {
+	ID             int64
+	Event          DomainEvent
+	Status         OutboxStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	PublishedSinks []string
+	LastError      string
+	CreatedAt      time.Time
+	PublishedAt    *time.Time
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+type PRHistoryEvent string
+
+const (
+	PRHistoryCreated            PRHistoryEvent = "PR_CREATED"
+	PRHistoryMerged             PRHistoryEvent = "PR_MERGED"
+	PRHistoryClosed             PRHistoryEvent = "PR_CLOSED"
+	PRHistoryReopened           PRHistoryEvent = "PR_REOPENED"
+	PRHistoryReviewerReassigned PRHistoryEvent = "REVIEWER_REASSIGNED"
+	PRHistoryReviewerRemoved    PRHistoryEvent = "REVIEWER_REMOVED"
+	PRHistoryReviewerKept       PRHistoryEvent = "REVIEWER_KEPT"
+)
+
+// PRHistoryEntry запись в истории PR.
+// Escalated отмечает назначение лида или контакта эскалации вместо обычного кандидата.
+type PRHistoryEntry struct {
+	ID            int64
+	PullRequestID string
+	EventType     PRHistoryEvent
+	OldReviewerID string
+	NewReviewerID string
+	Escalated     bool
+	Reason        string
+	CreatedAt     time.Time
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+type TeamRole string
+
+const (
+	TeamRoleMember TeamRole = "MEMBER"
+	TeamRoleLead   TeamRole = "LEAD"
+)
+
+// MaxReviewWeight верхняя граница веса участника при выборе ревьюверов
+const MaxReviewWeight = 10
+
+// IsValid сообщает, что роль известна; пустая роль означает MEMBER
+func (r TeamRole) IsValid() bool {
+	switch r {
+	case "", TeamRoleMember, TeamRoleLead:
+		return true
+	default:
+		return false
+	}
+}
+
+// TeamMembership членство пользователя в команде.
+// Основная команда пользователя (User.TeamName) всегда есть среди его членств.
+// ReviewWeight равный nil означает вес по умолчанию.
+type TeamMembership struct {
+	TeamName     string
+	UserID       string
+	Role         TeamRole
+	ReviewWeight *float64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -7,6 +7,7 @@ type PRStatus string
 const (
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
 )
 
 type PullRequest struct {
@@ -15,6 +16,9 @@ type PullRequest struct {
 	AuthorID          string
 	Status            PRStatus
 	AssignedReviewers []string
+	Size              PRSize
+	SizeClass         string
+	ReviewDueAt       *time.Time
 	CreatedAt         time.Time
 	MergedAt          *time.Time
 }
@@ -24,4 +28,5 @@ type PullRequestShort struct {
 	PullRequestName string
 	AuthorID        string
 	Status          PRStatus
+	SizeClass       string
 }
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+type ReviewRuleType string
+
+const (
+	// ReviewRuleNeverReviews UserID никогда не ревьюит PR автора OtherUserID
+	ReviewRuleNeverReviews ReviewRuleType = "NEVER_REVIEWS"
+	// ReviewRuleAlwaysPair UserID и OtherUserID всегда ревьюят PR друг друга
+	ReviewRuleAlwaysPair ReviewRuleType = "ALWAYS_PAIR"
+)
+
+// ReviewRule правило конфликта интересов или обязательной пары при выборе ревьюверов
+type ReviewRule struct {
+	ID          int64
+	Type        ReviewRuleType
+	UserID      string
+	OtherUserID string
+	Reason      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
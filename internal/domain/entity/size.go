@@ -0,0 +1,37 @@
+package entity
+
+// PRSize описывает объём изменений в PR
+type PRSize struct {
+	LinesAdded   int
+	LinesDeleted int
+	FilesChanged int
+}
+
+// TotalLines возвращает суммарное количество изменённых строк
+func (s PRSize) TotalLines() int {
+	return s.LinesAdded + s.LinesDeleted
+}
+
+// IsEmpty сообщает, что размер PR не был передан
+func (s PRSize) IsEmpty() bool {
+	return s.LinesAdded == 0 && s.LinesDeleted == 0 && s.FilesChanged == 0
+}
+
+// SizeBucket описывает класс размера PR и связанные с ним правила ревью.
+// MaxLines и MaxFiles равные nil означают отсутствие ограничения.
+type SizeBucket struct {
+	SizeClass     string
+	MaxLines      *int
+	MaxFiles      *int
+	ReviewerCount int
+	SLAHours      int
+}
+
+// SizePolicy таблица классов размера, действующая для команды.
+// InheritedFrom — предок, чья таблица унаследована; IsDefault — таблицы нет ни у команды, ни у предков.
+type SizePolicy struct {
+	TeamName      string
+	Buckets       []*SizeBucket
+	InheritedFrom string
+	IsDefault     bool
+}
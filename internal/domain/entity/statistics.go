@@ -1,12 +1,37 @@
 package entity
 
+// Statistics общая статистика системы.
+// WeightedLoadByUser — открытые ревью пользователя, делённые на его вес ревью в основной команде.
 type Statistics struct {
-	TotalPRs          int            `json:"total_prs"`
-	OpenPRs           int            `json:"open_prs"`
-	MergedPRs         int            `json:"merged_prs"`
-	AssignmentsByUser map[string]int `json:"assignments_by_user"`
-	AssignmentsByPR   map[string]int `json:"assignments_by_pr"`
-	TotalTeams        int            `json:"total_teams"`
-	TotalUsers        int            `json:"total_users"`
-	ActiveUsers       int            `json:"active_users"`
+	TotalPRs           int                `json:"total_prs"`
+	OpenPRs            int                `json:"open_prs"`
+	MergedPRs          int                `json:"merged_prs"`
+	AssignmentsByUser  map[string]int     `json:"assignments_by_user"`
+	WeightedLoadByUser map[string]float64 `json:"weighted_load_by_user"`
+	AssignmentsByPR    map[string]int     `json:"assignments_by_pr"`
+	PRsBySizeClass     map[string]int     `json:"prs_by_size_class"`
+	BotPRs             int                `json:"bot_prs"`
+	OpenBotPRs         int                `json:"open_bot_prs"`
+	BotPRsByAuthor     map[string]int     `json:"bot_prs_by_author"`
+	TotalTeams         int                `json:"total_teams"`
+	TotalUsers         int                `json:"total_users"`
+	ActiveUsers        int                `json:"active_users"`
+	TotalBots          int                `json:"total_bots"`
+}
+
+// TeamStatistics сводная статистика по команде и всем её потомкам.
+// WeightedLoadByUser — открытые ревью участников команд поддерева (включая дополнительные членства),
+// делённые на вес ревью в запрошенной команде.
+type TeamStatistics struct {
+	TeamName           string             `json:"team_name"`
+	Teams              []string           `json:"teams"`
+	TotalPRs           int                `json:"total_prs"`
+	OpenPRs            int                `json:"open_prs"`
+	MergedPRs          int                `json:"merged_prs"`
+	PRsByTeam          map[string]int     `json:"prs_by_team"`
+	PRsBySizeClass     map[string]int     `json:"prs_by_size_class"`
+	AssignmentsByUser  map[string]int     `json:"assignments_by_user"`
+	WeightedLoadByUser map[string]float64 `json:"weighted_load_by_user"`
+	TotalUsers         int                `json:"total_users"`
+	ActiveUsers        int                `json:"active_users"`
 }
@@ -3,18 +3,37 @@ package entity
 import "time"
 
 type Team struct {
-	TeamName  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	TeamName       string
+	ParentTeamName string
+	ArchivedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
+// IsArchived сообщает, что команда в архиве: только чтение, не участвует в выборе ревьюверов
+func (t *Team) IsArchived() bool {
+	return t.ArchivedAt != nil
+}
+
+// TeamMember участник команды. Primary отмечает, что команда основная для пользователя.
 type TeamMember struct {
 	UserID   string
 	Username string
 	IsActive bool
+	Kind     UserKind
+	UserProfile
+	Role         TeamRole
+	ReviewWeight *float64
+	Primary      bool
 }
 
+// TeamWithMembers команда с участниками и положением в иерархии.
+// Ancestors упорядочены от корня к родителю, Children — прямые дочерние команды.
 type TeamWithMembers struct {
-	TeamName string
-	Members  []TeamMember
+	TeamName       string
+	ParentTeamName string
+	Ancestors      []string
+	Children       []string
+	Archived       bool
+	Members        []TeamMember
 }
@@ -1,12 +1,110 @@
 package entity
 
-import "time"
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"time"
+	"unicode/utf8"
+)
 
+type UserKind string
+
+const (
+	UserKindHuman UserKind = "HUMAN"
+	UserKindBot   UserKind = "BOT"
+)
+
+// User пользователь. TeamName — основная команда; остальные команды хранятся в членствах.
 type User struct {
-	UserID    string
-	Username  string
-	TeamName  string
-	IsActive  bool
+	UserID   string
+	Username string
+	TeamName string
+	IsActive bool
+	Kind     UserKind
+	UserProfile
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// UserProfile необязательные данные профиля; пустое поле означает «не задано».
+// Timezone — имя часового пояса IANA, например Europe/Moscow.
+type UserProfile struct {
+	DisplayName string
+	Email       string
+	ChatHandle  string
+	Timezone    string
+}
+
+// Merge возвращает профиль, в котором непустые поля update заменяют текущие
+func (p UserProfile) Merge(update UserProfile) UserProfile {
+	if update.DisplayName != "" {
+		p.DisplayName = update.DisplayName
+	}
+	if update.Email != "" {
+		p.Email = update.Email
+	}
+	if update.ChatHandle != "" {
+		p.ChatHandle = update.ChatHandle
+	}
+	if update.Timezone != "" {
+		p.Timezone = update.Timezone
+	}
+	return p
+}
+
+// IsBot сообщает, что пользователь является ботом или сервисным аккаунтом
+func (u *User) IsBot() bool {
+	return u.Kind == UserKindBot
+}
+
+// maxProfileFieldLength верхняя граница длины текстовых полей профиля
+const maxProfileFieldLength = 255
+
+// chatHandlePattern допустимый ник в чате: необязательный @ и до 64 букв, цифр, точек, дефисов и подчёркиваний
+var chatHandlePattern = regexp.MustCompile(`^@?[A-Za-z0-9._-]{1,64}$`)
+
+// Validate проверяет заданные поля профиля
+func (p UserProfile) Validate() error {
+	if utf8.RuneCountInString(p.DisplayName) > maxProfileFieldLength {
+		return errors.New("display_name must be at most 255 characters")
+	}
+
+	if p.Email != "" {
+		addr, err := mail.ParseAddress(p.Email)
+		if err != nil || addr.Address != p.Email || len(p.Email) > maxProfileFieldLength {
+			return errors.New("email must be a valid address")
+		}
+	}
+
+	if p.ChatHandle != "" && !chatHandlePattern.MatchString(p.ChatHandle) {
+		return errors.New("chat_handle must be up to 64 letters, digits, '.', '_' or '-', optionally prefixed with '@'")
+	}
+
+	if p.Timezone != "" {
+		if _, err := time.LoadLocation(p.Timezone); err != nil || p.Timezone == "Local" {
+			return errors.New("timezone must be an IANA time zone name, e.g. Europe/Moscow")
+		}
+	}
+
+	return nil
+}
+
+// UserWithOpenReviews пользователь с числом открытых PR, где он назначен ревьювером
+type UserWithOpenReviews struct {
+	User
+	OpenReviews int
+}
+
+// UserFilter условия выборки пользователей; пустые поля не фильтруют.
+// TeamName учитывает все команды пользователя, NamePrefix сравнивается с username и display name,
+// Username и Email — точное совпадение; строки сравниваются без учёта регистра.
+type UserFilter struct {
+	TeamName   string
+	IsActive   *bool
+	NamePrefix string
+	Username   string
+	Email      string
+	Limit      int
+	Offset     int
+}
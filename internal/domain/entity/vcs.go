@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VCSProvider хостинг кода, присылающий события PR
+type VCSProvider string
+
+const (
+	VCSProviderGitHub VCSProvider = "GITHUB"
+	VCSProviderGitLab VCSProvider = "GITLAB"
+)
+
+// IsValid сообщает, что провайдер поддерживается
+func (p VCSProvider) IsValid() bool {
+	switch p {
+	case VCSProviderGitHub, VCSProviderGitLab:
+		return true
+	default:
+		return false
+	}
+}
+
+// VCSIdentity связь логина на хостинге кода с пользователем сервиса.
+// Логин хранится в нижнем регистре.
+type VCSIdentity struct {
+	Provider  VCSProvider
+	Login     string
+	UserID    string
+	CreatedAt time.Time
+}
+
+// VCSPullRequestLink связь PR сервиса с PR на хостинге кода
+type VCSPullRequestLink struct {
+	PullRequestID string
+	Provider      VCSProvider
+	Repository    string
+	Number        int
+	URL           string
+}
+
+// VCSPullRequestAction изменение PR на хостинге кода, на которое реагирует сервис
+type VCSPullRequestAction string
+
+const (
+	VCSPullRequestOpened         VCSPullRequestAction = "OPENED"
+	VCSPullRequestReadyForReview VCSPullRequestAction = "READY_FOR_REVIEW"
+	VCSPullRequestMerged         VCSPullRequestAction = "MERGED"
+	VCSPullRequestClosed         VCSPullRequestAction = "CLOSED"
+	VCSPullRequestReopened       VCSPullRequestAction = "REOPENED"
+)
+
+// VCSPullRequestEvent событие PR, полученное из вебхука хостинга кода.
+// DeliveryID уникален в пределах провайдера и используется для дедупликации.
+type VCSPullRequestEvent struct {
+	Provider    VCSProvider
+	DeliveryID  string
+	Action      VCSPullRequestAction
+	Repository  string
+	Number      int
+	Title       string
+	URL         string
+	AuthorLogin string
+	Draft       bool
+	Size        PRSize
+}
+
+// PullRequestID возвращает ID PR в сервисе, например github:org/repo#42
+func (e *VCSPullRequestEvent) PullRequestID() string {
+	return strings.ToLower(string(e.Provider)) + ":" + e.Repository + "#" + strconv.Itoa(e.Number)
+}
+
+// VCSReviewerRequestStatus статус отправки ревьюверов на хостинг кода
+type VCSReviewerRequestStatus string
+
+const (
+	VCSReviewerRequestPending VCSReviewerRequestStatus = "PENDING"
+	VCSReviewerRequestSent    VCSReviewerRequestStatus = "SENT"
+	VCSReviewerRequestFailed  VCSReviewerRequestStatus = "FAILED"
+	VCSReviewerRequestSkipped VCSReviewerRequestStatus = "SKIPPED"
+)
+
+// VCSReviewerRequest изменение ревьюверов PR, которое нужно передать на хостинг кода.
+// AddedReviewers и RemovedReviewers содержат user_id; LastError хранит причину последней неудачи или пропуска,
+// а у отправленного запроса — ревьюверов, не отправленных из-за отсутствия привязки логина.
+type VCSReviewerRequest struct {
+	ID               int64
+	PullRequestID    string
+	AddedReviewers   []string
+	RemovedReviewers []string
+	Status           VCSReviewerRequestStatus
+	Attempts         int
+	NextAttemptAt    time.Time
+	LastError        string
+	CreatedAt        time.Time
+	SentAt           *time.Time
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+// WebhookSubscription подписка внешнего сервиса на события.
+// Пустой TeamName означает события всех команд.
+type WebhookSubscription struct {
+	ID         int64
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	TeamName   string
+	CreatedAt  time.Time
+}
+
+// WebhookDeliveryStatus статус доставки события подписчику
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+// WebhookDelivery доставка события по подписке.
+// Payload — подписываемое JSON-тело запроса; ResponseStatus равен 0, если ответа не было.
+// PullRequestID пуст для событий команд.
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventID        string
+	EventType      EventType
+	PullRequestID  string
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	ResponseStatus int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
@@ -3,14 +3,21 @@ package errors
 import "errors"
 
 var (
-	ErrTeamExists   = errors.New("TEAM_EXISTS")
-	ErrPRExists     = errors.New("PR_EXISTS")
-	ErrPRMerged     = errors.New("PR_MERGED")
-	ErrNotAssigned  = errors.New("NOT_ASSIGNED")
-	ErrNoCandidate  = errors.New("NO_CANDIDATE")
-	ErrNotFound     = errors.New("NOT_FOUND")
-	ErrUnauthorized = errors.New("UNAUTHORIZED")
-	ErrInvalidInput = errors.New("INVALID_INPUT")
+	ErrTeamExists     = errors.New("TEAM_EXISTS")
+	ErrUserExists     = errors.New("USER_EXISTS")
+	ErrPRExists       = errors.New("PR_EXISTS")
+	ErrPRMerged       = errors.New("PR_MERGED")
+	ErrPRClosed       = errors.New("PR_CLOSED")
+	ErrNotAssigned    = errors.New("NOT_ASSIGNED")
+	ErrNoCandidate    = errors.New("NO_CANDIDATE")
+	ErrNotFound       = errors.New("NOT_FOUND")
+	ErrUnauthorized   = errors.New("UNAUTHORIZED")
+	ErrInvalidInput   = errors.New("INVALID_INPUT")
+	ErrRuleExists     = errors.New("RULE_EXISTS")
+	ErrTeamArchived   = errors.New("TEAM_ARCHIVED")
+	ErrTeamHasOpenPRs = errors.New("TEAM_HAS_OPEN_PRS")
+
+	ErrActivationChangeNotPending = errors.New("ACTIVATION_CHANGE_NOT_PENDING")
 )
 
 // DomainError представляет доменную ошибку с кодом и сообщением
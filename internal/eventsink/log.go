@@ -0,0 +1,33 @@
+package eventsink
+
+import (
+	"context"
+	"log"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// LogSink пишет события в лог сервиса
+type LogSink struct{}
+
+// NewLogSink создает получателя, пишущего события в лог
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+// Name возвращает имя получателя
+func (s *LogSink) Name() string {
+	return "log"
+}
+
+// Send пишет событие в лог одной строкой JSON
+func (s *LogSink) Send(_ context.Context, event *entity.DomainEvent) error {
+	body, err := usecase.EncodeEvent(event)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Event %s: %s", event.Type, body)
+	return nil
+}
@@ -0,0 +1,67 @@
+package eventsink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// RedisStreamSink публикует события в поток Redis командой XADD через пул соединений.
+// Событие считается принятым, когда Redis вернул ID записи.
+type RedisStreamSink struct {
+	client *redis.Client
+	stream string
+}
+
+// NewRedisStreamSink создает получателя для потока stream на сервере addr; пустой password отключает AUTH.
+// timeout ограничивает подключение, запись и чтение ответа.
+func NewRedisStreamSink(addr, password, stream string, timeout time.Duration) *RedisStreamSink {
+	return &RedisStreamSink{
+		client: redis.NewClient(&redis.Options{
+			Addr:         addr,
+			Password:     password,
+			DialTimeout:  timeout,
+			ReadTimeout:  timeout,
+			WriteTimeout: timeout,
+		}),
+		stream: stream,
+	}
+}
+
+// Name возвращает имя получателя
+func (s *RedisStreamSink) Name() string {
+	return "redis"
+}
+
+// Send добавляет событие в поток с полями id, type, pull_request_id и event (JSON события)
+func (s *RedisStreamSink) Send(ctx context.Context, event *entity.DomainEvent) error {
+	body, err := usecase.EncodeEvent(event)
+	if err != nil {
+		return err
+	}
+
+	err = s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.stream,
+		Values: []interface{}{
+			"id", event.ID,
+			"type", string(event.Type),
+			"pull_request_id", event.PullRequestID,
+			"event", string(body),
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("redis XADD: %w", err)
+	}
+
+	return nil
+}
+
+// Close закрывает пул соединений с Redis
+func (s *RedisStreamSink) Close() error {
+	return s.client.Close()
+}
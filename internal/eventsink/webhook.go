@@ -0,0 +1,29 @@
+package eventsink
+
+import (
+	"context"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// WebhookSink ставит события в очередь доставки подписчикам исходящих вебхуков.
+// Доставки всем подпискам создаются в одной транзакции.
+type WebhookSink struct {
+	webhookUC *usecase.WebhookUseCase
+}
+
+// NewWebhookSink создает получателя для исходящих вебхуков
+func NewWebhookSink(webhookUC *usecase.WebhookUseCase) *WebhookSink {
+	return &WebhookSink{webhookUC: webhookUC}
+}
+
+// Name возвращает имя получателя
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+// Send создает доставки события подходящим подпискам
+func (s *WebhookSink) Send(ctx context.Context, event *entity.DomainEvent) error {
+	return s.webhookUC.Publish(ctx, event)
+}
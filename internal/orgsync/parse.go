@@ -0,0 +1,288 @@
+// Package orgsync разбирает декларативное описание команд и участников из YAML или CSV.
+package orgsync
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+)
+
+// Format формат файла описания
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatCSV  Format = "csv"
+)
+
+// ParseFormat разбирает имя формата; пустое имя означает YAML
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "", "yaml", "yml":
+		return FormatYAML, nil
+	case "csv":
+		return FormatCSV, nil
+	default:
+		return "", fmt.Errorf("unsupported format %q, expected yaml or csv", name)
+	}
+}
+
+// Parse читает описание команд в заданном формате.
+// Проверяется только структура: обязательные поля и типы значений.
+func Parse(format Format, r io.Reader) ([]*entity.TeamWithMembers, error) {
+	switch format {
+	case FormatYAML:
+		return ParseYAML(r)
+	case FormatCSV:
+		return ParseCSV(r)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// yamlOrg корень YAML-описания
+type yamlOrg struct {
+	Teams []yamlTeam `yaml:"teams"`
+}
+
+type yamlTeam struct {
+	Name    string       `yaml:"name"`
+	Parent  string       `yaml:"parent"`
+	Members []yamlMember `yaml:"members"`
+}
+
+type yamlMember struct {
+	UserID       string   `yaml:"user_id"`
+	Username     string   `yaml:"username"`
+	IsActive     *bool    `yaml:"is_active"`
+	Role         string   `yaml:"role"`
+	ReviewWeight *float64 `yaml:"review_weight"`
+	DisplayName  string   `yaml:"display_name"`
+	Email        string   `yaml:"email"`
+	ChatHandle   string   `yaml:"chat_handle"`
+	Timezone     string   `yaml:"timezone"`
+}
+
+// ParseYAML читает описание вида
+//
+//	teams:
+//	  - name: backend
+//	    parent: platform
+//	    members:
+//	      - user_id: u1
+//	        username: alice
+//	        role: LEAD
+//
+// is_active по умолчанию true.
+func ParseYAML(r io.Reader) ([]*entity.TeamWithMembers, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	var org yamlOrg
+	if err := decoder.Decode(&org); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("empty org description")
+		}
+		return nil, fmt.Errorf("invalid yaml: %w", err)
+	}
+
+	b := newBuilder()
+	for i, t := range org.Teams {
+		if t.Name == "" {
+			return nil, fmt.Errorf("team #%d: name is required", i+1)
+		}
+		if err := b.addTeam(t.Name, t.Parent); err != nil {
+			return nil, err
+		}
+
+		for _, m := range t.Members {
+			isActive := true
+			if m.IsActive != nil {
+				isActive = *m.IsActive
+			}
+
+			if err := b.addMember(t.Name, entity.TeamMember{
+				UserID:   m.UserID,
+				Username: m.Username,
+				IsActive: isActive,
+				Kind:     entity.UserKindHuman,
+				UserProfile: entity.UserProfile{
+					DisplayName: m.DisplayName,
+					Email:       m.Email,
+					ChatHandle:  m.ChatHandle,
+					Timezone:    m.Timezone,
+				},
+				Role:         entity.TeamRole(strings.ToUpper(m.Role)),
+				ReviewWeight: m.ReviewWeight,
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return b.teams, nil
+}
+
+// csvColumns известные колонки CSV; обязательны team_name, user_id и username
+var csvColumns = map[string]bool{
+	"team_name":        true,
+	"parent_team_name": true,
+	"user_id":          true,
+	"username":         true,
+	"is_active":        true,
+	"role":             true,
+	"review_weight":    true,
+	"display_name":     true,
+	"email":            true,
+	"chat_handle":      true,
+	"timezone":         true,
+}
+
+// ParseCSV читает описание с заголовком и одной строкой на участника команды.
+// Строка с пустым user_id объявляет команду без участников;
+// parent_team_name достаточно указать в одной строке команды.
+func ParseCSV(r io.Reader) ([]*entity.TeamWithMembers, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("empty org description")
+		}
+		return nil, fmt.Errorf("invalid csv: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !csvColumns[name] {
+			return nil, fmt.Errorf("unknown csv column %q", name)
+		}
+		index[name] = i
+	}
+	for _, required := range []string{"team_name", "user_id", "username"} {
+		if _, ok := index[required]; !ok {
+			return nil, fmt.Errorf("csv column %q is required", required)
+		}
+	}
+
+	b := newBuilder()
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %w", err)
+		}
+
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		teamName := field("team_name")
+		if teamName == "" {
+			return nil, fmt.Errorf("line %d: team_name is required", line)
+		}
+		if err := b.addTeam(teamName, field("parent_team_name")); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if field("user_id") == "" {
+			continue
+		}
+
+		isActive := true
+		if raw := field("is_active"); raw != "" {
+			isActive, err = strconv.ParseBool(raw)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: is_active must be true or false", line)
+			}
+		}
+
+		var reviewWeight *float64
+		if raw := field("review_weight"); raw != "" {
+			weight, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: review_weight must be a number", line)
+			}
+			reviewWeight = &weight
+		}
+
+		if err := b.addMember(teamName, entity.TeamMember{
+			UserID:   field("user_id"),
+			Username: field("username"),
+			IsActive: isActive,
+			Kind:     entity.UserKindHuman,
+			UserProfile: entity.UserProfile{
+				DisplayName: field("display_name"),
+				Email:       field("email"),
+				ChatHandle:  field("chat_handle"),
+				Timezone:    field("timezone"),
+			},
+			Role:         entity.TeamRole(strings.ToUpper(field("role"))),
+			ReviewWeight: reviewWeight,
+		}); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+
+	return b.teams, nil
+}
+
+// builder собирает команды в порядке первого упоминания
+type builder struct {
+	teams  []*entity.TeamWithMembers
+	byName map[string]*entity.TeamWithMembers
+}
+
+func newBuilder() *builder {
+	return &builder{byName: make(map[string]*entity.TeamWithMembers)}
+}
+
+// addTeam объявляет команду; повторное объявление не может сменить родителя
+func (b *builder) addTeam(name, parent string) error {
+	team, ok := b.byName[name]
+	if !ok {
+		team = &entity.TeamWithMembers{TeamName: name, Members: []entity.TeamMember{}}
+		b.teams = append(b.teams, team)
+		b.byName[name] = team
+	}
+
+	if parent == "" {
+		return nil
+	}
+	if team.ParentTeamName != "" && team.ParentTeamName != parent {
+		return fmt.Errorf("team %s has conflicting parents %s and %s", name, team.ParentTeamName, parent)
+	}
+	team.ParentTeamName = parent
+	return nil
+}
+
+// addMember добавляет участника; пользователь указывается в команде не больше одного раза
+func (b *builder) addMember(teamName string, member entity.TeamMember) error {
+	if member.UserID == "" || member.Username == "" {
+		return fmt.Errorf("team %s: user_id and username are required", teamName)
+	}
+
+	team := b.byName[teamName]
+	for _, existing := range team.Members {
+		if existing.UserID == member.UserID {
+			return fmt.Errorf("team %s: user %s is listed twice", teamName, member.UserID)
+		}
+	}
+
+	team.Members = append(team.Members, member)
+	return nil
+}
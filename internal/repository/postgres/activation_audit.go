@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+)
+
+// ActivationAuditRepository реализует repository.ActivationAuditRepository для PostgreSQL
+type ActivationAuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewActivationAuditRepository создает новый репозиторий аудита изменений активности
+func NewActivationAuditRepository(pool *pgxpool.Pool) *ActivationAuditRepository {
+	return &ActivationAuditRepository{pool: pool}
+}
+
+// Add добавляет запись аудита
+func (r *ActivationAuditRepository) Add(ctx context.Context, entry *entity.ActivationAuditEntry) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		INSERT INTO activation_audit_log
+			(change_id, user_id, is_active, outcome, reassigned_prs, unassigned_prs, failed_prs, error_code, error_message, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''), $10)
+		RETURNING id
+	`
+
+	err := conn.QueryRow(ctx, query,
+		entry.ChangeID,
+		entry.UserID,
+		entry.IsActive,
+		entry.Outcome,
+		entry.ReassignedPRs,
+		entry.UnassignedPRs,
+		entry.FailedPRs,
+		entry.ErrorCode,
+		entry.ErrorMessage,
+		entry.CreatedAt,
+	).Scan(&entry.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to add activation audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUser возвращает аудит пользователя в хронологическом порядке
+func (r *ActivationAuditRepository) GetByUser(ctx context.Context, userID string) ([]*entity.ActivationAuditEntry, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT id, COALESCE(change_id, 0), user_id, is_active, outcome,
+		       reassigned_prs, unassigned_prs, failed_prs, COALESCE(error_code, ''),
+		       COALESCE(error_message, ''), created_at
+		FROM activation_audit_log
+		WHERE user_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := conn.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activation audit: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entity.ActivationAuditEntry
+	for rows.Next() {
+		var entry entity.ActivationAuditEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ChangeID,
+			&entry.UserID,
+			&entry.IsActive,
+			&entry.Outcome,
+			&entry.ReassignedPRs,
+			&entry.UnassignedPRs,
+			&entry.FailedPRs,
+			&entry.ErrorCode,
+			&entry.ErrorMessage,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan activation audit entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate activation audit: %w", err)
+	}
+
+	return entries, nil
+}
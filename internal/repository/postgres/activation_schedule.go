@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+)
+
+// ActivationScheduleRepository реализует repository.ActivationScheduleRepository для PostgreSQL
+type ActivationScheduleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewActivationScheduleRepository создает новый репозиторий запланированных изменений активности
+func NewActivationScheduleRepository(pool *pgxpool.Pool) *ActivationScheduleRepository {
+	return &ActivationScheduleRepository{pool: pool}
+}
+
+const scheduledActivationColumns = `
+	id, user_id, is_active, apply_at, hand_off_reviews, COALESCE(successor_id, ''), COALESCE(reverse_of_id, 0), status, created_at, processed_at
+`
+
+// Create сохраняет запланированное изменение
+func (r *ActivationScheduleRepository) Create(ctx context.Context, change *entity.ScheduledActivationChange) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		INSERT INTO scheduled_activation_changes
+			(user_id, is_active, apply_at, hand_off_reviews, successor_id, reverse_of_id, status, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, 0), $7, $8)
+		RETURNING id
+	`
+
+	err := conn.QueryRow(ctx, query,
+		change.UserID,
+		change.IsActive,
+		change.ApplyAt,
+		change.HandOffReviews,
+		change.SuccessorID,
+		change.ReverseOfID,
+		change.Status,
+		change.CreatedAt,
+	).Scan(&change.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to create scheduled activation change: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID возвращает запланированное изменение
+func (r *ActivationScheduleRepository) GetByID(ctx context.Context, changeID int64) (*entity.ScheduledActivationChange, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `SELECT ` + scheduledActivationColumns + ` FROM scheduled_activation_changes WHERE id = $1`
+
+	change, err := scanScheduledActivation(conn.QueryRow(ctx, query, changeID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get scheduled activation change: %w", err)
+	}
+
+	return change, nil
+}
+
+// GetReverse возвращает обратное изменение, запланированное в паре с changeID
+func (r *ActivationScheduleRepository) GetReverse(ctx context.Context, changeID int64) (*entity.ScheduledActivationChange, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `SELECT ` + scheduledActivationColumns + ` FROM scheduled_activation_changes WHERE reverse_of_id = $1`
+
+	change, err := scanScheduledActivation(conn.QueryRow(ctx, query, changeID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get reverse activation change: %w", err)
+	}
+
+	return change, nil
+}
+
+// List возвращает изменения по времени применения; пустые userID и status не фильтруют
+func (r *ActivationScheduleRepository) List(
+	ctx context.Context,
+	userID string,
+	status entity.ActivationChangeStatus,
+) ([]*entity.ScheduledActivationChange, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT ` + scheduledActivationColumns + `
+		FROM scheduled_activation_changes
+		WHERE ($1 = '' OR user_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY apply_at, id
+	`
+
+	rows, err := conn.Query(ctx, query, userID, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled activation changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*entity.ScheduledActivationChange
+	for rows.Next() {
+		change, err := scanScheduledActivation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled activation change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate scheduled activation changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// LockNextDue блокирует ближайшее наступившее изменение до конца транзакции.
+// Изменения, уже заблокированные другим обработчиком, пропускаются. Возвращает nil, если таких нет.
+func (r *ActivationScheduleRepository) LockNextDue(ctx context.Context, now time.Time) (*entity.ScheduledActivationChange, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT ` + scheduledActivationColumns + `
+		FROM scheduled_activation_changes
+		WHERE status = 'PENDING' AND apply_at <= $1
+		ORDER BY apply_at, id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	change, err := scanScheduledActivation(conn.QueryRow(ctx, query, now))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock due activation change: %w", err)
+	}
+
+	return change, nil
+}
+
+// SetStatus меняет статус изменения
+func (r *ActivationScheduleRepository) SetStatus(
+	ctx context.Context,
+	changeID int64,
+	status entity.ActivationChangeStatus,
+	processedAt time.Time,
+) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `UPDATE scheduled_activation_changes SET status = $2, processed_at = $3 WHERE id = $1`
+
+	result, err := conn.Exec(ctx, query, changeID, status, processedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update scheduled activation change: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// scanScheduledActivation читает изменение из строки результата
+func scanScheduledActivation(row pgx.Row) (*entity.ScheduledActivationChange, error) {
+	var change entity.ScheduledActivationChange
+	err := row.Scan(
+		&change.ID,
+		&change.UserID,
+		&change.IsActive,
+		&change.ApplyAt,
+		&change.HandOffReviews,
+		&change.SuccessorID,
+		&change.ReverseOfID,
+		&change.Status,
+		&change.CreatedAt,
+		&change.ProcessedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &change, nil
+}
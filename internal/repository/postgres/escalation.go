@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+)
+
+// EscalationRepository реализует repository.EscalationRepository для PostgreSQL
+type EscalationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewEscalationRepository создает новый репозиторий контактов эскалации
+func NewEscalationRepository(pool *pgxpool.Pool) *EscalationRepository {
+	return &EscalationRepository{pool: pool}
+}
+
+// GetByTeam возвращает лидов и контакты эскалации команды: сначала лиды, затем контакты
+func (r *EscalationRepository) GetByTeam(ctx context.Context, teamName string) ([]*entity.EscalationContact, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT team_name, user_id, role, position
+		FROM team_escalation_contacts
+		WHERE team_name = $1
+		ORDER BY CASE role WHEN 'LEAD' THEN 0 ELSE 1 END, position, user_id
+	`
+
+	rows, err := conn.Query(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get escalation contacts: %w", err)
+	}
+	defer rows.Close()
+
+	var contacts []*entity.EscalationContact
+	for rows.Next() {
+		var contact entity.EscalationContact
+		err := rows.Scan(
+			&contact.TeamName,
+			&contact.UserID,
+			&contact.Role,
+			&contact.Position,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan escalation contact: %w", err)
+		}
+		contacts = append(contacts, &contact)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate escalation contacts: %w", err)
+	}
+
+	return contacts, nil
+}
+
+// ReplaceForTeam полностью заменяет лидов и контакты эскалации команды
+func (r *EscalationRepository) ReplaceForTeam(ctx context.Context, teamName string, contacts []*entity.EscalationContact) error {
+	conn := getConn(ctx, r.pool)
+
+	deleteQuery := `DELETE FROM team_escalation_contacts WHERE team_name = $1`
+	if _, err := conn.Exec(ctx, deleteQuery, teamName); err != nil {
+		return fmt.Errorf("failed to delete old escalation contacts: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO team_escalation_contacts (team_name, user_id, role, position)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	for _, contact := range contacts {
+		_, err := conn.Exec(ctx, insertQuery, teamName, contact.UserID, contact.Role, contact.Position)
+		if err != nil {
+			return fmt.Errorf("failed to insert escalation contact %s: %w", contact.UserID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+)
+
+// PRHistoryRepository реализует repository.PRHistoryRepository для PostgreSQL
+type PRHistoryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPRHistoryRepository создает новый репозиторий истории PR
+func NewPRHistoryRepository(pool *pgxpool.Pool) *PRHistoryRepository {
+	return &PRHistoryRepository{pool: pool}
+}
+
+// Add добавляет запись в историю PR
+func (r *PRHistoryRepository) Add(ctx context.Context, entry *entity.PRHistoryEntry) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		INSERT INTO pr_history (pull_request_id, event_type, old_reviewer_id, new_reviewer_id, escalated, reason, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7)
+		RETURNING id
+	`
+
+	err := conn.QueryRow(ctx, query,
+		entry.PullRequestID,
+		entry.EventType,
+		entry.OldReviewerID,
+		entry.NewReviewerID,
+		entry.Escalated,
+		entry.Reason,
+		entry.CreatedAt,
+	).Scan(&entry.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to add PR history entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetByPR возвращает историю PR в хронологическом порядке
+func (r *PRHistoryRepository) GetByPR(ctx context.Context, prID string) ([]*entity.PRHistoryEntry, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT id, pull_request_id, event_type, COALESCE(old_reviewer_id, ''), COALESCE(new_reviewer_id, ''),
+		       escalated, reason, created_at
+		FROM pr_history
+		WHERE pull_request_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := conn.Query(ctx, query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entity.PRHistoryEntry
+	for rows.Next() {
+		var entry entity.PRHistoryEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.PullRequestID,
+			&entry.EventType,
+			&entry.OldReviewerID,
+			&entry.NewReviewerID,
+			&entry.Escalated,
+			&entry.Reason,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan PR history entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate PR history: %w", err)
+	}
+
+	return entries, nil
+}
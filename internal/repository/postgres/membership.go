@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+)
+
+// MembershipRepository реализует repository.MembershipRepository для PostgreSQL
+type MembershipRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewMembershipRepository создает новый репозиторий членств в командах
+func NewMembershipRepository(pool *pgxpool.Pool) *MembershipRepository {
+	return &MembershipRepository{pool: pool}
+}
+
+// Upsert создает членство или обновляет его роль и вес
+func (r *MembershipRepository) Upsert(ctx context.Context, membership *entity.TeamMembership) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		INSERT INTO team_memberships (team_name, user_id, role, review_weight, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (team_name, user_id) DO UPDATE
+		SET role = EXCLUDED.role,
+		    review_weight = EXCLUDED.review_weight,
+		    updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := conn.Exec(ctx, query,
+		membership.TeamName,
+		membership.UserID,
+		membershipRole(membership),
+		membership.ReviewWeight,
+		membership.CreatedAt,
+		membership.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert team membership: %w", err)
+	}
+
+	return nil
+}
+
+// Remove удаляет членство
+func (r *MembershipRepository) Remove(ctx context.Context, teamName, userID string) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `DELETE FROM team_memberships WHERE team_name = $1 AND user_id = $2`
+
+	result, err := conn.Exec(ctx, query, teamName, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove team membership: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Get возвращает членство пользователя в команде
+func (r *MembershipRepository) Get(ctx context.Context, teamName, userID string) (*entity.TeamMembership, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT team_name, user_id, role, review_weight::float8, created_at, updated_at
+		FROM team_memberships
+		WHERE team_name = $1 AND user_id = $2
+	`
+
+	var membership entity.TeamMembership
+	err := conn.QueryRow(ctx, query, teamName, userID).Scan(
+		&membership.TeamName,
+		&membership.UserID,
+		&membership.Role,
+		&membership.ReviewWeight,
+		&membership.CreatedAt,
+		&membership.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get team membership: %w", err)
+	}
+
+	return &membership, nil
+}
+
+// GetByTeam возвращает членства команды
+func (r *MembershipRepository) GetByTeam(ctx context.Context, teamName string) ([]*entity.TeamMembership, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT team_name, user_id, role, review_weight::float8, created_at, updated_at
+		FROM team_memberships
+		WHERE team_name = $1
+		ORDER BY user_id
+	`
+
+	rows, err := conn.Query(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team memberships: %w", err)
+	}
+	defer rows.Close()
+
+	return scanMemberships(rows)
+}
+
+// GetByUser возвращает членства пользователя
+func (r *MembershipRepository) GetByUser(ctx context.Context, userID string) ([]*entity.TeamMembership, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT team_name, user_id, role, review_weight::float8, created_at, updated_at
+		FROM team_memberships
+		WHERE user_id = $1
+		ORDER BY created_at, team_name
+	`
+
+	rows, err := conn.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user memberships: %w", err)
+	}
+	defer rows.Close()
+
+	return scanMemberships(rows)
+}
+
+// scanMemberships читает членства из результата запроса
+func scanMemberships(rows pgx.Rows) ([]*entity.TeamMembership, error) {
+	var memberships []*entity.TeamMembership
+	for rows.Next() {
+		var membership entity.TeamMembership
+		err := rows.Scan(
+			&membership.TeamName,
+			&membership.UserID,
+			&membership.Role,
+			&membership.ReviewWeight,
+			&membership.CreatedAt,
+			&membership.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team membership: %w", err)
+		}
+		memberships = append(memberships, &membership)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate team memberships: %w", err)
+	}
+
+	return memberships, nil
+}
+
+// membershipRole возвращает роль членства, подставляя MEMBER по умолчанию
+func membershipRole(membership *entity.TeamMembership) entity.TeamRole {
+	if membership.Role == "" {
+		return entity.TeamRoleMember
+	}
+	return membership.Role
+}
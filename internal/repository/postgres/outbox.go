@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+)
+
+// OutboxRepository реализует repository.OutboxRepository для PostgreSQL
+type OutboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOutboxRepository создает новый репозиторий outbox
+func NewOutboxRepository(pool *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{pool: pool}
+}
+
+const outboxColumns = `
+	id, event_id, event_type, team_name, pull_request_id, data, occurred_at,
+	status, attempts, next_attempt_at, published_sinks, last_error, created_at, published_at
+`
+
+// Create записывает событие в outbox текущей транзакции
+func (r *OutboxRepository) Create(ctx context.Context, message *entity.OutboxMessage) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		INSERT INTO outbox (event_id, event_type, team_name, pull_request_id, data, occurred_at, status, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	data := message.Event.Data
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	err := conn.QueryRow(ctx, query,
+		message.Event.ID,
+		message.Event.Type,
+		message.Event.TeamName,
+		message.Event.PullRequestID,
+		data,
+		message.Event.OccurredAt,
+		message.Status,
+		message.NextAttemptAt,
+		message.CreatedAt,
+	).Scan(&message.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to create outbox message: %w", err)
+	}
+
+	return nil
+}
+
+// Update сохраняет результат попытки публикации
+func (r *OutboxRepository) Update(ctx context.Context, message *entity.OutboxMessage) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		UPDATE outbox
+		SET status = $2, attempts = $3, next_attempt_at = $4, published_sinks = $5, last_error = $6, published_at = $7
+		WHERE id = $1
+	`
+
+	result, err := conn.Exec(ctx, query,
+		message.ID,
+		message.Status,
+		message.Attempts,
+		message.NextAttemptAt,
+		nonNilStrings(message.PublishedSinks),
+		message.LastError,
+		message.PublishedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update outbox message: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// LockNextDue блокирует ближайшее готовое к публикации событие до конца транзакции.
+// Событие пропускается, пока у того же PR есть более раннее событие в статусе PENDING (в том числе захваченное),
+// поэтому события одного PR публикуются по порядку и при нескольких обработчиках. Возвращает nil, если таких нет.
+func (r *OutboxRepository) LockNextDue(ctx context.Context, now time.Time) (*entity.OutboxMessage, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT ` + outboxColumns + `
+		FROM outbox o
+		WHERE o.status = 'PENDING' AND o.next_attempt_at <= $1
+			AND (o.pull_request_id = '' OR NOT EXISTS (
+				SELECT 1 FROM outbox e
+				WHERE e.pull_request_id = o.pull_request_id AND e.status = 'PENDING' AND e.id < o.id
+			))
+		ORDER BY o.next_attempt_at, o.id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	message, err := scanOutboxMessage(conn.QueryRow(ctx, query, now))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock due outbox message: %w", err)
+	}
+
+	return message, nil
+}
+
+// List возвращает события в порядке записи; пустые prID и status не фильтруют
+func (r *OutboxRepository) List(ctx context.Context, prID string, status entity.OutboxStatus) ([]*entity.OutboxMessage, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT ` + outboxColumns + `
+		FROM outbox
+		WHERE ($1 = '' OR pull_request_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY id
+	`
+
+	rows, err := conn.Query(ctx, query, prID, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*entity.OutboxMessage
+	for rows.Next() {
+		message, err := scanOutboxMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// scanOutboxMessage читает событие outbox из строки результата
+func scanOutboxMessage(row pgx.Row) (*entity.OutboxMessage, error) {
+	var message entity.OutboxMessage
+	err := row.Scan(
+		&message.ID,
+		&message.Event.ID,
+		&message.Event.Type,
+		&message.Event.TeamName,
+		&message.Event.PullRequestID,
+		&message.Event.Data,
+		&message.Event.OccurredAt,
+		&message.Status,
+		&message.Attempts,
+		&message.NextAttemptAt,
+		&message.PublishedSinks,
+		&message.LastError,
+		&message.CreatedAt,
+		&message.PublishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &message, nil
+}
@@ -28,8 +28,11 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *entity.PullReque
 	conn := getConn(ctx, r.pool)
 
 	query := `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO pull_requests (
+			pull_request_id, pull_request_name, author_id, status, created_at, merged_at,
+			lines_added, lines_deleted, files_changed, size_class, review_due_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11)
 	`
 
 	_, err := conn.Exec(ctx, query,
@@ -39,6 +42,11 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *entity.PullReque
 		pr.Status,
 		pr.CreatedAt,
 		pr.MergedAt,
+		pr.Size.LinesAdded,
+		pr.Size.LinesDeleted,
+		pr.Size.FilesChanged,
+		pr.SizeClass,
+		pr.ReviewDueAt,
 	)
 
 	if err != nil {
@@ -119,7 +127,8 @@ func (r *PullRequestRepository) GetByID(ctx context.Context, prID string) (*enti
 	conn := getConn(ctx, r.pool)
 
 	query := `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at,
+		       lines_added, lines_deleted, files_changed, COALESCE(size_class, ''), review_due_at
 		FROM pull_requests
 		WHERE pull_request_id = $1
 	`
@@ -132,6 +141,11 @@ func (r *PullRequestRepository) GetByID(ctx context.Context, prID string) (*enti
 		&pr.Status,
 		&pr.CreatedAt,
 		&pr.MergedAt,
+		&pr.Size.LinesAdded,
+		&pr.Size.LinesDeleted,
+		&pr.Size.FilesChanged,
+		&pr.SizeClass,
+		&pr.ReviewDueAt,
 	)
 
 	if err != nil {
@@ -177,7 +191,8 @@ func (r *PullRequestRepository) GetByReviewer(ctx context.Context, userID string
 	conn := getConn(ctx, r.pool)
 
 	query := `
-		SELECT DISTINCT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at
+		SELECT DISTINCT p.pull_request_id, p.pull_request_name, p.author_id, p.status,
+		       COALESCE(p.size_class, ''), p.created_at
 		FROM pull_requests p
 		INNER JOIN pr_reviewers pr ON p.pull_request_id = pr.pull_request_id
 		WHERE pr.reviewer_id = $1
@@ -199,6 +214,7 @@ func (r *PullRequestRepository) GetByReviewer(ctx context.Context, userID string
 			&pr.PullRequestName,
 			&pr.AuthorID,
 			&pr.Status,
+			&pr.SizeClass,
 			&createdAt,
 		)
 		if err != nil {
@@ -241,10 +257,12 @@ func (r *PullRequestRepository) GetOpenPRsByReviewers(ctx context.Context, revie
 
 	// Используем ANY для поиска по массиву
 	query := `
-		SELECT DISTINCT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at, p.merged_at
+		SELECT DISTINCT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at, p.merged_at,
+		       p.lines_added, p.lines_deleted, p.files_changed, COALESCE(p.size_class, ''), p.review_due_at
 		FROM pull_requests p
 		INNER JOIN pr_reviewers pr ON p.pull_request_id = pr.pull_request_id
 		WHERE pr.reviewer_id = ANY($1) AND p.status = 'OPEN'
+		ORDER BY p.created_at, p.pull_request_id
 	`
 
 	rows, err := conn.Query(ctx, query, reviewerIDs)
@@ -254,6 +272,7 @@ func (r *PullRequestRepository) GetOpenPRsByReviewers(ctx context.Context, revie
 	defer rows.Close()
 
 	var prs []*entity.PullRequest
+	prByID := make(map[string]*entity.PullRequest)
 	for rows.Next() {
 		var pr entity.PullRequest
 		err := rows.Scan(
@@ -263,36 +282,100 @@ func (r *PullRequestRepository) GetOpenPRsByReviewers(ctx context.Context, revie
 			&pr.Status,
 			&pr.CreatedAt,
 			&pr.MergedAt,
+			&pr.Size.LinesAdded,
+			&pr.Size.LinesDeleted,
+			&pr.Size.FilesChanged,
+			&pr.SizeClass,
+			&pr.ReviewDueAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan PR: %w", err)
 		}
-
-		// Получаем ревьюверов для каждого PR
-		reviewersQuery := `SELECT reviewer_id FROM pr_reviewers WHERE pull_request_id = $1`
-		reviewerRows, err := conn.Query(ctx, reviewersQuery, pr.PullRequestID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get reviewers: %w", err)
-		}
-
-		var reviewers []string
-		for reviewerRows.Next() {
-			var reviewerID string
-			if err := reviewerRows.Scan(&reviewerID); err != nil {
-				reviewerRows.Close()
-				return nil, fmt.Errorf("failed to scan reviewer: %w", err)
-			}
-			reviewers = append(reviewers, reviewerID)
-		}
-		reviewerRows.Close()
-
-		pr.AssignedReviewers = reviewers
 		prs = append(prs, &pr)
+		prByID[pr.PullRequestID] = &pr
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("failed to iterate PRs: %w", err)
 	}
+	rows.Close()
+
+	if len(prs) == 0 {
+		return prs, nil
+	}
+
+	// Получаем ревьюверов всех найденных PR одним запросом
+	// (внутри транзакции нельзя выполнять запрос, пока открыт предыдущий курсор)
+	prIDs := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		prIDs = append(prIDs, pr.PullRequestID)
+	}
+
+	reviewersQuery := `
+		SELECT pull_request_id, reviewer_id
+		FROM pr_reviewers
+		WHERE pull_request_id = ANY($1)
+		ORDER BY assigned_at
+	`
+
+	reviewerRows, err := conn.Query(ctx, reviewersQuery, prIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewers: %w", err)
+	}
+	defer reviewerRows.Close()
+
+	for reviewerRows.Next() {
+		var prID, reviewerID string
+		if err := reviewerRows.Scan(&prID, &reviewerID); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer: %w", err)
+		}
+		pr := prByID[prID]
+		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+	}
+
+	if err := reviewerRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate reviewers: %w", err)
+	}
 
 	return prs, nil
 }
+
+// CountOpenByAuthorTeam возвращает количество открытых PR авторов команды
+func (r *PullRequestRepository) CountOpenByAuthorTeam(ctx context.Context, teamName string) (int, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT COUNT(*)
+		FROM pull_requests p
+		INNER JOIN users u ON p.author_id = u.user_id
+		WHERE u.team_name = $1 AND p.status = 'OPEN'
+	`
+
+	var count int
+	err := conn.QueryRow(ctx, query, teamName).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count open PRs: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountOpenByReviewer возвращает количество открытых PR, где пользователь назначен ревьювером
+func (r *PullRequestRepository) CountOpenByReviewer(ctx context.Context, userID string) (int, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT COUNT(DISTINCT p.pull_request_id)
+		FROM pull_requests p
+		INNER JOIN pr_reviewers pr ON p.pull_request_id = pr.pull_request_id
+		WHERE pr.reviewer_id = $1 AND p.status = 'OPEN'
+	`
+
+	var count int
+	err := conn.QueryRow(ctx, query, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count open reviews: %w", err)
+	}
+
+	return count, nil
+}
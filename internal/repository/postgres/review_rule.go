@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+)
+
+// ReviewRuleRepository реализует repository.ReviewRuleRepository для PostgreSQL
+type ReviewRuleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewReviewRuleRepository создает новый репозиторий правил выбора ревьюверов
+func NewReviewRuleRepository(pool *pgxpool.Pool) *ReviewRuleRepository {
+	return &ReviewRuleRepository{pool: pool}
+}
+
+// Create создает новое правило
+func (r *ReviewRuleRepository) Create(ctx context.Context, rule *entity.ReviewRule) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		INSERT INTO review_rules (rule_type, user_id, other_user_id, reason, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	err := conn.QueryRow(ctx, query,
+		rule.Type,
+		rule.UserID,
+		rule.OtherUserID,
+		rule.Reason,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	).Scan(&rule.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to create review rule: %w", err)
+	}
+
+	return nil
+}
+
+// Update обновляет правило
+func (r *ReviewRuleRepository) Update(ctx context.Context, rule *entity.ReviewRule) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		UPDATE review_rules
+		SET rule_type = $2, user_id = $3, other_user_id = $4, reason = $5, updated_at = $6
+		WHERE id = $1
+	`
+
+	result, err := conn.Exec(ctx, query,
+		rule.ID,
+		rule.Type,
+		rule.UserID,
+		rule.OtherUserID,
+		rule.Reason,
+		rule.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update review rule: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete удаляет правило
+func (r *ReviewRuleRepository) Delete(ctx context.Context, ruleID int64) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `DELETE FROM review_rules WHERE id = $1`
+
+	result, err := conn.Exec(ctx, query, ruleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete review rule: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetByID возвращает правило по ID
+func (r *ReviewRuleRepository) GetByID(ctx context.Context, ruleID int64) (*entity.ReviewRule, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT id, rule_type, user_id, other_user_id, reason, created_at, updated_at
+		FROM review_rules
+		WHERE id = $1
+	`
+
+	var rule entity.ReviewRule
+	err := conn.QueryRow(ctx, query, ruleID).Scan(
+		&rule.ID,
+		&rule.Type,
+		&rule.UserID,
+		&rule.OtherUserID,
+		&rule.Reason,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get review rule: %w", err)
+	}
+
+	return &rule, nil
+}
+
+// List возвращает все правила
+func (r *ReviewRuleRepository) List(ctx context.Context) ([]*entity.ReviewRule, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT id, rule_type, user_id, other_user_id, reason, created_at, updated_at
+		FROM review_rules
+		ORDER BY id
+	`
+
+	rows, err := conn.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review rules: %w", err)
+	}
+	defer rows.Close()
+
+	return scanReviewRules(rows)
+}
+
+// GetByUsers возвращает правила, в которых участвует любой из пользователей
+func (r *ReviewRuleRepository) GetByUsers(ctx context.Context, userIDs []string) ([]*entity.ReviewRule, error) {
+	if len(userIDs) == 0 {
+		return []*entity.ReviewRule{}, nil
+	}
+
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT id, rule_type, user_id, other_user_id, reason, created_at, updated_at
+		FROM review_rules
+		WHERE user_id = ANY($1) OR other_user_id = ANY($1)
+		ORDER BY id
+	`
+
+	rows, err := conn.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review rules by users: %w", err)
+	}
+	defer rows.Close()
+
+	return scanReviewRules(rows)
+}
+
+// scanReviewRules читает правила из результата запроса
+func scanReviewRules(rows pgx.Rows) ([]*entity.ReviewRule, error) {
+	var rules []*entity.ReviewRule
+	for rows.Next() {
+		var rule entity.ReviewRule
+		err := rows.Scan(
+			&rule.ID,
+			&rule.Type,
+			&rule.UserID,
+			&rule.OtherUserID,
+			&rule.Reason,
+			&rule.CreatedAt,
+			&rule.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review rule: %w", err)
+		}
+		rules = append(rules, &rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate review rules: %w", err)
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+)
+
+// SizePolicyRepository реализует repository.SizePolicyRepository для PostgreSQL
+type SizePolicyRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSizePolicyRepository создает новый репозиторий политик размера PR
+func NewSizePolicyRepository(pool *pgxpool.Pool) *SizePolicyRepository {
+	return &SizePolicyRepository{pool: pool}
+}
+
+// GetByTeam возвращает классы размера команды, упорядоченные по max_lines
+func (r *SizePolicyRepository) GetByTeam(ctx context.Context, teamName string) ([]*entity.SizeBucket, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT size_class, max_lines, max_files, reviewer_count, sla_hours
+		FROM team_size_buckets
+		WHERE team_name = $1
+		ORDER BY max_lines ASC NULLS LAST, size_class
+	`
+
+	rows, err := conn.Query(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get size buckets: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []*entity.SizeBucket
+	for rows.Next() {
+		var bucket entity.SizeBucket
+		err := rows.Scan(
+			&bucket.SizeClass,
+			&bucket.MaxLines,
+			&bucket.MaxFiles,
+			&bucket.ReviewerCount,
+			&bucket.SLAHours,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan size bucket: %w", err)
+		}
+		buckets = append(buckets, &bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate size buckets: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// ReplaceForTeam полностью заменяет таблицу классов размера команды
+func (r *SizePolicyRepository) ReplaceForTeam(ctx context.Context, teamName string, buckets []*entity.SizeBucket) error {
+	conn := getConn(ctx, r.pool)
+
+	deleteQuery := `DELETE FROM team_size_buckets WHERE team_name = $1`
+	if _, err := conn.Exec(ctx, deleteQuery, teamName); err != nil {
+		return fmt.Errorf("failed to delete old size buckets: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO team_size_buckets (team_name, size_class, max_lines, max_files, reviewer_count, sla_hours)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	for _, bucket := range buckets {
+		_, err := conn.Exec(ctx, insertQuery,
+			teamName,
+			bucket.SizeClass,
+			bucket.MaxLines,
+			bucket.MaxFiles,
+			bucket.ReviewerCount,
+			bucket.SLAHours,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert size bucket %s: %w", bucket.SizeClass, err)
+		}
+	}
+
+	return nil
+}
@@ -24,6 +24,8 @@ func (r *StatisticsRepository) GetStatistics(ctx context.Context) (*entity.Stati
 	stats := &entity.Statistics{
 		AssignmentsByUser: make(map[string]int),
 		AssignmentsByPR:   make(map[string]int),
+		PRsBySizeClass:    make(map[string]int),
+		BotPRsByAuthor:    make(map[string]int),
 	}
 
 	// Получаем общее количество PR
@@ -44,11 +46,12 @@ func (r *StatisticsRepository) GetStatistics(ctx context.Context) (*entity.Stati
 		return nil, fmt.Errorf("failed to get merged PRs: %w", err)
 	}
 
-	// Получаем количество назначений по пользователям
+	// Получаем количество назначений по пользователям (боты ревьюверами не бывают)
 	assignmentsByUserQuery := `
 		SELECT u.username, COUNT(pr.reviewer_id) as assignments
 		FROM users u
 		LEFT JOIN pr_reviewers pr ON u.user_id = pr.reviewer_id
+		WHERE u.kind = 'HUMAN'
 		GROUP BY u.user_id, u.username
 		ORDER BY assignments DESC
 	`
@@ -72,6 +75,20 @@ func (r *StatisticsRepository) GetStatistics(ctx context.Context) (*entity.Stati
 		return nil, fmt.Errorf("failed to iterate assignments by user: %w", err)
 	}
 
+	// Получаем текущую нагрузку пользователей (открытые ревью), нормированную на вес ревью в основной команде
+	weightedLoadQuery := `
+		SELECT u.username, (` + openReviewsCount + ` / COALESCE(m.review_weight, 1))::float8
+		FROM users u
+		LEFT JOIN team_memberships m ON u.user_id = m.user_id AND u.team_name = m.team_name
+		WHERE u.kind = 'HUMAN'
+	`
+
+	weightedLoad, err := r.queryRatios(ctx, weightedLoadQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weighted load by user: %w", err)
+	}
+	stats.WeightedLoadByUser = weightedLoad
+
 	// Получаем количество назначений по PR
 	assignmentsByPRQuery := `
 		SELECT p.pull_request_id, COUNT(pr.reviewer_id) as reviewers_count
@@ -100,6 +117,71 @@ func (r *StatisticsRepository) GetStatistics(ctx context.Context) (*entity.Stati
 		return nil, fmt.Errorf("failed to iterate assignments by PR: %w", err)
 	}
 
+	// Получаем распределение PR по классам размера
+	sizeClassQuery := `
+		SELECT size_class, COUNT(*)
+		FROM pull_requests
+		WHERE size_class IS NOT NULL
+		GROUP BY size_class
+	`
+
+	rows3, err := r.pool.Query(ctx, sizeClassQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PRs by size class: %w", err)
+	}
+	defer rows3.Close()
+
+	for rows3.Next() {
+		var sizeClass string
+		var count int
+		if err := rows3.Scan(&sizeClass, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan PRs by size class: %w", err)
+		}
+		stats.PRsBySizeClass[sizeClass] = count
+	}
+
+	if err := rows3.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate PRs by size class: %w", err)
+	}
+
+	// Получаем PR ботов отдельно от PR людей
+	botPRsQuery := `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE p.status = 'OPEN')
+		FROM pull_requests p
+		INNER JOIN users u ON p.author_id = u.user_id
+		WHERE u.kind = 'BOT'
+	`
+	if err := r.pool.QueryRow(ctx, botPRsQuery).Scan(&stats.BotPRs, &stats.OpenBotPRs); err != nil {
+		return nil, fmt.Errorf("failed to get bot PRs: %w", err)
+	}
+
+	botPRsByAuthorQuery := `
+		SELECT u.username, COUNT(*)
+		FROM pull_requests p
+		INNER JOIN users u ON p.author_id = u.user_id
+		WHERE u.kind = 'BOT'
+		GROUP BY u.user_id, u.username
+	`
+
+	rows4, err := r.pool.Query(ctx, botPRsByAuthorQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bot PRs by author: %w", err)
+	}
+	defer rows4.Close()
+
+	for rows4.Next() {
+		var username string
+		var count int
+		if err := rows4.Scan(&username, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan bot PRs by author: %w", err)
+		}
+		stats.BotPRsByAuthor[username] = count
+	}
+
+	if err := rows4.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate bot PRs by author: %w", err)
+	}
+
 	// Получаем количество команд
 	totalTeamsQuery := `SELECT COUNT(*) FROM teams`
 	if err := r.pool.QueryRow(ctx, totalTeamsQuery).Scan(&stats.TotalTeams); err != nil {
@@ -118,5 +200,167 @@ func (r *StatisticsRepository) GetStatistics(ctx context.Context) (*entity.Stati
 		return nil, fmt.Errorf("failed to get active users: %w", err)
 	}
 
+	// Получаем количество ботов
+	totalBotsQuery := `SELECT COUNT(*) FROM users WHERE kind = 'BOT'`
+	if err := r.pool.QueryRow(ctx, totalBotsQuery).Scan(&stats.TotalBots); err != nil {
+		return nil, fmt.Errorf("failed to get total bots: %w", err)
+	}
+
 	return stats, nil
 }
+
+// GetTeamStatistics возвращает сводную статистику по PR авторов и участникам перечисленных команд
+func (r *StatisticsRepository) GetTeamStatistics(ctx context.Context, teamNames []string) (*entity.TeamStatistics, error) {
+	stats := &entity.TeamStatistics{
+		Teams: teamNames,
+	}
+
+	// Получаем количество PR авторов команд
+	prsQuery := `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE p.status = 'OPEN'),
+		       COUNT(*) FILTER (WHERE p.status = 'MERGED')
+		FROM pull_requests p
+		INNER JOIN users u ON p.author_id = u.user_id
+		WHERE u.team_name = ANY($1)
+	`
+	if err := r.pool.QueryRow(ctx, prsQuery, teamNames).Scan(&stats.TotalPRs, &stats.OpenPRs, &stats.MergedPRs); err != nil {
+		return nil, fmt.Errorf("failed to get team PRs: %w", err)
+	}
+
+	// Получаем распределение PR по командам авторов
+	prsByTeamQuery := `
+		SELECT u.team_name, COUNT(*)
+		FROM pull_requests p
+		INNER JOIN users u ON p.author_id = u.user_id
+		WHERE u.team_name = ANY($1)
+		GROUP BY u.team_name
+	`
+
+	prsByTeam, err := r.queryCounts(ctx, prsByTeamQuery, teamNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PRs by team: %w", err)
+	}
+	stats.PRsByTeam = prsByTeam
+
+	// Получаем распределение PR по классам размера
+	sizeClassQuery := `
+		SELECT p.size_class, COUNT(*)
+		FROM pull_requests p
+		INNER JOIN users u ON p.author_id = u.user_id
+		WHERE u.team_name = ANY($1) AND p.size_class IS NOT NULL
+		GROUP BY p.size_class
+	`
+
+	prsBySizeClass, err := r.queryCounts(ctx, sizeClassQuery, teamNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PRs by size class: %w", err)
+	}
+	stats.PRsBySizeClass = prsBySizeClass
+
+	// Получаем количество назначений участников команд
+	assignmentsByUserQuery := `
+		SELECT u.username, COUNT(pr.reviewer_id) as assignments
+		FROM users u
+		LEFT JOIN pr_reviewers pr ON u.user_id = pr.reviewer_id
+		WHERE u.team_name = ANY($1) AND u.kind = 'HUMAN'
+		GROUP BY u.user_id, u.username
+	`
+
+	assignmentsByUser, err := r.queryCounts(ctx, assignmentsByUserQuery, teamNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignments by user: %w", err)
+	}
+	stats.AssignmentsByUser = assignmentsByUser
+
+	// Получаем текущую нагрузку всех участников команд, включая дополнительные членства.
+	// Вес берётся из членства в запрошенной команде (как при выборе ревьюверов из её пула),
+	// для участников только дочерних команд - из их членства в поддереве.
+	weightedLoadQuery := `
+		SELECT u.username, (` + openReviewsCount + ` / COALESCE(
+			CASE WHEN BOOL_OR(m.team_name = $2)
+				THEN MAX(m.review_weight) FILTER (WHERE m.team_name = $2)
+				ELSE MAX(m.review_weight)
+			END, 1))::float8
+		FROM users u
+		INNER JOIN team_memberships m ON u.user_id = m.user_id AND m.team_name = ANY($1)
+		WHERE u.kind = 'HUMAN'
+		GROUP BY u.user_id, u.username
+	`
+
+	weightedLoad, err := r.queryRatios(ctx, weightedLoadQuery, teamNames, teamNames[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weighted load by user: %w", err)
+	}
+	stats.WeightedLoadByUser = weightedLoad
+
+	// Получаем количество участников команд
+	usersQuery := `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE is_active = true)
+		FROM users
+		WHERE team_name = ANY($1)
+	`
+	if err := r.pool.QueryRow(ctx, usersQuery, teamNames).Scan(&stats.TotalUsers, &stats.ActiveUsers); err != nil {
+		return nil, fmt.Errorf("failed to get team users: %w", err)
+	}
+
+	return stats, nil
+}
+
+// openReviewsCount подзапрос количества открытых PR, на которые назначен пользователь u
+const openReviewsCount = `(
+	SELECT COUNT(*)
+	FROM pr_reviewers r
+	INNER JOIN pull_requests p ON p.pull_request_id = r.pull_request_id
+	WHERE r.reviewer_id = u.user_id AND p.status = 'OPEN'
+)`
+
+// queryCounts выполняет запрос, возвращающий пары (ключ, количество)
+func (r *StatisticsRepository) queryCounts(ctx context.Context, query string, args ...interface{}) (map[string]int, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var key string
+		var count int
+		if err := rows.Scan(&key, &count); err != nil {
+			return nil, err
+		}
+		counts[key] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// queryRatios выполняет запрос, возвращающий пары (ключ, дробное значение)
+func (r *StatisticsRepository) queryRatios(ctx context.Context, query string, args ...interface{}) (map[string]float64, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ratios := make(map[string]float64)
+	for rows.Next() {
+		var key string
+		var ratio float64
+		if err := rows.Scan(&key, &ratio); err != nil {
+			return nil, err
+		}
+		ratios[key] = ratio
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ratios, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -27,11 +28,11 @@ func (r *TeamRepository) Create(ctx context.Context, team *entity.Team) error {
 	conn := getConn(ctx, r.pool)
 
 	query := `
-		INSERT INTO teams (team_name, created_at, updated_at)
-		VALUES ($1, $2, $3)
+		INSERT INTO teams (team_name, parent_team_name, created_at, updated_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4)
 	`
 
-	_, err := conn.Exec(ctx, query, team.TeamName, team.CreatedAt, team.UpdatedAt)
+	_, err := conn.Exec(ctx, query, team.TeamName, team.ParentTeamName, team.CreatedAt, team.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create team: %w", err)
 	}
@@ -44,7 +45,7 @@ func (r *TeamRepository) GetByName(ctx context.Context, teamName string) (*entit
 	conn := getConn(ctx, r.pool)
 
 	query := `
-		SELECT team_name, created_at, updated_at
+		SELECT team_name, COALESCE(parent_team_name, ''), archived_at, created_at, updated_at
 		FROM teams
 		WHERE team_name = $1
 	`
@@ -52,6 +53,8 @@ func (r *TeamRepository) GetByName(ctx context.Context, teamName string) (*entit
 	var team entity.Team
 	err := conn.QueryRow(ctx, query, teamName).Scan(
 		&team.TeamName,
+		&team.ParentTeamName,
+		&team.ArchivedAt,
 		&team.CreatedAt,
 		&team.UpdatedAt,
 	)
@@ -82,3 +85,213 @@ func (r *TeamRepository) Exists(ctx context.Context, teamName string) (bool, err
 
 	return exists, nil
 }
+
+// Rename переименовывает команду; ссылки обновляются каскадом внешних ключей
+func (r *TeamRepository) Rename(ctx context.Context, oldName, newName string) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		UPDATE teams
+		SET team_name = $2, updated_at = NOW()
+		WHERE team_name = $1
+	`
+
+	result, err := conn.Exec(ctx, query, oldName, newName)
+	if err != nil {
+		return fmt.Errorf("failed to rename team: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetArchivedAt архивирует команду или возвращает её из архива (nil)
+func (r *TeamRepository) SetArchivedAt(ctx context.Context, teamName string, archivedAt *time.Time) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		UPDATE teams
+		SET archived_at = $2, updated_at = NOW()
+		WHERE team_name = $1
+	`
+
+	result, err := conn.Exec(ctx, query, teamName, archivedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set team archive state: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete удаляет команду; участники остаются без команды
+func (r *TeamRepository) Delete(ctx context.Context, teamName string) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `DELETE FROM teams WHERE team_name = $1`
+
+	result, err := conn.Exec(ctx, query, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetParent задаёт родительскую команду (пустое имя делает команду корневой)
+func (r *TeamRepository) SetParent(ctx context.Context, teamName, parentTeamName string) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		UPDATE teams
+		SET parent_team_name = NULLIF($2, ''), updated_at = NOW()
+		WHERE team_name = $1
+	`
+
+	result, err := conn.Exec(ctx, query, teamName, parentTeamName)
+	if err != nil {
+		return fmt.Errorf("failed to set parent team: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetAncestors возвращает предков команды, начиная с родителя
+func (r *TeamRepository) GetAncestors(ctx context.Context, teamName string) ([]*entity.Team, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT p.team_name, p.parent_team_name, p.archived_at, p.created_at, p.updated_at, 1 AS depth
+			FROM teams t
+			INNER JOIN teams p ON t.parent_team_name = p.team_name
+			WHERE t.team_name = $1
+			UNION ALL
+			SELECT p.team_name, p.parent_team_name, p.archived_at, p.created_at, p.updated_at, a.depth + 1
+			FROM ancestors a
+			INNER JOIN teams p ON a.parent_team_name = p.team_name
+			WHERE a.depth < $2
+		)
+		SELECT team_name, COALESCE(parent_team_name, ''), archived_at, created_at, updated_at
+		FROM ancestors
+		ORDER BY depth
+	`
+
+	rows, err := conn.Query(ctx, query, teamName, maxTeamDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team ancestors: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTeams(rows)
+}
+
+// GetChildren возвращает прямые дочерние команды
+func (r *TeamRepository) GetChildren(ctx context.Context, teamName string) ([]*entity.Team, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT team_name, COALESCE(parent_team_name, ''), archived_at, created_at, updated_at
+		FROM teams
+		WHERE parent_team_name = $1
+		ORDER BY team_name
+	`
+
+	rows, err := conn.Query(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get child teams: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTeams(rows)
+}
+
+// List возвращает все команды по имени
+func (r *TeamRepository) List(ctx context.Context) ([]*entity.Team, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT team_name, COALESCE(parent_team_name, ''), archived_at, created_at, updated_at
+		FROM teams
+		ORDER BY team_name
+	`
+
+	rows, err := conn.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTeams(rows)
+}
+
+// GetSubtree возвращает команду и всех её потомков
+func (r *TeamRepository) GetSubtree(ctx context.Context, teamName string) ([]*entity.Team, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT team_name, parent_team_name, archived_at, created_at, updated_at, 0 AS depth
+			FROM teams
+			WHERE team_name = $1
+			UNION ALL
+			SELECT c.team_name, c.parent_team_name, c.archived_at, c.created_at, c.updated_at, s.depth + 1
+			FROM subtree s
+			INNER JOIN teams c ON c.parent_team_name = s.team_name
+			WHERE s.depth < $2
+		)
+		SELECT team_name, COALESCE(parent_team_name, ''), archived_at, created_at, updated_at
+		FROM subtree
+		ORDER BY depth, team_name
+	`
+
+	rows, err := conn.Query(ctx, query, teamName, maxTeamDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team subtree: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTeams(rows)
+}
+
+// maxTeamDepth ограничивает глубину обхода иерархии команд
+const maxTeamDepth = 32
+
+// scanTeams читает команды из результата запроса
+func scanTeams(rows pgx.Rows) ([]*entity.Team, error) {
+	var teams []*entity.Team
+	for rows.Next() {
+		var team entity.Team
+		err := rows.Scan(
+			&team.TeamName,
+			&team.ParentTeamName,
+			&team.ArchivedAt,
+			&team.CreatedAt,
+			&team.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teams = append(teams, &team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate teams: %w", err)
+	}
+
+	return teams, nil
+}
@@ -34,6 +34,29 @@ func (tm *TransactionManager) RunInTransaction(ctx context.Context, fn func(ctx
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
+	return runTx(ctx, tx, fn)
+}
+
+// RunInSavepoint выполняет функцию в точке сохранения текущей транзакции: ошибка fn откатывает
+// только её изменения, и вызывающий может продолжить работу в транзакции.
+// Без транзакции в контексте работает как RunInTransaction.
+func (tm *TransactionManager) RunInSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	outer := extractTx(ctx)
+	if outer == nil {
+		return tm.RunInTransaction(ctx, fn)
+	}
+
+	// Начинаем точку сохранения внутри существующей транзакции
+	tx, err := outer.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	return runTx(ctx, tx, fn)
+}
+
+// runTx выполняет функцию в транзакции или точке сохранения tx и завершает её
+func runTx(ctx context.Context, tx pgx.Tx, fn func(ctx context.Context) error) error {
 	// Сохраняем транзакцию в контекст
 	ctx = injectTx(ctx, tx)
 
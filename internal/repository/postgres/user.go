@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -12,6 +13,11 @@ import (
 	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
 )
 
+// userColumns колонки пользователя в порядке scanUser; таблица users должна иметь псевдоним u
+const userColumns = `u.user_id, u.username, COALESCE(u.team_name, ''), u.is_active, u.kind,
+		COALESCE(u.display_name, ''), COALESCE(u.email, ''), COALESCE(u.chat_handle, ''), COALESCE(u.timezone, ''),
+		u.created_at, u.updated_at`
+
 // UserRepository реализует repository.UserRepository для PostgreSQL
 type UserRepository struct {
 	pool *pgxpool.Pool
@@ -27,8 +33,11 @@ func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
 	conn := getConn(ctx, r.pool)
 
 	query := `
-		INSERT INTO users (user_id, username, team_name, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO users (
+			user_id, username, team_name, is_active, kind,
+			display_name, email, chat_handle, timezone, created_at, updated_at
+		)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), $10, $11)
 	`
 
 	_, err := conn.Exec(ctx, query,
@@ -36,6 +45,11 @@ func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
 		user.Username,
 		user.TeamName,
 		user.IsActive,
+		userKind(user),
+		user.DisplayName,
+		user.Email,
+		user.ChatHandle,
+		user.Timezone,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -44,36 +58,49 @@ func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
-	return nil
+	return syncPrimaryMembership(ctx, conn, user.UserID, "", user.TeamName)
 }
 
-// Update обновляет пользователя
+// Update обновляет пользователя. При смене основной команды членство переносится вместе с ней.
 func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
 	conn := getConn(ctx, r.pool)
 
 	query := `
-		UPDATE users
-		SET username = $2, team_name = $3, is_active = $4, updated_at = $5
-		WHERE user_id = $1
+		WITH old AS (
+			SELECT COALESCE(team_name, '') AS team_name FROM users WHERE user_id = $1
+		), updated AS (
+			UPDATE users
+			SET username = $2, team_name = NULLIF($3, ''), is_active = $4, kind = $5,
+			    display_name = NULLIF($6, ''), email = NULLIF($7, ''), chat_handle = NULLIF($8, ''),
+			    timezone = NULLIF($9, ''), updated_at = $10
+			WHERE user_id = $1
+			RETURNING user_id
+		)
+		SELECT old.team_name FROM old, updated
 	`
 
-	result, err := conn.Exec(ctx, query,
+	var oldTeamName string
+	err := conn.QueryRow(ctx, query,
 		user.UserID,
 		user.Username,
 		user.TeamName,
 		user.IsActive,
+		userKind(user),
+		user.DisplayName,
+		user.Email,
+		user.ChatHandle,
+		user.Timezone,
 		user.UpdatedAt,
-	)
+	).Scan(&oldTeamName)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domainErrors.ErrNotFound
+		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return domainErrors.ErrNotFound
-	}
-
-	return nil
+	return syncPrimaryMembership(ctx, conn, user.UserID, oldTeamName, user.TeamName)
 }
 
 // GetByID возвращает пользователя по ID
@@ -81,21 +108,12 @@ func (r *UserRepository) GetByID(ctx context.Context, userID string) (*entity.Us
 	conn := getConn(ctx, r.pool)
 
 	query := `
-		SELECT user_id, username, team_name, is_active, created_at, updated_at
-		FROM users
-		WHERE user_id = $1
+		SELECT ` + userColumns + `
+		FROM users u
+		WHERE u.user_id = $1
 	`
 
-	var user entity.User
-	err := conn.QueryRow(ctx, query, userID).Scan(
-		&user.UserID,
-		&user.Username,
-		&user.TeamName,
-		&user.IsActive,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
+	user, err := scanUser(conn.QueryRow(ctx, query, userID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domainErrors.ErrNotFound
@@ -103,18 +121,19 @@ func (r *UserRepository) GetByID(ctx context.Context, userID string) (*entity.Us
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	return &user, nil
+	return user, nil
 }
 
-// GetByTeam возвращает всех пользователей команды
+// GetByTeam возвращает всех участников команды, включая тех, для кого она не основная
 func (r *UserRepository) GetByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
 	conn := getConn(ctx, r.pool)
 
 	query := `
-		SELECT user_id, username, team_name, is_active, created_at, updated_at
-		FROM users
-		WHERE team_name = $1
-		ORDER BY username
+		SELECT ` + userColumns + `
+		FROM users u
+		INNER JOIN team_memberships m ON u.user_id = m.user_id
+		WHERE m.team_name = $1
+		ORDER BY u.username
 	`
 
 	rows, err := conn.Query(ctx, query, teamName)
@@ -125,19 +144,11 @@ func (r *UserRepository) GetByTeam(ctx context.Context, teamName string) ([]*ent
 
 	var users []*entity.User
 	for rows.Next() {
-		var user entity.User
-		err := rows.Scan(
-			&user.UserID,
-			&user.Username,
-			&user.TeamName,
-			&user.IsActive,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
+		user, err := scanUser(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
-		users = append(users, &user)
+		users = append(users, user)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -147,15 +158,17 @@ func (r *UserRepository) GetByTeam(ctx context.Context, teamName string) ([]*ent
 	return users, nil
 }
 
-// GetActiveByTeam возвращает активных пользователей команды
+// GetActiveByTeam возвращает активных участников команды (для архивной команды — никого)
 func (r *UserRepository) GetActiveByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
 	conn := getConn(ctx, r.pool)
 
 	query := `
-		SELECT user_id, username, team_name, is_active, created_at, updated_at
-		FROM users
-		WHERE team_name = $1 AND is_active = true
-		ORDER BY username
+		SELECT ` + userColumns + `
+		FROM users u
+		INNER JOIN team_memberships m ON u.user_id = m.user_id
+		INNER JOIN teams t ON m.team_name = t.team_name
+		WHERE m.team_name = $1 AND u.is_active = true AND t.archived_at IS NULL
+		ORDER BY u.username
 	`
 
 	rows, err := conn.Query(ctx, query, teamName)
@@ -166,19 +179,11 @@ func (r *UserRepository) GetActiveByTeam(ctx context.Context, teamName string) (
 
 	var users []*entity.User
 	for rows.Next() {
-		var user entity.User
-		err := rows.Scan(
-			&user.UserID,
-			&user.Username,
-			&user.TeamName,
-			&user.IsActive,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
+		user, err := scanUser(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
-		users = append(users, &user)
+		users = append(users, user)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -192,29 +197,187 @@ func (r *UserRepository) GetActiveByTeam(ctx context.Context, teamName string) (
 func (r *UserRepository) UpsertBatch(ctx context.Context, users []*entity.User) error {
 	conn := getConn(ctx, r.pool)
 
+	oldTeamQuery := `SELECT COALESCE(team_name, '') FROM users WHERE user_id = $1`
+
 	query := `
-		INSERT INTO users (user_id, username, team_name, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO users (
+			user_id, username, team_name, is_active, kind,
+			display_name, email, chat_handle, timezone, created_at, updated_at
+		)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), $10, $11)
 		ON CONFLICT (user_id) DO UPDATE
 		SET username = EXCLUDED.username,
 		    team_name = EXCLUDED.team_name,
 		    is_active = EXCLUDED.is_active,
+		    kind = EXCLUDED.kind,
+		    display_name = EXCLUDED.display_name,
+		    email = EXCLUDED.email,
+		    chat_handle = EXCLUDED.chat_handle,
+		    timezone = EXCLUDED.timezone,
 		    updated_at = EXCLUDED.updated_at
 	`
 
 	for _, user := range users {
-		_, err := conn.Exec(ctx, query,
+		var oldTeamName string
+		err := conn.QueryRow(ctx, oldTeamQuery, user.UserID).Scan(&oldTeamName)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to get user %s: %w", user.UserID, err)
+		}
+
+		_, err = conn.Exec(ctx, query,
 			user.UserID,
 			user.Username,
 			user.TeamName,
 			user.IsActive,
+			userKind(user),
+			user.DisplayName,
+			user.Email,
+			user.ChatHandle,
+			user.Timezone,
 			user.CreatedAt,
 			user.UpdatedAt,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to upsert user %s: %w", user.UserID, err)
 		}
+
+		if err := syncPrimaryMembership(ctx, conn, user.UserID, oldTeamName, user.TeamName); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// List возвращает страницу пользователей по фильтру, упорядоченную по username,
+// с числом открытых ревью, и общее число подходящих пользователей
+func (r *UserRepository) List(ctx context.Context, filter entity.UserFilter) ([]*entity.UserWithOpenReviews, int, error) {
+	conn := getConn(ctx, r.pool)
+
+	where := `
+		WHERE ($1 = '' OR EXISTS (
+		          SELECT 1 FROM team_memberships m WHERE m.user_id = u.user_id AND m.team_name = $1
+		      ))
+		  AND ($2::boolean IS NULL OR u.is_active = $2)
+		  AND ($3 = '' OR u.username ILIKE $3 || '%' ESCAPE '\' OR u.display_name ILIKE $3 || '%' ESCAPE '\')
+		  AND ($4 = '' OR lower(u.username) = lower($4))
+		  AND ($5 = '' OR lower(u.email) = lower($5))
+	`
+	prefix := likeEscaper.Replace(filter.NamePrefix)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM users u` + where
+	if err := conn.QueryRow(ctx, countQuery, filter.TeamName, filter.IsActive, prefix, filter.Username, filter.Email).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	query := `
+		SELECT ` + userColumns + `,
+		       (SELECT COUNT(DISTINCT p.pull_request_id)
+		        FROM pr_reviewers pr
+		        INNER JOIN pull_requests p ON p.pull_request_id = pr.pull_request_id
+		        WHERE pr.reviewer_id = u.user_id AND p.status = 'OPEN')
+		FROM users u` + where + `
+		ORDER BY u.username, u.user_id
+		LIMIT $6 OFFSET $7
+	`
+
+	rows, err := conn.Query(ctx, query,
+		filter.TeamName, filter.IsActive, prefix, filter.Username, filter.Email, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*entity.UserWithOpenReviews
+	for rows.Next() {
+		var user entity.UserWithOpenReviews
+		err := rows.Scan(
+			&user.UserID,
+			&user.Username,
+			&user.TeamName,
+			&user.IsActive,
+			&user.Kind,
+			&user.DisplayName,
+			&user.Email,
+			&user.ChatHandle,
+			&user.Timezone,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.OpenReviews,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+// likeEscaper экранирует спецсимволы шаблона LIKE
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// syncPrimaryMembership переносит членство пользователя вслед за его основной командой.
+// Членство в новой основной команде сохраняет роль и вес, если уже существовало.
+func syncPrimaryMembership(ctx context.Context, conn querier, userID, oldTeamName, newTeamName string) error {
+	if oldTeamName == newTeamName {
+		if newTeamName == "" {
+			return nil
+		}
+	} else if oldTeamName != "" {
+		deleteQuery := `DELETE FROM team_memberships WHERE team_name = $1 AND user_id = $2`
+		if _, err := conn.Exec(ctx, deleteQuery, oldTeamName, userID); err != nil {
+			return fmt.Errorf("failed to remove membership in team %s: %w", oldTeamName, err)
+		}
+	}
+
+	if newTeamName == "" {
+		return nil
+	}
+
+	insertQuery := `
+		INSERT INTO team_memberships (team_name, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (team_name, user_id) DO NOTHING
+	`
+	if _, err := conn.Exec(ctx, insertQuery, newTeamName, userID); err != nil {
+		return fmt.Errorf("failed to add membership in team %s: %w", newTeamName, err)
+	}
+
+	return nil
+}
+
+// scanUser читает пользователя из строки с колонками userColumns
+func scanUser(row pgx.Row) (*entity.User, error) {
+	var user entity.User
+	err := row.Scan(
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.Kind,
+		&user.DisplayName,
+		&user.Email,
+		&user.ChatHandle,
+		&user.Timezone,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// userKind возвращает тип пользователя, подставляя HUMAN по умолчанию
+func userKind(user *entity.User) entity.UserKind {
+	if user.Kind == "" {
+		return entity.UserKindHuman
+	}
+	return user.Kind
+}
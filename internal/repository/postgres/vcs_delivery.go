@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+)
+
+// VCSDeliveryRepository реализует repository.VCSDeliveryRepository для PostgreSQL
+type VCSDeliveryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewVCSDeliveryRepository создает новый репозиторий доставок вебхуков
+func NewVCSDeliveryRepository(pool *pgxpool.Pool) *VCSDeliveryRepository {
+	return &VCSDeliveryRepository{pool: pool}
+}
+
+// Record сохраняет доставку. Параллельная доставка с тем же ID ждёт завершения транзакции первой
+// и получает false, поэтому событие обрабатывается один раз.
+func (r *VCSDeliveryRepository) Record(
+	ctx context.Context,
+	provider entity.VCSProvider,
+	deliveryID, event string,
+	receivedAt time.Time,
+) (bool, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		INSERT INTO vcs_deliveries (provider, delivery_id, event, received_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, delivery_id) DO NOTHING
+	`
+
+	result, err := conn.Exec(ctx, query, provider, deliveryID, event, receivedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to record vcs delivery: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+)
+
+// VCSIdentityRepository реализует repository.VCSIdentityRepository для PostgreSQL
+type VCSIdentityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewVCSIdentityRepository создает новый репозиторий логинов хостингов кода
+func NewVCSIdentityRepository(pool *pgxpool.Pool) *VCSIdentityRepository {
+	return &VCSIdentityRepository{pool: pool}
+}
+
+// Upsert привязывает логин к пользователю, заменяя прежнюю привязку
+func (r *VCSIdentityRepository) Upsert(ctx context.Context, identity *entity.VCSIdentity) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		INSERT INTO vcs_identities (provider, login, user_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, login) DO UPDATE
+		SET user_id = EXCLUDED.user_id,
+		    created_at = EXCLUDED.created_at
+	`
+
+	_, err := conn.Exec(ctx, query, identity.Provider, identity.Login, identity.UserID, identity.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert vcs identity: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет привязку логина
+func (r *VCSIdentityRepository) Delete(ctx context.Context, provider entity.VCSProvider, login string) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `DELETE FROM vcs_identities WHERE provider = $1 AND login = $2`
+
+	result, err := conn.Exec(ctx, query, provider, login)
+	if err != nil {
+		return fmt.Errorf("failed to delete vcs identity: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Get возвращает привязку логина
+func (r *VCSIdentityRepository) Get(ctx context.Context, provider entity.VCSProvider, login string) (*entity.VCSIdentity, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT provider, login, user_id, created_at
+		FROM vcs_identities
+		WHERE provider = $1 AND login = $2
+	`
+
+	var identity entity.VCSIdentity
+	err := conn.QueryRow(ctx, query, provider, login).Scan(
+		&identity.Provider,
+		&identity.Login,
+		&identity.UserID,
+		&identity.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get vcs identity: %w", err)
+	}
+
+	return &identity, nil
+}
+
+// List возвращает привязки; пустые provider и userID не фильтруют
+func (r *VCSIdentityRepository) List(ctx context.Context, provider entity.VCSProvider, userID string) ([]*entity.VCSIdentity, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT provider, login, user_id, created_at
+		FROM vcs_identities
+		WHERE ($1 = '' OR provider = $1) AND ($2 = '' OR user_id = $2)
+		ORDER BY provider, login
+	`
+
+	rows, err := conn.Query(ctx, query, provider, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vcs identities: %w", err)
+	}
+	defer rows.Close()
+
+	var identities []*entity.VCSIdentity
+	for rows.Next() {
+		var identity entity.VCSIdentity
+		if err := rows.Scan(&identity.Provider, &identity.Login, &identity.UserID, &identity.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan vcs identity: %w", err)
+		}
+		identities = append(identities, &identity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate vcs identities: %w", err)
+	}
+
+	return identities, nil
+}
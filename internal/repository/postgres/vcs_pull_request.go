@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+)
+
+// VCSPullRequestRepository реализует repository.VCSPullRequestRepository для PostgreSQL
+type VCSPullRequestRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewVCSPullRequestRepository создает новый репозиторий связей PR с хостингом кода
+func NewVCSPullRequestRepository(pool *pgxpool.Pool) *VCSPullRequestRepository {
+	return &VCSPullRequestRepository{pool: pool}
+}
+
+// Upsert сохраняет связь PR с PR на хостинге кода
+func (r *VCSPullRequestRepository) Upsert(ctx context.Context, link *entity.VCSPullRequestLink) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		INSERT INTO vcs_pull_requests (pull_request_id, provider, repository, number, url)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (pull_request_id) DO UPDATE
+		SET provider = EXCLUDED.provider,
+		    repository = EXCLUDED.repository,
+		    number = EXCLUDED.number,
+		    url = EXCLUDED.url
+	`
+
+	_, err := conn.Exec(ctx, query, link.PullRequestID, link.Provider, link.Repository, link.Number, link.URL)
+	if err != nil {
+		return fmt.Errorf("failed to upsert vcs pull request link: %w", err)
+	}
+
+	return nil
+}
+
+// GetByPullRequest возвращает связь PR с хостингом кода
+func (r *VCSPullRequestRepository) GetByPullRequest(ctx context.Context, prID string) (*entity.VCSPullRequestLink, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT pull_request_id, provider, repository, number, url
+		FROM vcs_pull_requests
+		WHERE pull_request_id = $1
+	`
+
+	var link entity.VCSPullRequestLink
+	err := conn.QueryRow(ctx, query, prID).Scan(
+		&link.PullRequestID,
+		&link.Provider,
+		&link.Repository,
+		&link.Number,
+		&link.URL,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get vcs pull request link: %w", err)
+	}
+
+	return &link, nil
+}
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+)
+
+// VCSReviewerRequestRepository реализует repository.VCSReviewerRequestRepository для PostgreSQL
+type VCSReviewerRequestRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewVCSReviewerRequestRepository создает новый репозиторий запросов ревьюверов на хостинге кода
+func NewVCSReviewerRequestRepository(pool *pgxpool.Pool) *VCSReviewerRequestRepository {
+	return &VCSReviewerRequestRepository{pool: pool}
+}
+
+const vcsReviewerRequestColumns = `
+	id, pull_request_id, added_reviewers, removed_reviewers, status, attempts, next_attempt_at, last_error, created_at, sent_at
+`
+
+// Create ставит запрос в очередь на отправку
+func (r *VCSReviewerRequestRepository) Create(ctx context.Context, request *entity.VCSReviewerRequest) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		INSERT INTO vcs_reviewer_requests (pull_request_id, added_reviewers, removed_reviewers, status, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	err := conn.QueryRow(ctx, query,
+		request.PullRequestID,
+		nonNilStrings(request.AddedReviewers),
+		nonNilStrings(request.RemovedReviewers),
+		request.Status,
+		request.NextAttemptAt,
+		request.CreatedAt,
+	).Scan(&request.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to create vcs reviewer request: %w", err)
+	}
+
+	return nil
+}
+
+// Update сохраняет результат попытки отправки
+func (r *VCSReviewerRequestRepository) Update(ctx context.Context, request *entity.VCSReviewerRequest) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		UPDATE vcs_reviewer_requests
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, sent_at = $6
+		WHERE id = $1
+	`
+
+	result, err := conn.Exec(ctx, query,
+		request.ID,
+		request.Status,
+		request.Attempts,
+		request.NextAttemptAt,
+		request.LastError,
+		request.SentAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update vcs reviewer request: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// LockNextDue блокирует ближайший готовый к отправке запрос до конца транзакции.
+// Запрос пропускается, пока у того же PR есть более ранний неотправленный запрос,
+// чтобы изменения ревьюверов доходили до хостинга в порядке появления. Возвращает nil, если таких нет.
+func (r *VCSReviewerRequestRepository) LockNextDue(ctx context.Context, now time.Time) (*entity.VCSReviewerRequest, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT ` + vcsReviewerRequestColumns + `
+		FROM vcs_reviewer_requests r
+		WHERE r.status = 'PENDING' AND r.next_attempt_at <= $1
+			AND NOT EXISTS (
+				SELECT 1 FROM vcs_reviewer_requests e
+				WHERE e.pull_request_id = r.pull_request_id AND e.status = 'PENDING' AND e.id < r.id
+			)
+		ORDER BY r.next_attempt_at, r.id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	request, err := scanVCSReviewerRequest(conn.QueryRow(ctx, query, now))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock due vcs reviewer request: %w", err)
+	}
+
+	return request, nil
+}
+
+// List возвращает запросы в порядке создания; пустые prID и status не фильтруют
+func (r *VCSReviewerRequestRepository) List(
+	ctx context.Context,
+	prID string,
+	status entity.VCSReviewerRequestStatus,
+) ([]*entity.VCSReviewerRequest, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT ` + vcsReviewerRequestColumns + `
+		FROM vcs_reviewer_requests
+		WHERE ($1 = '' OR pull_request_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY id
+	`
+
+	rows, err := conn.Query(ctx, query, prID, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vcs reviewer requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []*entity.VCSReviewerRequest
+	for rows.Next() {
+		request, err := scanVCSReviewerRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vcs reviewer request: %w", err)
+		}
+		requests = append(requests, request)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate vcs reviewer requests: %w", err)
+	}
+
+	return requests, nil
+}
+
+// scanVCSReviewerRequest читает запрос из строки результата
+func scanVCSReviewerRequest(row pgx.Row) (*entity.VCSReviewerRequest, error) {
+	var request entity.VCSReviewerRequest
+	err := row.Scan(
+		&request.ID,
+		&request.PullRequestID,
+		&request.AddedReviewers,
+		&request.RemovedReviewers,
+		&request.Status,
+		&request.Attempts,
+		&request.NextAttemptAt,
+		&request.LastError,
+		&request.CreatedAt,
+		&request.SentAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// nonNilStrings заменяет nil на пустой срез, чтобы в TEXT[] не попал NULL
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
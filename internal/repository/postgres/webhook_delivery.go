@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+)
+
+// WebhookDeliveryRepository реализует repository.WebhookDeliveryRepository для PostgreSQL
+type WebhookDeliveryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWebhookDeliveryRepository создает новый репозиторий доставок вебхуков
+func NewWebhookDeliveryRepository(pool *pgxpool.Pool) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{pool: pool}
+}
+
+const webhookDeliveryColumns = `
+	id, subscription_id, event_id, event_type, pull_request_id, payload, status, attempts, next_attempt_at,
+	response_status, last_error, created_at, delivered_at
+`
+
+// Create ставит доставку в очередь
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, pull_request_id, payload, status, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	err := conn.QueryRow(ctx, query,
+		delivery.SubscriptionID,
+		delivery.EventID,
+		delivery.EventType,
+		delivery.PullRequestID,
+		delivery.Payload,
+		delivery.Status,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+	).Scan(&delivery.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID возвращает доставку
+func (r *WebhookDeliveryRepository) GetByID(ctx context.Context, deliveryID int64) (*entity.WebhookDelivery, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+
+	delivery, err := scanWebhookDelivery(conn.QueryRow(ctx, query, deliveryID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// Update сохраняет результат попытки доставки
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, response_status = $5, last_error = $6, delivered_at = $7
+		WHERE id = $1
+	`
+
+	result, err := conn.Exec(ctx, query,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// LockNextDue блокирует ближайшую готовую доставку до конца транзакции.
+// Доставки, уже заблокированные другим обработчиком, пропускаются, как и доставки, перед которыми
+// у той же подписки есть недоставленное событие того же PR. Возвращает nil, если таких нет.
+func (r *WebhookDeliveryRepository) LockNextDue(ctx context.Context, now time.Time) (*entity.WebhookDelivery, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries d
+		WHERE d.status = 'PENDING' AND d.next_attempt_at <= $1
+			AND (d.pull_request_id = '' OR NOT EXISTS (
+				SELECT 1 FROM webhook_deliveries e
+				WHERE e.subscription_id = d.subscription_id AND e.pull_request_id = d.pull_request_id
+					AND e.status = 'PENDING' AND e.id < d.id
+			))
+		ORDER BY d.next_attempt_at, d.id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	delivery, err := scanWebhookDelivery(conn.QueryRow(ctx, query, now))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock due webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// List возвращает доставки, новые первыми; нулевой subscriptionID и пустой status не фильтруют
+func (r *WebhookDeliveryRepository) List(
+	ctx context.Context,
+	subscriptionID int64,
+	status entity.WebhookDeliveryStatus,
+) ([]*entity.WebhookDelivery, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE ($1 = 0 OR subscription_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY id DESC
+	`
+
+	rows, err := conn.Query(ctx, query, subscriptionID, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*entity.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// scanWebhookDelivery читает доставку из строки результата
+func scanWebhookDelivery(row pgx.Row) (*entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.PullRequestID,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.ResponseStatus,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&delivery.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
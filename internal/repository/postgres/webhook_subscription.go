@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+)
+
+// WebhookSubscriptionRepository реализует repository.WebhookSubscriptionRepository для PostgreSQL
+type WebhookSubscriptionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWebhookSubscriptionRepository создает новый репозиторий подписок на вебхуки
+func NewWebhookSubscriptionRepository(pool *pgxpool.Pool) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{pool: pool}
+}
+
+const webhookSubscriptionColumns = `id, url, secret, event_types, COALESCE(team_name, ''), created_at`
+
+// Create сохраняет подписку
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `
+		INSERT INTO webhook_subscriptions (url, secret, event_types, team_name, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		RETURNING id
+	`
+
+	eventTypes := make([]string, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	err := conn.QueryRow(ctx, query,
+		subscription.URL,
+		subscription.Secret,
+		eventTypes,
+		subscription.TeamName,
+		subscription.CreatedAt,
+	).Scan(&subscription.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID возвращает подписку
+func (r *WebhookSubscriptionRepository) GetByID(ctx context.Context, subscriptionID int64) (*entity.WebhookSubscription, error) {
+	conn := getConn(ctx, r.pool)
+
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+
+	subscription, err := scanWebhookSubscription(conn.QueryRow(ctx, query, subscriptionID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// List возвращает все подписки в порядке создания
+func (r *WebhookSubscriptionRepository) List(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions ORDER BY id`
+
+	return r.query(ctx, query)
+}
+
+// ListMatching возвращает подписки на тип события с фильтром по команде teamName или без фильтра
+func (r *WebhookSubscriptionRepository) ListMatching(
+	ctx context.Context,
+	eventType entity.EventType,
+	teamName string,
+) ([]*entity.WebhookSubscription, error) {
+	query := `
+		SELECT ` + webhookSubscriptionColumns + `
+		FROM webhook_subscriptions
+		WHERE $1 = ANY(event_types) AND (team_name IS NULL OR team_name = $2)
+		ORDER BY id
+	`
+
+	return r.query(ctx, query, string(eventType), teamName)
+}
+
+// Delete удаляет подписку вместе с журналом доставок
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, subscriptionID int64) error {
+	conn := getConn(ctx, r.pool)
+
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1`
+
+	result, err := conn.Exec(ctx, query, subscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// query выполняет выборку подписок
+func (r *WebhookSubscriptionRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entity.WebhookSubscription, error) {
+	conn := getConn(ctx, r.pool)
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*entity.WebhookSubscription
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// scanWebhookSubscription читает подписку из строки результата
+func scanWebhookSubscription(row pgx.Row) (*entity.WebhookSubscription, error) {
+	var subscription entity.WebhookSubscription
+	var eventTypes []string
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.URL,
+		&subscription.Secret,
+		&eventTypes,
+		&subscription.TeamName,
+		&subscription.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.EventTypes = make([]entity.EventType, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		subscription.EventTypes = append(subscription.EventTypes, entity.EventType(eventType))
+	}
+
+	return &subscription, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
 )
@@ -13,12 +14,29 @@ type UserRepository interface {
 	GetByTeam(ctx context.Context, teamName string) ([]*entity.User, error)
 	GetActiveByTeam(ctx context.Context, teamName string) ([]*entity.User, error)
 	UpsertBatch(ctx context.Context, users []*entity.User) error
+	List(ctx context.Context, filter entity.UserFilter) ([]*entity.UserWithOpenReviews, int, error)
 }
 
 type TeamRepository interface {
 	Create(ctx context.Context, team *entity.Team) error
 	GetByName(ctx context.Context, teamName string) (*entity.Team, error)
 	Exists(ctx context.Context, teamName string) (bool, error)
+	Rename(ctx context.Context, oldName, newName string) error
+	SetArchivedAt(ctx context.Context, teamName string, archivedAt *time.Time) error
+	Delete(ctx context.Context, teamName string) error
+	SetParent(ctx context.Context, teamName, parentTeamName string) error
+	GetAncestors(ctx context.Context, teamName string) ([]*entity.Team, error)
+	GetChildren(ctx context.Context, teamName string) ([]*entity.Team, error)
+	GetSubtree(ctx context.Context, teamName string) ([]*entity.Team, error)
+	List(ctx context.Context) ([]*entity.Team, error)
+}
+
+type MembershipRepository interface {
+	Upsert(ctx context.Context, membership *entity.TeamMembership) error
+	Remove(ctx context.Context, teamName, userID string) error
+	Get(ctx context.Context, teamName, userID string) (*entity.TeamMembership, error)
+	GetByTeam(ctx context.Context, teamName string) ([]*entity.TeamMembership, error)
+	GetByUser(ctx context.Context, userID string) ([]*entity.TeamMembership, error)
 }
 
 type PullRequestRepository interface {
@@ -28,12 +46,105 @@ type PullRequestRepository interface {
 	GetByReviewer(ctx context.Context, userID string) ([]*entity.PullRequestShort, error)
 	Exists(ctx context.Context, prID string) (bool, error)
 	GetOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]*entity.PullRequest, error)
+	CountOpenByAuthorTeam(ctx context.Context, teamName string) (int, error)
+	CountOpenByReviewer(ctx context.Context, userID string) (int, error)
+}
+
+type SizePolicyRepository interface {
+	GetByTeam(ctx context.Context, teamName string) ([]*entity.SizeBucket, error)
+	ReplaceForTeam(ctx context.Context, teamName string, buckets []*entity.SizeBucket) error
+}
+
+type EscalationRepository interface {
+	GetByTeam(ctx context.Context, teamName string) ([]*entity.EscalationContact, error)
+	ReplaceForTeam(ctx context.Context, teamName string, contacts []*entity.EscalationContact) error
+}
+
+type PRHistoryRepository interface {
+	Add(ctx context.Context, entry *entity.PRHistoryEntry) error
+	GetByPR(ctx context.Context, prID string) ([]*entity.PRHistoryEntry, error)
+}
+
+type ReviewRuleRepository interface {
+	Create(ctx context.Context, rule *entity.ReviewRule) error
+	Update(ctx context.Context, rule *entity.ReviewRule) error
+	Delete(ctx context.Context, ruleID int64) error
+	GetByID(ctx context.Context, ruleID int64) (*entity.ReviewRule, error)
+	List(ctx context.Context) ([]*entity.ReviewRule, error)
+	GetByUsers(ctx context.Context, userIDs []string) ([]*entity.ReviewRule, error)
+}
+
+type ActivationScheduleRepository interface {
+	Create(ctx context.Context, change *entity.ScheduledActivationChange) error
+	GetByID(ctx context.Context, changeID int64) (*entity.ScheduledActivationChange, error)
+	GetReverse(ctx context.Context, changeID int64) (*entity.ScheduledActivationChange, error)
+	List(ctx context.Context, userID string, status entity.ActivationChangeStatus) ([]*entity.ScheduledActivationChange, error)
+	LockNextDue(ctx context.Context, now time.Time) (*entity.ScheduledActivationChange, error)
+	SetStatus(ctx context.Context, changeID int64, status entity.ActivationChangeStatus, processedAt time.Time) error
+}
+
+type ActivationAuditRepository interface {
+	Add(ctx context.Context, entry *entity.ActivationAuditEntry) error
+	GetByUser(ctx context.Context, userID string) ([]*entity.ActivationAuditEntry, error)
+}
+
+type VCSIdentityRepository interface {
+	Upsert(ctx context.Context, identity *entity.VCSIdentity) error
+	Delete(ctx context.Context, provider entity.VCSProvider, login string) error
+	Get(ctx context.Context, provider entity.VCSProvider, login string) (*entity.VCSIdentity, error)
+	List(ctx context.Context, provider entity.VCSProvider, userID string) ([]*entity.VCSIdentity, error)
+}
+
+type VCSDeliveryRepository interface {
+	// Record сохраняет доставку вебхука и возвращает false, если она уже была обработана
+	Record(ctx context.Context, provider entity.VCSProvider, deliveryID, event string, receivedAt time.Time) (bool, error)
+}
+
+type VCSPullRequestRepository interface {
+	Upsert(ctx context.Context, link *entity.VCSPullRequestLink) error
+	GetByPullRequest(ctx context.Context, prID string) (*entity.VCSPullRequestLink, error)
+}
+
+type VCSReviewerRequestRepository interface {
+	Create(ctx context.Context, request *entity.VCSReviewerRequest) error
+	Update(ctx context.Context, request *entity.VCSReviewerRequest) error
+	// LockNextDue блокирует самый ранний готовый к отправке запрос, перед которым нет неотправленных запросов того же PR
+	LockNextDue(ctx context.Context, now time.Time) (*entity.VCSReviewerRequest, error)
+	List(ctx context.Context, prID string, status entity.VCSReviewerRequestStatus) ([]*entity.VCSReviewerRequest, error)
+}
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *entity.WebhookSubscription) error
+	GetByID(ctx context.Context, subscriptionID int64) (*entity.WebhookSubscription, error)
+	List(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	Delete(ctx context.Context, subscriptionID int64) error
+	// ListMatching возвращает подписки на тип события с фильтром по команде teamName или без фильтра
+	ListMatching(ctx context.Context, eventType entity.EventType, teamName string) ([]*entity.WebhookSubscription, error)
+}
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *entity.WebhookDelivery) error
+	GetByID(ctx context.Context, deliveryID int64) (*entity.WebhookDelivery, error)
+	Update(ctx context.Context, delivery *entity.WebhookDelivery) error
+	LockNextDue(ctx context.Context, now time.Time) (*entity.WebhookDelivery, error)
+	List(ctx context.Context, subscriptionID int64, status entity.WebhookDeliveryStatus) ([]*entity.WebhookDelivery, error)
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, message *entity.OutboxMessage) error
+	Update(ctx context.Context, message *entity.OutboxMessage) error
+	// LockNextDue блокирует самое раннее готовое событие, перед которым нет ожидающих публикации событий того же PR
+	LockNextDue(ctx context.Context, now time.Time) (*entity.OutboxMessage, error)
+	List(ctx context.Context, prID string, status entity.OutboxStatus) ([]*entity.OutboxMessage, error)
 }
 
 type TransactionManager interface {
 	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// RunInSavepoint изолирует fn в точке сохранения текущей транзакции
+	RunInSavepoint(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type StatisticsRepository interface {
 	GetStatistics(ctx context.Context) (*entity.Statistics, error)
+	GetTeamStatistics(ctx context.Context, teamNames []string) (*entity.TeamStatistics, error)
 }
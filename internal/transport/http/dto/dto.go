@@ -19,21 +19,34 @@ type ErrorDetail struct {
 
 // TeamMemberDTO представляет участника команды
 type TeamMemberDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID       string   `json:"user_id"`
+	Username     string   `json:"username"`
+	IsActive     bool     `json:"is_active"`
+	Kind         string   `json:"kind,omitempty"`
+	DisplayName  string   `json:"display_name,omitempty"`
+	Email        string   `json:"email,omitempty"`
+	ChatHandle   string   `json:"chat_handle,omitempty"`
+	Timezone     string   `json:"timezone,omitempty"`
+	Role         string   `json:"role,omitempty"`
+	ReviewWeight *float64 `json:"review_weight,omitempty"`
+	Primary      bool     `json:"primary,omitempty"`
 }
 
 // TeamDTO представляет команду
 type TeamDTO struct {
-	TeamName string          `json:"team_name"`
-	Members  []TeamMemberDTO `json:"members"`
+	TeamName       string          `json:"team_name"`
+	ParentTeamName string          `json:"parent_team_name,omitempty"`
+	Ancestors      []string        `json:"ancestors,omitempty"`
+	Children       []string        `json:"children,omitempty"`
+	Archived       bool            `json:"archived,omitempty"`
+	Members        []TeamMemberDTO `json:"members"`
 }
 
 // CreateTeamRequest запрос на создание команды
 type CreateTeamRequest struct {
-	TeamName string          `json:"team_name"`
-	Members  []TeamMemberDTO `json:"members"`
+	TeamName       string          `json:"team_name"`
+	ParentTeamName string          `json:"parent_team_name,omitempty"`
+	Members        []TeamMemberDTO `json:"members"`
 }
 
 // CreateTeamResponse ответ на создание команды
@@ -43,30 +56,91 @@ type CreateTeamResponse struct {
 
 // UserDTO представляет пользователя
 type UserDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	TeamName    string `json:"team_name"`
+	IsActive    bool   `json:"is_active"`
+	Kind        string `json:"kind"`
+	DisplayName string `json:"display_name,omitempty"`
+	Email       string `json:"email,omitempty"`
+	ChatHandle  string `json:"chat_handle,omitempty"`
+	Timezone    string `json:"timezone,omitempty"`
+}
+
+// UpdateUserRequest запрос на изменение пользователя.
+// Отсутствующее поле не меняется, пустая строка очищает поле профиля.
+type UpdateUserRequest struct {
+	UserID      string  `json:"user_id"`
+	Username    *string `json:"username,omitempty"`
+	DisplayName *string `json:"display_name,omitempty"`
+	Email       *string `json:"email,omitempty"`
+	ChatHandle  *string `json:"chat_handle,omitempty"`
+	Timezone    *string `json:"timezone,omitempty"`
+}
+
+// UserResponse ответ с пользователем
+type UserResponse struct {
+	User UserDTO `json:"user"`
+}
+
+// UserWithOpenReviewsDTO представляет пользователя с числом открытых ревью
+type UserWithOpenReviewsDTO struct {
+	UserDTO
+	OpenReviews int `json:"open_reviews"`
+}
+
+// GetUserResponse ответ на получение пользователя
+type GetUserResponse struct {
+	User UserWithOpenReviewsDTO `json:"user"`
+}
+
+// ListUsersResponse страница списка пользователей
+type ListUsersResponse struct {
+	Users  []UserWithOpenReviewsDTO `json:"users"`
+	Total  int                      `json:"total"`
+	Limit  int                      `json:"limit"`
+	Offset int                      `json:"offset"`
 }
 
 // SetIsActiveRequest запрос на изменение активности пользователя
 type SetIsActiveRequest struct {
-	UserID   string `json:"user_id"`
-	IsActive bool   `json:"is_active"`
+	UserID      string `json:"user_id"`
+	IsActive    bool   `json:"is_active"`
+	HandOff     bool   `json:"hand_off_reviews,omitempty"`
+	SuccessorID string `json:"successor_id,omitempty"`
 }
 
 // SetIsActiveResponse ответ на изменение активности
 type SetIsActiveResponse struct {
+	User    UserDTO                   `json:"user"`
+	HandOff *ActivationChangeResponse `json:"hand_off,omitempty"`
+}
+
+// RegisterBotRequest запрос на регистрацию бота
+type RegisterBotRequest struct {
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	OwningTeam string `json:"owning_team"`
+}
+
+// RegisterBotResponse ответ на регистрацию бота
+type RegisterBotResponse struct {
 	User UserDTO `json:"user"`
 }
 
-// PullRequestDTO представляет Pull Request
+// PullRequestDTO представляет Pull Request.
+// createdAt и mergedAt остаются в camelCase по контракту openapi.yml, новые поля - в snake_case.
 type PullRequestDTO struct {
 	PullRequestID     string   `json:"pull_request_id"`
 	PullRequestName   string   `json:"pull_request_name"`
 	AuthorID          string   `json:"author_id"`
 	Status            string   `json:"status"`
 	AssignedReviewers []string `json:"assigned_reviewers"`
+	LinesAdded        int      `json:"lines_added"`
+	LinesDeleted      int      `json:"lines_deleted"`
+	FilesChanged      int      `json:"files_changed"`
+	SizeClass         string   `json:"size_class,omitempty"`
+	ReviewDueAt       *string  `json:"review_due_at,omitempty"`
 	CreatedAt         *string  `json:"createdAt,omitempty"`
 	MergedAt          *string  `json:"mergedAt,omitempty"`
 }
@@ -77,6 +151,7 @@ type PullRequestShortDTO struct {
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
 	Status          string `json:"status"`
+	SizeClass       string `json:"size_class,omitempty"`
 }
 
 // CreatePRRequest запрос на создание PR
@@ -84,6 +159,9 @@ type CreatePRRequest struct {
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
+	LinesAdded      int    `json:"lines_added"`
+	LinesDeleted    int    `json:"lines_deleted"`
+	FilesChanged    int    `json:"files_changed"`
 }
 
 // CreatePRResponse ответ на создание PR
@@ -111,6 +189,7 @@ type ReassignRequest struct {
 type ReassignResponse struct {
 	PR         PullRequestDTO `json:"pr"`
 	ReplacedBy string         `json:"replaced_by"`
+	Escalated  bool           `json:"escalated"`
 }
 
 // GetUserReviewsResponse ответ на получение PR пользователя
@@ -126,43 +205,95 @@ func ToTeamDTO(team *entity.TeamWithMembers) TeamDTO {
 	members := make([]TeamMemberDTO, 0, len(team.Members))
 	for _, m := range team.Members {
 		members = append(members, TeamMemberDTO{
-			UserID:   m.UserID,
-			Username: m.Username,
-			IsActive: m.IsActive,
+			UserID:       m.UserID,
+			Username:     m.Username,
+			IsActive:     m.IsActive,
+			Kind:         string(m.Kind),
+			DisplayName:  m.DisplayName,
+			Email:        m.Email,
+			ChatHandle:   m.ChatHandle,
+			Timezone:     m.Timezone,
+			Role:         string(m.Role),
+			ReviewWeight: m.ReviewWeight,
+			Primary:      m.Primary,
 		})
 	}
 
 	return TeamDTO{
-		TeamName: team.TeamName,
-		Members:  members,
+		TeamName:       team.TeamName,
+		ParentTeamName: team.ParentTeamName,
+		Ancestors:      team.Ancestors,
+		Children:       team.Children,
+		Archived:       team.Archived,
+		Members:        members,
 	}
 }
 
 // ToTeamEntity преобразует DTO в entity
 func ToTeamEntity(dto *CreateTeamRequest) *entity.TeamWithMembers {
-	members := make([]entity.TeamMember, 0, len(dto.Members))
-	for _, m := range dto.Members {
+	return &entity.TeamWithMembers{
+		TeamName:       dto.TeamName,
+		ParentTeamName: dto.ParentTeamName,
+		Members:        ToTeamMemberEntities(dto.Members),
+	}
+}
+
+// ToTeamMemberEntities преобразует список DTO участников в entities
+func ToTeamMemberEntities(dtos []TeamMemberDTO) []entity.TeamMember {
+	members := make([]entity.TeamMember, 0, len(dtos))
+	for _, m := range dtos {
+		// Пустой kind означает «не менять» для существующего пользователя и HUMAN для нового
+		kind := entity.UserKind(m.Kind)
+
 		members = append(members, entity.TeamMember{
 			UserID:   m.UserID,
 			Username: m.Username,
 			IsActive: m.IsActive,
+			Kind:     kind,
+			UserProfile: entity.UserProfile{
+				DisplayName: m.DisplayName,
+				Email:       m.Email,
+				ChatHandle:  m.ChatHandle,
+				Timezone:    m.Timezone,
+			},
+			Role:         entity.TeamRole(m.Role),
+			ReviewWeight: m.ReviewWeight,
 		})
 	}
 
-	return &entity.TeamWithMembers{
-		TeamName: dto.TeamName,
-		Members:  members,
-	}
+	return members
 }
 
 // ToUserDTO преобразует entity в DTO
 func ToUserDTO(user *entity.User) UserDTO {
 	return UserDTO{
-		UserID:   user.UserID,
-		Username: user.Username,
-		TeamName: user.TeamName,
-		IsActive: user.IsActive,
+		UserID:      user.UserID,
+		Username:    user.Username,
+		TeamName:    user.TeamName,
+		IsActive:    user.IsActive,
+		Kind:        string(user.Kind),
+		DisplayName: user.DisplayName,
+		Email:       user.Email,
+		ChatHandle:  user.ChatHandle,
+		Timezone:    user.Timezone,
+	}
+}
+
+// ToUserWithOpenReviewsDTO преобразует entity в DTO
+func ToUserWithOpenReviewsDTO(user *entity.UserWithOpenReviews) UserWithOpenReviewsDTO {
+	return UserWithOpenReviewsDTO{
+		UserDTO:     ToUserDTO(&user.User),
+		OpenReviews: user.OpenReviews,
+	}
+}
+
+// ToUserWithOpenReviewsDTOs преобразует список entities в список DTOs
+func ToUserWithOpenReviewsDTOs(users []*entity.UserWithOpenReviews) []UserWithOpenReviewsDTO {
+	dtos := make([]UserWithOpenReviewsDTO, 0, len(users))
+	for _, user := range users {
+		dtos = append(dtos, ToUserWithOpenReviewsDTO(user))
 	}
+	return dtos
 }
 
 // ToPullRequestDTO преобразует entity в DTO
@@ -173,9 +304,18 @@ func ToPullRequestDTO(pr *entity.PullRequest) PullRequestDTO {
 		AuthorID:          pr.AuthorID,
 		Status:            string(pr.Status),
 		AssignedReviewers: pr.AssignedReviewers,
+		LinesAdded:        pr.Size.LinesAdded,
+		LinesDeleted:      pr.Size.LinesDeleted,
+		FilesChanged:      pr.Size.FilesChanged,
+		SizeClass:         pr.SizeClass,
 	}
 
 	// Форматируем время в RFC3339
+	if pr.ReviewDueAt != nil {
+		reviewDueAt := pr.ReviewDueAt.Format(time.RFC3339)
+		dto.ReviewDueAt = &reviewDueAt
+	}
+
 	if !pr.CreatedAt.IsZero() {
 		createdAt := pr.CreatedAt.Format(time.RFC3339)
 		dto.CreatedAt = &createdAt
@@ -196,6 +336,7 @@ func ToPullRequestShortDTO(pr *entity.PullRequestShort) PullRequestShortDTO {
 		PullRequestName: pr.PullRequestName,
 		AuthorID:        pr.AuthorID,
 		Status:          string(pr.Status),
+		SizeClass:       pr.SizeClass,
 	}
 }
 
@@ -209,12 +350,475 @@ func ToPullRequestShortDTOs(prs []*entity.PullRequestShort) []PullRequestShortDT
 }
 
 type DeactivateTeamMembersRequest struct {
+	TeamName       string   `json:"team_name"`
+	ExcludeUserIDs []string `json:"exclude_user_ids,omitempty"`
+	Strict         bool     `json:"strict,omitempty"`
+	DryRun         bool     `json:"dry_run,omitempty"`
+}
+
+// ChangeUsersActivationRequest запрос на деактивацию или реактивацию списка пользователей
+type ChangeUsersActivationRequest struct {
+	UserIDs []string `json:"user_ids"`
+	Strict  bool     `json:"strict,omitempty"`
+	DryRun  bool     `json:"dry_run,omitempty"`
+}
+
+// MemberActivationDTO изменение активности одного пользователя
+type MemberActivationDTO struct {
+	UserID string `json:"user_id"`
+	Action string `json:"action"`
+}
+
+// ActivationChangeResponse ответ на деактивацию или реактивацию пользователей
+type ActivationChangeResponse struct {
+	DeactivatedCount int                   `json:"deactivated_count"`
+	ReactivatedCount int                   `json:"reactivated_count"`
+	ReassignedPRs    int                   `json:"reassigned_prs"`
+	EscalatedPRs     int                   `json:"escalated_prs"`
+	UnassignedPRs    int                   `json:"unassigned_prs"`
+	FailedPRs        int                   `json:"failed_prs"`
+	DryRun           bool                  `json:"dry_run"`
+	UserIDs          []string              `json:"user_ids"`
+	UnreviewedPRs    []string              `json:"unreviewed_prs"`
+	Users            []MemberActivationDTO `json:"users"`
+	HandOffs         []ReviewHandOffDTO    `json:"hand_offs"`
+}
+
+// SizeBucketDTO представляет класс размера PR
+type SizeBucketDTO struct {
+	SizeClass     string `json:"size_class"`
+	MaxLines      *int   `json:"max_lines,omitempty"`
+	MaxFiles      *int   `json:"max_files,omitempty"`
+	ReviewerCount int    `json:"reviewer_count"`
+	SLAHours      int    `json:"sla_hours"`
+}
+
+// SizePolicyDTO представляет таблицу классов размера команды
+type SizePolicyDTO struct {
+	TeamName      string          `json:"team_name"`
+	InheritedFrom string          `json:"inherited_from,omitempty"`
+	IsDefault     bool            `json:"is_default"`
+	Buckets       []SizeBucketDTO `json:"buckets"`
+}
+
+// SetSizePolicyRequest запрос на замену таблицы классов размера
+type SetSizePolicyRequest struct {
+	TeamName string          `json:"team_name"`
+	Buckets  []SizeBucketDTO `json:"buckets"`
+}
+
+// SetSizePolicyResponse ответ на замену таблицы классов размера
+type SetSizePolicyResponse struct {
+	Policy SizePolicyDTO `json:"policy"`
+}
+
+// ToSizePolicyDTO преобразует entity в DTO
+func ToSizePolicyDTO(policy *entity.SizePolicy) SizePolicyDTO {
+	buckets := make([]SizeBucketDTO, 0, len(policy.Buckets))
+	for _, b := range policy.Buckets {
+		buckets = append(buckets, SizeBucketDTO{
+			SizeClass:     b.SizeClass,
+			MaxLines:      b.MaxLines,
+			MaxFiles:      b.MaxFiles,
+			ReviewerCount: b.ReviewerCount,
+			SLAHours:      b.SLAHours,
+		})
+	}
+
+	return SizePolicyDTO{
+		TeamName:      policy.TeamName,
+		InheritedFrom: policy.InheritedFrom,
+		IsDefault:     policy.IsDefault,
+		Buckets:       buckets,
+	}
+}
+
+// ToSizeBucketEntities преобразует DTO в entities
+func ToSizeBucketEntities(dtos []SizeBucketDTO) []*entity.SizeBucket {
+	buckets := make([]*entity.SizeBucket, 0, len(dtos))
+	for _, b := range dtos {
+		buckets = append(buckets, &entity.SizeBucket{
+			SizeClass:     b.SizeClass,
+			MaxLines:      b.MaxLines,
+			MaxFiles:      b.MaxFiles,
+			ReviewerCount: b.ReviewerCount,
+			SLAHours:      b.SLAHours,
+		})
+	}
+	return buckets
+}
+
+// RebalanceTeamRequest запрос на перераспределение открытых ревью в команде
+type RebalanceTeamRequest struct {
+	TeamName string `json:"team_name"`
+	DryRun   bool   `json:"dry_run"`
+}
+
+// RebalanceMoveDTO перенос назначения между ревьюверами
+type RebalanceMoveDTO struct {
+	PullRequestID string `json:"pull_request_id"`
+	FromUserID    string `json:"from_user_id"`
+	ToUserID      string `json:"to_user_id"`
+}
+
+// RebalanceTeamResponse ответ на перераспределение ревью
+type RebalanceTeamResponse struct {
+	TeamName   string             `json:"team_name"`
+	DryRun     bool               `json:"dry_run"`
+	Moves      []RebalanceMoveDTO `json:"moves"`
+	LoadBefore map[string]int     `json:"load_before"`
+	LoadAfter  map[string]int     `json:"load_after"`
+	Rules      []ReviewRuleDTO    `json:"rules"`
+}
+
+// EscalationContactsDTO представляет лидов и контакты эскалации команды
+type EscalationContactsDTO struct {
+	TeamName           string   `json:"team_name"`
+	Leads              []string `json:"leads"`
+	EscalationContacts []string `json:"escalation_contacts"`
+}
+
+// SetEscalationContactsResponse ответ на замену контактов эскалации
+type SetEscalationContactsResponse struct {
+	Escalation EscalationContactsDTO `json:"escalation"`
+}
+
+// ToEscalationContactsDTO преобразует entities в DTO
+func ToEscalationContactsDTO(teamName string, contacts []*entity.EscalationContact) EscalationContactsDTO {
+	result := EscalationContactsDTO{
+		TeamName:           teamName,
+		Leads:              []string{},
+		EscalationContacts: []string{},
+	}
+
+	for _, c := range contacts {
+		if c.Role == entity.EscalationRoleLead {
+			result.Leads = append(result.Leads, c.UserID)
+		} else {
+			result.EscalationContacts = append(result.EscalationContacts, c.UserID)
+		}
+	}
+
+	return result
+}
+
+// PRHistoryEntryDTO представляет запись истории PR
+type PRHistoryEntryDTO struct {
+	ID            int64  `json:"id"`
+	EventType     string `json:"event_type"`
+	OldReviewerID string `json:"old_reviewer_id,omitempty"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+	Escalated     bool   `json:"escalated"`
+	Reason        string `json:"reason,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// GetPRHistoryResponse ответ на получение истории PR
+type GetPRHistoryResponse struct {
+	PullRequestID string              `json:"pull_request_id"`
+	History       []PRHistoryEntryDTO `json:"history"`
+}
+
+// ToPRHistoryDTOs преобразует записи истории в DTOs
+func ToPRHistoryDTOs(entries []*entity.PRHistoryEntry) []PRHistoryEntryDTO {
+	dtos := make([]PRHistoryEntryDTO, 0, len(entries))
+	for _, e := range entries {
+		dtos = append(dtos, PRHistoryEntryDTO{
+			ID:            e.ID,
+			EventType:     string(e.EventType),
+			OldReviewerID: e.OldReviewerID,
+			NewReviewerID: e.NewReviewerID,
+			Escalated:     e.Escalated,
+			Reason:        e.Reason,
+			CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return dtos
+}
+
+// ReviewRuleDTO представляет правило выбора ревьюверов
+type ReviewRuleDTO struct {
+	RuleID      int64  `json:"rule_id"`
+	RuleType    string `json:"rule_type"`
+	UserID      string `json:"user_id"`
+	OtherUserID string `json:"other_user_id"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// SaveReviewRuleRequest запрос на создание или обновление правила
+type SaveReviewRuleRequest struct {
+	RuleID      int64  `json:"rule_id"`
+	RuleType    string `json:"rule_type"`
+	UserID      string `json:"user_id"`
+	OtherUserID string `json:"other_user_id"`
+	Reason      string `json:"reason"`
+}
+
+// DeleteReviewRuleRequest запрос на удаление правила
+type DeleteReviewRuleRequest struct {
+	RuleID int64 `json:"rule_id"`
+}
+
+// ReviewRuleResponse ответ с одним правилом
+type ReviewRuleResponse struct {
+	Rule ReviewRuleDTO `json:"rule"`
+}
+
+// ListReviewRulesResponse ответ со списком правил
+type ListReviewRulesResponse struct {
+	Rules []ReviewRuleDTO `json:"rules"`
+}
+
+// ToReviewRuleDTO преобразует entity в DTO
+func ToReviewRuleDTO(rule *entity.ReviewRule) ReviewRuleDTO {
+	return ReviewRuleDTO{
+		RuleID:      rule.ID,
+		RuleType:    string(rule.Type),
+		UserID:      rule.UserID,
+		OtherUserID: rule.OtherUserID,
+		Reason:      rule.Reason,
+	}
+}
+
+// ToReviewRuleDTOs преобразует список entities в список DTOs
+func ToReviewRuleDTOs(rules []*entity.ReviewRule) []ReviewRuleDTO {
+	dtos := make([]ReviewRuleDTO, 0, len(rules))
+	for _, rule := range rules {
+		dtos = append(dtos, ToReviewRuleDTO(rule))
+	}
+	return dtos
+}
+
+// ToReviewRuleEntity преобразует DTO в entity
+func ToReviewRuleEntity(dto *SaveReviewRuleRequest) *entity.ReviewRule {
+	return &entity.ReviewRule{
+		ID:          dto.RuleID,
+		Type:        entity.ReviewRuleType(dto.RuleType),
+		UserID:      dto.UserID,
+		OtherUserID: dto.OtherUserID,
+		Reason:      dto.Reason,
+	}
+}
+
+// AddTeamMembersRequest запрос на добавление участников в существующую команду
+type AddTeamMembersRequest struct {
+	TeamName string          `json:"team_name"`
+	Members  []TeamMemberDTO `json:"members"`
+}
+
+// AddTeamMembersResponse ответ на добавление участников
+type AddTeamMembersResponse struct {
+	Team TeamDTO `json:"team"`
+}
+
+// RemoveTeamMemberRequest запрос на исключение участника из команды
+type RemoveTeamMemberRequest struct {
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+}
+
+// MoveTeamMemberRequest запрос на перевод участника в другую команду
+type MoveTeamMemberRequest struct {
+	UserID         string `json:"user_id"`
+	TeamName       string `json:"team_name"`
+	TargetTeamName string `json:"target_team_name"`
+	ReviewPolicy   string `json:"review_policy"`
+}
+
+// TransferUserRequest запрос на перевод пользователя из текущей команды
+type TransferUserRequest struct {
+	UserID         string `json:"user_id"`
+	TargetTeamName string `json:"target_team_name"`
+	ReviewPolicy   string `json:"review_policy"`
+}
+
+// ReviewHandOffDTO изменение ревью одного PR при смене команды или деактивации ревьювера
+type ReviewHandOffDTO struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id,omitempty"`
+	Action        string `json:"action"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+	Escalated     bool   `json:"escalated"`
+	ErrorCode     string `json:"error_code,omitempty"`
+}
+
+// MembershipChangeResponse ответ на исключение или перевод участника
+type MembershipChangeResponse struct {
+	UserID        string             `json:"user_id"`
+	FromTeam      string             `json:"from_team"`
+	ToTeam        string             `json:"to_team,omitempty"`
+	ReviewPolicy  string             `json:"review_policy"`
+	ReassignedPRs int                `json:"reassigned_prs"`
+	EscalatedPRs  int                `json:"escalated_prs"`
+	UnassignedPRs int                `json:"unassigned_prs"`
+	KeptPRs       int                `json:"kept_prs"`
+	HandOffs      []ReviewHandOffDTO `json:"hand_offs"`
+}
+
+// RenameTeamRequest запрос на переименование команды
+type RenameTeamRequest struct {
+	TeamName    string `json:"team_name"`
+	NewTeamName string `json:"new_team_name"`
+}
+
+// ArchiveTeamRequest запрос на архивацию команды или возврат из архива
+type ArchiveTeamRequest struct {
+	TeamName string `json:"team_name"`
+}
+
+// TeamResponse ответ с командой
+type TeamResponse struct {
+	Team TeamDTO `json:"team"`
+}
+
+// DeleteTeamRequest запрос на удаление команды
+type DeleteTeamRequest struct {
 	TeamName string `json:"team_name"`
+	Force    bool   `json:"force"`
+}
+
+// DeleteTeamResponse ответ на удаление команды
+type DeleteTeamResponse struct {
+	TeamName      string `json:"team_name"`
+	DetachedUsers int    `json:"detached_users"`
+	OpenPRs       int    `json:"open_prs"`
+}
+
+// SetMembershipRequest запрос на добавление пользователя в команду или смену его роли в ней
+type SetMembershipRequest struct {
+	TeamName     string   `json:"team_name"`
+	UserID       string   `json:"user_id"`
+	Role         string   `json:"role,omitempty"`
+	ReviewWeight *float64 `json:"review_weight,omitempty"`
+}
+
+// SetTeamParentRequest запрос на перемещение команды в иерархии
+type SetTeamParentRequest struct {
+	TeamName       string `json:"team_name"`
+	ParentTeamName string `json:"parent_team_name"`
+}
+
+// ScheduleActivationRequest запрос на планирование изменения активности пользователя.
+// Время в RFC3339; until задаёт момент обратного изменения.
+type ScheduleActivationRequest struct {
+	UserID         string  `json:"user_id"`
+	IsActive       bool    `json:"is_active"`
+	From           string  `json:"from"`
+	Until          *string `json:"until,omitempty"`
+	HandOffReviews bool    `json:"hand_off_reviews"`
+	SuccessorID    string  `json:"successor_id,omitempty"`
+}
+
+// ScheduledActivationDTO представляет запланированное изменение активности
+type ScheduledActivationDTO struct {
+	ChangeID       int64   `json:"change_id"`
+	UserID         string  `json:"user_id"`
+	IsActive       bool    `json:"is_active"`
+	ApplyAt        string  `json:"apply_at"`
+	HandOffReviews bool    `json:"hand_off_reviews"`
+	SuccessorID    string  `json:"successor_id,omitempty"`
+	ReverseOfID    int64   `json:"reverse_of_id,omitempty"`
+	Status         string  `json:"status"`
+	CreatedAt      string  `json:"created_at"`
+	ProcessedAt    *string `json:"processed_at,omitempty"`
+}
+
+// ScheduledActivationsResponse ответ со списком запланированных изменений
+type ScheduledActivationsResponse struct {
+	Changes []ScheduledActivationDTO `json:"changes"`
+}
+
+// CancelScheduledActivationRequest запрос на отмену запланированного изменения
+type CancelScheduledActivationRequest struct {
+	ChangeID int64 `json:"change_id"`
+}
+
+// ActivationAuditEntryDTO представляет запись аудита изменения активности
+type ActivationAuditEntryDTO struct {
+	ID            int64  `json:"id"`
+	ChangeID      int64  `json:"change_id,omitempty"`
+	UserID        string `json:"user_id"`
+	IsActive      bool   `json:"is_active"`
+	Outcome       string `json:"outcome"`
+	ReassignedPRs int    `json:"reassigned_prs"`
+	UnassignedPRs int    `json:"unassigned_prs"`
+	FailedPRs     int    `json:"failed_prs"`
+	ErrorCode     string `json:"error_code,omitempty"`
+	ErrorMessage  string `json:"error_message,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// ActivationAuditResponse ответ с аудитом изменений активности пользователя
+type ActivationAuditResponse struct {
+	UserID  string                    `json:"user_id"`
+	Entries []ActivationAuditEntryDTO `json:"entries"`
+}
+
+// ToScheduledActivationDTO преобразует entity в DTO
+func ToScheduledActivationDTO(change *entity.ScheduledActivationChange) ScheduledActivationDTO {
+	dto := ScheduledActivationDTO{
+		ChangeID:       change.ID,
+		UserID:         change.UserID,
+		IsActive:       change.IsActive,
+		ApplyAt:        change.ApplyAt.Format(time.RFC3339),
+		HandOffReviews: change.HandOffReviews,
+		SuccessorID:    change.SuccessorID,
+		ReverseOfID:    change.ReverseOfID,
+		Status:         string(change.Status),
+		CreatedAt:      change.CreatedAt.Format(time.RFC3339),
+	}
+
+	if change.ProcessedAt != nil {
+		processedAt := change.ProcessedAt.Format(time.RFC3339)
+		dto.ProcessedAt = &processedAt
+	}
+
+	return dto
+}
+
+// ToScheduledActivationDTOs преобразует список entities в список DTOs
+func ToScheduledActivationDTOs(changes []*entity.ScheduledActivationChange) []ScheduledActivationDTO {
+	dtos := make([]ScheduledActivationDTO, 0, len(changes))
+	for _, change := range changes {
+		dtos = append(dtos, ToScheduledActivationDTO(change))
+	}
+	return dtos
+}
+
+// ToActivationAuditEntryDTOs преобразует записи аудита в DTOs
+func ToActivationAuditEntryDTOs(entries []*entity.ActivationAuditEntry) []ActivationAuditEntryDTO {
+	dtos := make([]ActivationAuditEntryDTO, 0, len(entries))
+	for _, e := range entries {
+		dtos = append(dtos, ActivationAuditEntryDTO{
+			ID:            e.ID,
+			ChangeID:      e.ChangeID,
+			UserID:        e.UserID,
+			IsActive:      e.IsActive,
+			Outcome:       string(e.Outcome),
+			ReassignedPRs: e.ReassignedPRs,
+			UnassignedPRs: e.UnassignedPRs,
+			FailedPRs:     e.FailedPRs,
+			ErrorCode:     e.ErrorCode,
+			ErrorMessage:  e.ErrorMessage,
+			CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return dtos
+}
+
+// OrgSyncActionDTO представляет одно изменение плана синхронизации оргструктуры
+type OrgSyncActionDTO struct {
+	Action         string   `json:"action"`
+	TeamName       string   `json:"team_name"`
+	ParentTeamName string   `json:"parent_team_name,omitempty"`
+	UserID         string   `json:"user_id,omitempty"`
+	FromTeam       string   `json:"from_team,omitempty"`
+	Fields         []string `json:"fields,omitempty"`
 }
 
-// DeactivateTeamMembersResponse ответ на массовую деактивацию
-type DeactivateTeamMembersResponse struct {
-	DeactivatedCount int      `json:"deactivated_count"`
-	ReassignedPRs    int      `json:"reassigned_prs"`
-	UserIDs          []string `json:"user_ids"`
+// OrgSyncResponse ответ на синхронизацию оргструктуры
+type OrgSyncResponse struct {
+	DryRun   bool               `json:"dry_run"`
+	Actions  []OrgSyncActionDTO `json:"actions"`
+	HandOffs []ReviewHandOffDTO `json:"hand_offs"`
 }
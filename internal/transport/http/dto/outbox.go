@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+)
+
+// OutboxMessageDTO представляет событие в outbox
+type OutboxMessageDTO struct {
+	MessageID      int64                  `json:"message_id"`
+	EventID        string                 `json:"event_id"`
+	EventType      string                 `json:"event_type"`
+	TeamName       string                 `json:"team_name,omitempty"`
+	PullRequestID  string                 `json:"pull_request_id,omitempty"`
+	OccurredAt     string                 `json:"occurred_at"`
+	Data           map[string]interface{} `json:"data"`
+	Status         string                 `json:"status"`
+	Attempts       int                    `json:"attempts"`
+	NextAttemptAt  string                 `json:"next_attempt_at"`
+	PublishedSinks []string               `json:"published_sinks"`
+	LastError      string                 `json:"last_error,omitempty"`
+	PublishedAt    *string                `json:"published_at,omitempty"`
+}
+
+// OutboxMessagesResponse ответ со списком событий outbox
+type OutboxMessagesResponse struct {
+	Messages []OutboxMessageDTO `json:"messages"`
+}
+
+// ToOutboxMessageDTO преобразует entity в DTO
+func ToOutboxMessageDTO(message *entity.OutboxMessage) OutboxMessageDTO {
+	dto := OutboxMessageDTO{
+		MessageID:      message.ID,
+		EventID:        message.Event.ID,
+		EventType:      string(message.Event.Type),
+		TeamName:       message.Event.TeamName,
+		PullRequestID:  message.Event.PullRequestID,
+		OccurredAt:     message.Event.OccurredAt.Format(time.RFC3339),
+		Data:           message.Event.Data,
+		Status:         string(message.Status),
+		Attempts:       message.Attempts,
+		NextAttemptAt:  message.NextAttemptAt.Format(time.RFC3339),
+		PublishedSinks: message.PublishedSinks,
+		LastError:      message.LastError,
+	}
+
+	if dto.PublishedSinks == nil {
+		dto.PublishedSinks = []string{}
+	}
+
+	if message.PublishedAt != nil {
+		publishedAt := message.PublishedAt.Format(time.RFC3339)
+		dto.PublishedAt = &publishedAt
+	}
+
+	return dto
+}
+
+// ToOutboxMessageDTOs преобразует список entities в список DTOs
+func ToOutboxMessageDTOs(messages []*entity.OutboxMessage) []OutboxMessageDTO {
+	dtos := make([]OutboxMessageDTO, 0, len(messages))
+	for _, message := range messages {
+		dtos = append(dtos, ToOutboxMessageDTO(message))
+	}
+	return dtos
+}
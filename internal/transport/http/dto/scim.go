@@ -0,0 +1,177 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+)
+
+// Схемы SCIM 2.0 (RFC 7643, RFC 7644)
+const (
+	SCIMUserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMGroupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMPatchOpSchema               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SCIMServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+// SCIMBasePath префикс эндпоинтов SCIM
+const SCIMBasePath = "/scim/v2"
+
+// SCIMMeta метаданные ресурса SCIM
+type SCIMMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location"`
+}
+
+// SCIMMultiValue многозначный атрибут SCIM (emails, ims, members)
+type SCIMMultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// SCIMUser ресурс User. id совпадает с user_id сервиса, ims хранит ник в чате.
+type SCIMUser struct {
+	Schemas     []string         `json:"schemas"`
+	ID          string           `json:"id,omitempty"`
+	ExternalID  string           `json:"externalId,omitempty"`
+	UserName    string           `json:"userName"`
+	DisplayName string           `json:"displayName,omitempty"`
+	Emails      []SCIMMultiValue `json:"emails,omitempty"`
+	IMs         []SCIMMultiValue `json:"ims,omitempty"`
+	Timezone    string           `json:"timezone,omitempty"`
+	Active      *bool            `json:"active,omitempty"`
+	Meta        *SCIMMeta        `json:"meta,omitempty"`
+}
+
+// SCIMGroup ресурс Group, соответствующий команде; id и displayName — имя команды
+type SCIMGroup struct {
+	Schemas     []string         `json:"schemas"`
+	ID          string           `json:"id,omitempty"`
+	DisplayName string           `json:"displayName"`
+	Members     []SCIMMultiValue `json:"members"`
+	Meta        *SCIMMeta        `json:"meta,omitempty"`
+}
+
+// SCIMListResponse страница результатов поиска
+type SCIMListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// SCIMPatchRequest запрос PATCH
+type SCIMPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+// SCIMPatchOperation одна операция PATCH; значение разбирается в зависимости от path
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// SCIMError ответ с ошибкой в формате SCIM
+type SCIMError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+// SCIMSupported признак поддержки возможности в ServiceProviderConfig
+type SCIMSupported struct {
+	Supported bool `json:"supported"`
+}
+
+// SCIMFilterSupport параметры фильтрации в ServiceProviderConfig
+type SCIMFilterSupport struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+// SCIMBulkSupport параметры пакетных операций в ServiceProviderConfig
+type SCIMBulkSupport struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+// SCIMAuthenticationScheme схема аутентификации в ServiceProviderConfig
+type SCIMAuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// SCIMServiceProviderConfig описание возможностей сервиса для клиента SCIM
+type SCIMServiceProviderConfig struct {
+	Schemas               []string                   `json:"schemas"`
+	Patch                 SCIMSupported              `json:"patch"`
+	Bulk                  SCIMBulkSupport            `json:"bulk"`
+	Filter                SCIMFilterSupport          `json:"filter"`
+	ChangePassword        SCIMSupported              `json:"changePassword"`
+	Sort                  SCIMSupported              `json:"sort"`
+	ETag                  SCIMSupported              `json:"etag"`
+	AuthenticationSchemes []SCIMAuthenticationScheme `json:"authenticationSchemes"`
+}
+
+// ToSCIMUser преобразует пользователя в ресурс SCIM
+func ToSCIMUser(user *entity.User) SCIMUser {
+	active := user.IsActive
+	scimUser := SCIMUser{
+		Schemas:     []string{SCIMUserSchema},
+		ID:          user.UserID,
+		UserName:    user.Username,
+		DisplayName: user.DisplayName,
+		Timezone:    user.Timezone,
+		Active:      &active,
+		Meta: &SCIMMeta{
+			ResourceType: "User",
+			Created:      user.CreatedAt.Format(time.RFC3339),
+			LastModified: user.UpdatedAt.Format(time.RFC3339),
+			Location:     SCIMBasePath + "/Users/" + user.UserID,
+		},
+	}
+
+	if user.Email != "" {
+		scimUser.Emails = []SCIMMultiValue{{Value: user.Email, Type: "work", Primary: true}}
+	}
+	if user.ChatHandle != "" {
+		scimUser.IMs = []SCIMMultiValue{{Value: user.ChatHandle, Primary: true}}
+	}
+
+	return scimUser
+}
+
+// ToSCIMGroup преобразует команду в ресурс SCIM
+func ToSCIMGroup(team *entity.TeamWithMembers) SCIMGroup {
+	members := make([]SCIMMultiValue, 0, len(team.Members))
+	for _, m := range team.Members {
+		members = append(members, SCIMMultiValue{
+			Value:   m.UserID,
+			Display: m.Username,
+		})
+	}
+
+	return SCIMGroup{
+		Schemas:     []string{SCIMGroupSchema},
+		ID:          team.TeamName,
+		DisplayName: team.TeamName,
+		Members:     members,
+		Meta: &SCIMMeta{
+			ResourceType: "Group",
+			Location:     SCIMBasePath + "/Groups/" + team.TeamName,
+		},
+	}
+}
@@ -0,0 +1,190 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+)
+
+// VCSIdentityDTO представляет привязку логина на хостинге кода к пользователю
+type VCSIdentityDTO struct {
+	Provider  string `json:"provider"`
+	Login     string `json:"login"`
+	UserID    string `json:"user_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// SetVCSIdentityRequest запрос на привязку логина
+type SetVCSIdentityRequest struct {
+	Provider string `json:"provider"`
+	Login    string `json:"login"`
+	UserID   string `json:"user_id"`
+}
+
+// DeleteVCSIdentityRequest запрос на удаление привязки логина
+type DeleteVCSIdentityRequest struct {
+	Provider string `json:"provider"`
+	Login    string `json:"login"`
+}
+
+// VCSIdentityResponse ответ с привязкой логина
+type VCSIdentityResponse struct {
+	Identity VCSIdentityDTO `json:"identity"`
+}
+
+// VCSIdentitiesResponse ответ со списком привязок логинов
+type VCSIdentitiesResponse struct {
+	Identities []VCSIdentityDTO `json:"identities"`
+}
+
+// WebhookResponse ответ на доставку вебхука хостинга кода
+type WebhookResponse struct {
+	DeliveryID    string          `json:"delivery_id"`
+	Outcome       string          `json:"outcome"`
+	PullRequestID string          `json:"pull_request_id,omitempty"`
+	PullRequest   *PullRequestDTO `json:"pr,omitempty"`
+}
+
+// VCSReviewerRequestDTO представляет отправку изменения ревьюверов на хостинг кода
+type VCSReviewerRequestDTO struct {
+	RequestID        int64    `json:"request_id"`
+	PullRequestID    string   `json:"pull_request_id"`
+	AddedReviewers   []string `json:"added_reviewers"`
+	RemovedReviewers []string `json:"removed_reviewers"`
+	Status           string   `json:"status"`
+	Attempts         int      `json:"attempts"`
+	NextAttemptAt    string   `json:"next_attempt_at"`
+	LastError        string   `json:"last_error,omitempty"`
+	CreatedAt        string   `json:"created_at"`
+	SentAt           *string  `json:"sent_at,omitempty"`
+}
+
+// VCSReviewerRequestsResponse ответ со списком отправок ревьюверов
+type VCSReviewerRequestsResponse struct {
+	Requests []VCSReviewerRequestDTO `json:"requests"`
+}
+
+// GitHubPullRequestEvent полезная нагрузка события pull_request GitHub (используемые поля)
+type GitHubPullRequestEvent struct {
+	Action      string            `json:"action"`
+	Number      int               `json:"number"`
+	PullRequest GitHubPullRequest `json:"pull_request"`
+	Repository  GitHubRepository  `json:"repository"`
+}
+
+// GitHubPullRequest PR в событии GitHub
+type GitHubPullRequest struct {
+	Number       int        `json:"number"`
+	Title        string     `json:"title"`
+	HTMLURL      string     `json:"html_url"`
+	Draft        bool       `json:"draft"`
+	Merged       bool       `json:"merged"`
+	User         GitHubUser `json:"user"`
+	Additions    int        `json:"additions"`
+	Deletions    int        `json:"deletions"`
+	ChangedFiles int        `json:"changed_files"`
+}
+
+// GitHubRepository репозиторий в событии GitHub
+type GitHubRepository struct {
+	FullName string `json:"full_name"`
+}
+
+// GitHubUser пользователь в событии GitHub
+type GitHubUser struct {
+	Login string `json:"login"`
+}
+
+// ToVCSIdentityDTO преобразует entity в DTO
+func ToVCSIdentityDTO(identity *entity.VCSIdentity) VCSIdentityDTO {
+	return VCSIdentityDTO{
+		Provider:  string(identity.Provider),
+		Login:     identity.Login,
+		UserID:    identity.UserID,
+		CreatedAt: identity.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// ToVCSIdentityDTOs преобразует список entities в список DTOs
+func ToVCSIdentityDTOs(identities []*entity.VCSIdentity) []VCSIdentityDTO {
+	dtos := make([]VCSIdentityDTO, 0, len(identities))
+	for _, identity := range identities {
+		dtos = append(dtos, ToVCSIdentityDTO(identity))
+	}
+	return dtos
+}
+
+// ToVCSReviewerRequestDTO преобразует entity в DTO
+func ToVCSReviewerRequestDTO(request *entity.VCSReviewerRequest) VCSReviewerRequestDTO {
+	dto := VCSReviewerRequestDTO{
+		RequestID:        request.ID,
+		PullRequestID:    request.PullRequestID,
+		AddedReviewers:   request.AddedReviewers,
+		RemovedReviewers: request.RemovedReviewers,
+		Status:           string(request.Status),
+		Attempts:         request.Attempts,
+		NextAttemptAt:    request.NextAttemptAt.Format(time.RFC3339),
+		LastError:        request.LastError,
+		CreatedAt:        request.CreatedAt.Format(time.RFC3339),
+	}
+
+	if request.SentAt != nil {
+		sentAt := request.SentAt.Format(time.RFC3339)
+		dto.SentAt = &sentAt
+	}
+
+	return dto
+}
+
+// ToVCSReviewerRequestDTOs преобразует список entities в список DTOs
+func ToVCSReviewerRequestDTOs(requests []*entity.VCSReviewerRequest) []VCSReviewerRequestDTO {
+	dtos := make([]VCSReviewerRequestDTO, 0, len(requests))
+	for _, request := range requests {
+		dtos = append(dtos, ToVCSReviewerRequestDTO(request))
+	}
+	return dtos
+}
+
+// GitLabMergeRequestEvent полезная нагрузка Merge Request Hook GitLab (используемые поля)
+type GitLabMergeRequestEvent struct {
+	ObjectKind       string                       `json:"object_kind"`
+	User             GitLabUser                   `json:"user"`
+	Project          GitLabProject                `json:"project"`
+	ObjectAttributes GitLabMergeRequestAttributes `json:"object_attributes"`
+	Changes          GitLabMergeRequestChanges    `json:"changes"`
+}
+
+// GitLabUser пользователь, вызвавший событие GitLab
+type GitLabUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+// GitLabProject проект в событии GitLab
+type GitLabProject struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+// GitLabMergeRequestAttributes merge request в событии GitLab.
+// WorkInProgress — прежнее название признака черновика.
+type GitLabMergeRequestAttributes struct {
+	IID            int    `json:"iid"`
+	Title          string `json:"title"`
+	URL            string `json:"url"`
+	Action         string `json:"action"`
+	AuthorID       int    `json:"author_id"`
+	Draft          bool   `json:"draft"`
+	WorkInProgress bool   `json:"work_in_progress"`
+}
+
+// GitLabMergeRequestChanges изменённые атрибуты merge request
+type GitLabMergeRequestChanges struct {
+	Draft          *GitLabBoolChange `json:"draft"`
+	WorkInProgress *GitLabBoolChange `json:"work_in_progress"`
+}
+
+// GitLabBoolChange прежнее и новое значение булева атрибута
+type GitLabBoolChange struct {
+	Previous bool `json:"previous"`
+	Current  bool `json:"current"`
+}
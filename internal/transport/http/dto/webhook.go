@@ -0,0 +1,133 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+)
+
+// WebhookSubscriptionDTO представляет подписку на исходящие вебхуки.
+// Secret возвращается только при создании подписки.
+type WebhookSubscriptionDTO struct {
+	SubscriptionID int64    `json:"subscription_id"`
+	URL            string   `json:"url"`
+	EventTypes     []string `json:"event_types"`
+	TeamName       string   `json:"team_name,omitempty"`
+	Secret         string   `json:"secret,omitempty"`
+	CreatedAt      string   `json:"created_at"`
+}
+
+// CreateWebhookSubscriptionRequest запрос на создание подписки
+type CreateWebhookSubscriptionRequest struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+	TeamName   string   `json:"team_name"`
+}
+
+// DeleteWebhookSubscriptionRequest запрос на удаление подписки
+type DeleteWebhookSubscriptionRequest struct {
+	SubscriptionID int64 `json:"subscription_id"`
+}
+
+// WebhookSubscriptionResponse ответ с подпиской
+type WebhookSubscriptionResponse struct {
+	Subscription WebhookSubscriptionDTO `json:"subscription"`
+}
+
+// WebhookSubscriptionsResponse ответ со списком подписок
+type WebhookSubscriptionsResponse struct {
+	Subscriptions []WebhookSubscriptionDTO `json:"subscriptions"`
+}
+
+// WebhookDeliveryDTO представляет доставку события подписчику
+type WebhookDeliveryDTO struct {
+	DeliveryID     int64           `json:"delivery_id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	PullRequestID  string          `json:"pull_request_id,omitempty"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  string          `json:"next_attempt_at"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      string          `json:"created_at"`
+	DeliveredAt    *string         `json:"delivered_at,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+// RedeliverWebhookRequest запрос на повторную доставку
+type RedeliverWebhookRequest struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+// WebhookDeliveryResponse ответ с доставкой
+type WebhookDeliveryResponse struct {
+	Delivery WebhookDeliveryDTO `json:"delivery"`
+}
+
+// WebhookDeliveriesResponse ответ с журналом доставок
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryDTO `json:"deliveries"`
+}
+
+// ToWebhookSubscriptionDTO преобразует entity в DTO без секрета
+func ToWebhookSubscriptionDTO(subscription *entity.WebhookSubscription) WebhookSubscriptionDTO {
+	eventTypes := make([]string, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	return WebhookSubscriptionDTO{
+		SubscriptionID: subscription.ID,
+		URL:            subscription.URL,
+		EventTypes:     eventTypes,
+		TeamName:       subscription.TeamName,
+		CreatedAt:      subscription.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// ToWebhookSubscriptionDTOs преобразует список entities в список DTOs
+func ToWebhookSubscriptionDTOs(subscriptions []*entity.WebhookSubscription) []WebhookSubscriptionDTO {
+	dtos := make([]WebhookSubscriptionDTO, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		dtos = append(dtos, ToWebhookSubscriptionDTO(subscription))
+	}
+	return dtos
+}
+
+// ToWebhookDeliveryDTO преобразует entity в DTO
+func ToWebhookDeliveryDTO(delivery *entity.WebhookDelivery) WebhookDeliveryDTO {
+	dto := WebhookDeliveryDTO{
+		DeliveryID:     delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		PullRequestID:  delivery.PullRequestID,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt.Format(time.RFC3339),
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+		Payload:        json.RawMessage(delivery.Payload),
+	}
+
+	if delivery.DeliveredAt != nil {
+		deliveredAt := delivery.DeliveredAt.Format(time.RFC3339)
+		dto.DeliveredAt = &deliveredAt
+	}
+
+	return dto
+}
+
+// ToWebhookDeliveryDTOs преобразует список entities в список DTOs
+func ToWebhookDeliveryDTOs(deliveries []*entity.WebhookDelivery) []WebhookDeliveryDTO {
+	dtos := make([]WebhookDeliveryDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		dtos = append(dtos, ToWebhookDeliveryDTO(delivery))
+	}
+	return dtos
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// ActivationScheduleHandler обрабатывает запросы для отложенных изменений активности
+type ActivationScheduleHandler struct {
+	scheduleUseCase *usecase.ActivationScheduleUseCase
+}
+
+// NewActivationScheduleHandler создает новый handler для отложенных изменений активности
+func NewActivationScheduleHandler(scheduleUseCase *usecase.ActivationScheduleUseCase) *ActivationScheduleHandler {
+	return &ActivationScheduleHandler{
+		scheduleUseCase: scheduleUseCase,
+	}
+}
+
+// ScheduleActivation обрабатывает POST /users/scheduleActivation
+func (h *ActivationScheduleHandler) ScheduleActivation(w http.ResponseWriter, r *http.Request) {
+	var req dto.ScheduleActivationRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.UserID == "" || req.From == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "user_id and from are required")
+		return
+	}
+
+	from, err := time.Parse(time.RFC3339, req.From)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "from must be an RFC3339 time")
+		return
+	}
+
+	var until *time.Time
+	if req.Until != nil {
+		parsed, err := time.Parse(time.RFC3339, *req.Until)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", "until must be an RFC3339 time")
+			return
+		}
+		until = &parsed
+	}
+
+	changes, err := h.scheduleUseCase.ScheduleActivation(
+		r.Context(),
+		req.UserID,
+		req.IsActive,
+		from,
+		until,
+		req.HandOffReviews,
+		req.SuccessorID,
+	)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.ScheduledActivationsResponse{
+		Changes: dto.ToScheduledActivationDTOs(changes),
+	}
+
+	respondJSON(w, http.StatusCreated, response)
+}
+
+// ListScheduledActivations обрабатывает GET /users/scheduledActivations
+func (h *ActivationScheduleHandler) ListScheduledActivations(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	status := entity.ActivationChangeStatus(r.URL.Query().Get("status"))
+
+	switch status {
+	case "", entity.ActivationChangePending, entity.ActivationChangeApplied,
+		entity.ActivationChangeFailed, entity.ActivationChangeCancelled:
+	default:
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "status must be PENDING, APPLIED, FAILED or CANCELLED")
+		return
+	}
+
+	changes, err := h.scheduleUseCase.ListScheduledActivations(r.Context(), userID, status)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.ScheduledActivationsResponse{
+		Changes: dto.ToScheduledActivationDTOs(changes),
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// CancelScheduledActivation обрабатывает POST /users/cancelScheduledActivation
+func (h *ActivationScheduleHandler) CancelScheduledActivation(w http.ResponseWriter, r *http.Request) {
+	var req dto.CancelScheduledActivationRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.ChangeID <= 0 {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "change_id is required")
+		return
+	}
+
+	changes, err := h.scheduleUseCase.CancelScheduledActivation(r.Context(), req.ChangeID)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.ScheduledActivationsResponse{
+		Changes: dto.ToScheduledActivationDTOs(changes),
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetActivationAudit обрабатывает GET /users/activationAudit
+func (h *ActivationScheduleHandler) GetActivationAudit(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "user_id query parameter is required")
+		return
+	}
+
+	entries, err := h.scheduleUseCase.GetActivationAudit(r.Context(), userID)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.ActivationAuditResponse{
+		UserID:  userID,
+		Entries: dto.ToActivationAuditEntryDTOs(entries),
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
 	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
 	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
 )
@@ -54,7 +55,8 @@ func getStatusCodeByErrorCode(code string) int {
 	switch code {
 	case "TEAM_EXISTS", "PR_EXISTS":
 		return http.StatusBadRequest
-	case "PR_MERGED", "NOT_ASSIGNED", "NO_CANDIDATE":
+	case "PR_MERGED", "PR_CLOSED", "NOT_ASSIGNED", "NO_CANDIDATE", "RULE_EXISTS", "TEAM_ARCHIVED", "TEAM_HAS_OPEN_PRS",
+		"ACTIVATION_CHANGE_NOT_PENDING", "USER_EXISTS":
 		return http.StatusConflict
 	case "NOT_FOUND":
 		return http.StatusNotFound
@@ -66,3 +68,41 @@ func getStatusCodeByErrorCode(code string) int {
 		return http.StatusInternalServerError
 	}
 }
+
+// isValidUserKind проверяет тип пользователя из запроса (пустой означает HUMAN)
+func isValidUserKind(kind string) bool {
+	switch entity.UserKind(kind) {
+	case "", entity.UserKindHuman, entity.UserKindBot:
+		return true
+	default:
+		return false
+	}
+}
+
+// validateMembership проверяет роль и вес участника команды; пустая строка — без ошибок
+func validateMembership(role string, reviewWeight *float64) string {
+	if !entity.TeamRole(role).IsValid() {
+		return "role must be MEMBER or LEAD"
+	}
+
+	if reviewWeight != nil && (*reviewWeight <= 0 || *reviewWeight > entity.MaxReviewWeight) {
+		return "review_weight must be greater than 0 and at most 10"
+	}
+
+	return ""
+}
+
+// validateProfile проверяет поля профиля пользователя; пустые поля не проверяются, пустая строка — без ошибок
+func validateProfile(displayName, email, chatHandle, timezone string) string {
+	profile := entity.UserProfile{
+		DisplayName: displayName,
+		Email:       email,
+		ChatHandle:  chatHandle,
+		Timezone:    timezone,
+	}
+	if err := profile.Validate(); err != nil {
+		return err.Error()
+	}
+
+	return ""
+}
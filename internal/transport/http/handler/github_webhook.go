@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// GitHubWebhookHandler принимает вебхуки GitHub и переводит события pull_request в жизненный цикл PR
+type GitHubWebhookHandler struct {
+	vcsUseCase *usecase.VCSUseCase
+	secret     string
+}
+
+// NewGitHubWebhookHandler создает новый handler вебхуков GitHub; secret — секрет вебхука в настройках репозитория
+func NewGitHubWebhookHandler(vcsUseCase *usecase.VCSUseCase, secret string) *GitHubWebhookHandler {
+	return &GitHubWebhookHandler{
+		vcsUseCase: vcsUseCase,
+		secret:     secret,
+	}
+}
+
+// HandleWebhook обрабатывает POST /webhooks/github.
+// Подпись X-Hub-Signature-256 проверяется по сырому телу; события, кроме pull_request, и прочие действия игнорируются.
+func (h *GitHubWebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "failed to read request body")
+		return
+	}
+
+	if !validGitHubSignature(h.secret, body, r.Header.Get("X-Hub-Signature-256")) {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "invalid webhook signature")
+		return
+	}
+
+	deliveryID := r.Header.Get("X-GitHub-Delivery")
+	if deliveryID == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "X-GitHub-Delivery header is required")
+		return
+	}
+
+	ignored := &usecase.VCSEventResult{DeliveryID: deliveryID, Outcome: usecase.VCSEventIgnored}
+	if r.Header.Get("X-GitHub-Event") != "pull_request" {
+		respondWebhookResult(w, ignored)
+		return
+	}
+
+	// Вебхук с типом содержимого application/x-www-form-urlencoded присылает JSON в поле payload
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid form payload")
+			return
+		}
+		body = []byte(form.Get("payload"))
+	}
+
+	var payload dto.GitHubPullRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid pull_request payload")
+		return
+	}
+
+	action, ok := gitHubPullRequestAction(&payload)
+	if !ok {
+		respondWebhookResult(w, ignored)
+		return
+	}
+
+	pr := payload.PullRequest
+	result, err := h.vcsUseCase.HandlePullRequestEvent(r.Context(), &entity.VCSPullRequestEvent{
+		Provider:    entity.VCSProviderGitHub,
+		DeliveryID:  deliveryID,
+		Action:      action,
+		Repository:  payload.Repository.FullName,
+		Number:      pr.Number,
+		Title:       pr.Title,
+		URL:         pr.HTMLURL,
+		AuthorLogin: pr.User.Login,
+		Draft:       pr.Draft,
+		Size: entity.PRSize{
+			LinesAdded:   pr.Additions,
+			LinesDeleted: pr.Deletions,
+			FilesChanged: pr.ChangedFiles,
+		},
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWebhookResult(w, result)
+}
+
+// gitHubPullRequestAction отображает действие pull_request GitHub; false — действие не влияет на PR
+func gitHubPullRequestAction(payload *dto.GitHubPullRequestEvent) (entity.VCSPullRequestAction, bool) {
+	switch payload.Action {
+	case "opened":
+		return entity.VCSPullRequestOpened, true
+	case "ready_for_review":
+		return entity.VCSPullRequestReadyForReview, true
+	case "reopened":
+		return entity.VCSPullRequestReopened, true
+	case "closed":
+		if payload.PullRequest.Merged {
+			return entity.VCSPullRequestMerged, true
+		}
+		return entity.VCSPullRequestClosed, true
+	default:
+		return "", false
+	}
+}
+
+// validGitHubSignature сравнивает подпись sha256=<hex> с HMAC-SHA256 тела за постоянное время
+func validGitHubSignature(secret string, body []byte, signature string) bool {
+	const prefix = "sha256="
+	if secret == "" || !strings.HasPrefix(signature, prefix) {
+		return false
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// GitLabWebhookHandler принимает вебхуки GitLab и переводит события merge request в жизненный цикл PR
+type GitLabWebhookHandler struct {
+	vcsUseCase *usecase.VCSUseCase
+	token      string
+}
+
+// NewGitLabWebhookHandler создает новый handler вебхуков GitLab; token — секретный токен в настройках вебхука
+func NewGitLabWebhookHandler(vcsUseCase *usecase.VCSUseCase, token string) *GitLabWebhookHandler {
+	return &GitLabWebhookHandler{
+		vcsUseCase: vcsUseCase,
+		token:      token,
+	}
+}
+
+// HandleWebhook обрабатывает POST /webhooks/gitlab.
+// Токен X-Gitlab-Token сравнивается с настроенным; события, кроме Merge Request Hook, и прочие действия игнорируются.
+func (h *GitLabWebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("X-Gitlab-Token")
+	if h.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "invalid webhook token")
+		return
+	}
+
+	// Idempotency-Key одинаков для повторных попыток одной доставки, в старых версиях GitLab его нет
+	deliveryID := r.Header.Get("Idempotency-Key")
+	if deliveryID == "" {
+		deliveryID = r.Header.Get("X-Gitlab-Event-UUID")
+	}
+	if deliveryID == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "Idempotency-Key or X-Gitlab-Event-UUID header is required")
+		return
+	}
+
+	ignored := &usecase.VCSEventResult{DeliveryID: deliveryID, Outcome: usecase.VCSEventIgnored}
+	if r.Header.Get("X-Gitlab-Event") != "Merge Request Hook" {
+		respondWebhookResult(w, ignored)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "failed to read request body")
+		return
+	}
+
+	var payload dto.GitLabMergeRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid merge request payload")
+		return
+	}
+
+	action, ok := gitLabMergeRequestAction(&payload)
+	if !ok {
+		respondWebhookResult(w, ignored)
+		return
+	}
+
+	mr := payload.ObjectAttributes
+	result, err := h.vcsUseCase.HandlePullRequestEvent(r.Context(), &entity.VCSPullRequestEvent{
+		Provider:    entity.VCSProviderGitLab,
+		DeliveryID:  deliveryID,
+		Action:      action,
+		Repository:  payload.Project.PathWithNamespace,
+		Number:      mr.IID,
+		Title:       mr.Title,
+		URL:         mr.URL,
+		AuthorLogin: gitLabAuthorLogin(&payload),
+		Draft:       mr.Draft || mr.WorkInProgress,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWebhookResult(w, result)
+}
+
+// gitLabMergeRequestAction отображает действие merge request GitLab; false — действие не влияет на PR.
+// Из обновлений учитывается только снятие признака черновика.
+func gitLabMergeRequestAction(payload *dto.GitLabMergeRequestEvent) (entity.VCSPullRequestAction, bool) {
+	switch payload.ObjectAttributes.Action {
+	case "open":
+		return entity.VCSPullRequestOpened, true
+	case "reopen":
+		return entity.VCSPullRequestReopened, true
+	case "merge":
+		return entity.VCSPullRequestMerged, true
+	case "close":
+		return entity.VCSPullRequestClosed, true
+	case "update":
+		for _, change := range []*dto.GitLabBoolChange{payload.Changes.Draft, payload.Changes.WorkInProgress} {
+			if change != nil && change.Previous && !change.Current {
+				return entity.VCSPullRequestReadyForReview, true
+			}
+		}
+		return "", false
+	default:
+		return "", false
+	}
+}
+
+// gitLabAuthorLogin возвращает логин автора merge request.
+// В событии есть только пользователь, вызвавший его; если это не автор, используется числовой id автора,
+// который можно привязать как логин.
+func gitLabAuthorLogin(payload *dto.GitLabMergeRequestEvent) string {
+	if payload.User.ID == payload.ObjectAttributes.AuthorID && payload.User.Username != "" {
+		return payload.User.Username
+	}
+	return strconv.Itoa(payload.ObjectAttributes.AuthorID)
+}
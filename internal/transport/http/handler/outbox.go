@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// OutboxHandler обрабатывает запросы просмотра outbox
+type OutboxHandler struct {
+	outboxUseCase *usecase.OutboxUseCase
+}
+
+// NewOutboxHandler создает новый handler для outbox
+func NewOutboxHandler(outboxUseCase *usecase.OutboxUseCase) *OutboxHandler {
+	return &OutboxHandler{
+		outboxUseCase: outboxUseCase,
+	}
+}
+
+// ListMessages обрабатывает GET /outbox/messages
+func (h *OutboxHandler) ListMessages(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	status := entity.OutboxStatus(r.URL.Query().Get("status"))
+
+	switch status {
+	case "", entity.OutboxPending, entity.OutboxPublished, entity.OutboxFailed:
+	default:
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "status must be PENDING, PUBLISHED or FAILED")
+		return
+	}
+
+	messages, err := h.outboxUseCase.ListMessages(r.Context(), prID, status)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.OutboxMessagesResponse{
+		Messages: dto.ToOutboxMessageDTOs(messages),
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
 	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
 	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
 )
@@ -35,8 +36,19 @@ func (h *PullRequestHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.LinesAdded < 0 || req.LinesDeleted < 0 || req.FilesChanged < 0 {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "lines_added, lines_deleted and files_changed must not be negative")
+		return
+	}
+
+	size := entity.PRSize{
+		LinesAdded:   req.LinesAdded,
+		LinesDeleted: req.LinesDeleted,
+		FilesChanged: req.FilesChanged,
+	}
+
 	// Создаем PR
-	pr, err := h.prUseCase.CreatePullRequest(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := h.prUseCase.CreatePullRequest(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID, size)
 	if err != nil {
 		handleUseCaseError(w, err)
 		return
@@ -92,15 +104,38 @@ func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	pr, newReviewerID, err := h.prUseCase.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID)
+	result, err := h.prUseCase.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID)
 	if err != nil {
 		handleUseCaseError(w, err)
 		return
 	}
 
 	response := dto.ReassignResponse{
-		PR:         dto.ToPullRequestDTO(pr),
-		ReplacedBy: newReviewerID,
+		PR:         dto.ToPullRequestDTO(result.PR),
+		ReplacedBy: result.NewReviewerID,
+		Escalated:  result.Escalated,
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetHistory обрабатывает GET /pullRequest/history
+func (h *PullRequestHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "pull_request_id query parameter is required")
+		return
+	}
+
+	entries, err := h.prUseCase.GetHistory(r.Context(), prID)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.GetPRHistoryResponse{
+		PullRequestID: prID,
+		History:       dto.ToPRHistoryDTOs(entries),
 	}
 
 	respondJSON(w, http.StatusOK, response)
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// ReviewRuleHandler обрабатывает запросы для правил выбора ревьюверов
+type ReviewRuleHandler struct {
+	ruleUseCase *usecase.ReviewRuleUseCase
+}
+
+// NewReviewRuleHandler создает новый handler для правил выбора ревьюверов
+func NewReviewRuleHandler(ruleUseCase *usecase.ReviewRuleUseCase) *ReviewRuleHandler {
+	return &ReviewRuleHandler{
+		ruleUseCase: ruleUseCase,
+	}
+}
+
+// CreateRule обрабатывает POST /reviewRules/create
+func (h *ReviewRuleHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	var req dto.SaveReviewRuleRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.RuleType == "" || req.UserID == "" || req.OtherUserID == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "rule_type, user_id and other_user_id are required")
+		return
+	}
+
+	req.RuleID = 0
+	rule, err := h.ruleUseCase.CreateRule(r.Context(), dto.ToReviewRuleEntity(&req))
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.ReviewRuleResponse{
+		Rule: dto.ToReviewRuleDTO(rule),
+	}
+
+	respondJSON(w, http.StatusCreated, response)
+}
+
+// UpdateRule обрабатывает POST /reviewRules/update
+func (h *ReviewRuleHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	var req dto.SaveReviewRuleRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.RuleID <= 0 || req.RuleType == "" || req.UserID == "" || req.OtherUserID == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "rule_id, rule_type, user_id and other_user_id are required")
+		return
+	}
+
+	rule, err := h.ruleUseCase.UpdateRule(r.Context(), dto.ToReviewRuleEntity(&req))
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.ReviewRuleResponse{
+		Rule: dto.ToReviewRuleDTO(rule),
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// DeleteRule обрабатывает POST /reviewRules/delete
+func (h *ReviewRuleHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	var req dto.DeleteReviewRuleRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.RuleID <= 0 {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "rule_id is required")
+		return
+	}
+
+	if err := h.ruleUseCase.DeleteRule(r.Context(), req.RuleID); err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetRule обрабатывает GET /reviewRules/get
+func (h *ReviewRuleHandler) GetRule(w http.ResponseWriter, r *http.Request) {
+	ruleID, err := strconv.ParseInt(r.URL.Query().Get("rule_id"), 10, 64)
+	if err != nil || ruleID <= 0 {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "rule_id query parameter is required")
+		return
+	}
+
+	rule, err := h.ruleUseCase.GetRule(r.Context(), ruleID)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.ReviewRuleResponse{
+		Rule: dto.ToReviewRuleDTO(rule),
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// ListRules обрабатывает GET /reviewRules/list
+func (h *ReviewRuleHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.ruleUseCase.ListRules(r.Context())
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.ListReviewRulesResponse{
+		Rules: dto.ToReviewRuleDTOs(rules),
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
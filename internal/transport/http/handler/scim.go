@@ -0,0 +1,575 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// Размер страницы поиска SCIM
+const (
+	defaultSCIMPageSize = 100
+	maxSCIMPageSize     = 200
+)
+
+// SCIMHandler обрабатывает запросы провижининга SCIM 2.0: Users — пользователи, Groups — команды
+type SCIMHandler struct {
+	userUseCase *usecase.UserUseCase
+	teamUseCase *usecase.TeamUseCase
+}
+
+// NewSCIMHandler создает новый handler для SCIM
+func NewSCIMHandler(userUseCase *usecase.UserUseCase, teamUseCase *usecase.TeamUseCase) *SCIMHandler {
+	return &SCIMHandler{
+		userUseCase: userUseCase,
+		teamUseCase: teamUseCase,
+	}
+}
+
+// ServiceProviderConfig обрабатывает GET /scim/v2/ServiceProviderConfig
+func (h *SCIMHandler) ServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	respondSCIM(w, http.StatusOK, dto.SCIMServiceProviderConfig{
+		Schemas: []string{dto.SCIMServiceProviderConfigSchema},
+		Patch:   dto.SCIMSupported{Supported: true},
+		Filter:  dto.SCIMFilterSupport{Supported: true, MaxResults: maxSCIMPageSize},
+		AuthenticationSchemes: []dto.SCIMAuthenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "Bearer Token",
+			Description: "Authorization: Bearer <SCIM_TOKEN>",
+		}},
+	})
+}
+
+// CreateUser обрабатывает POST /scim/v2/Users.
+// id пользователя берётся из externalId, а без него — из userName.
+func (h *SCIMHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var req dto.SCIMUser
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondSCIMError(w, http.StatusBadRequest, "invalidSyntax", "invalid request body")
+		return
+	}
+
+	if req.UserName == "" {
+		respondSCIMError(w, http.StatusBadRequest, "invalidValue", "userName is required")
+		return
+	}
+
+	userID := req.ExternalID
+	if userID == "" {
+		userID = req.UserName
+	}
+
+	isActive := true
+	if req.Active != nil {
+		isActive = *req.Active
+	}
+
+	profile := entity.UserProfile{
+		DisplayName: req.DisplayName,
+		Email:       primaryValue(req.Emails),
+		ChatHandle:  primaryValue(req.IMs),
+		Timezone:    req.Timezone,
+	}
+	if err := profile.Validate(); err != nil {
+		respondSCIMError(w, http.StatusBadRequest, "invalidValue", err.Error())
+		return
+	}
+
+	user, err := h.userUseCase.CreateUser(r.Context(), userID, req.UserName, isActive, profile)
+	if err != nil {
+		handleSCIMError(w, err)
+		return
+	}
+
+	resource := dto.ToSCIMUser(user)
+	w.Header().Set("Location", resource.Meta.Location)
+	respondSCIM(w, http.StatusCreated, resource)
+}
+
+// GetUser обрабатывает GET /scim/v2/Users/{id}
+func (h *SCIMHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	user, err := h.userUseCase.GetUser(r.Context(), scimResourceID(r))
+	if err != nil {
+		handleSCIMError(w, err)
+		return
+	}
+
+	respondSCIM(w, http.StatusOK, dto.ToSCIMUser(&user.User))
+}
+
+// ListUsers обрабатывает GET /scim/v2/Users.
+// Фильтр поддерживает userName eq/sw, emails.value eq, active eq и id eq, объединённые через and.
+func (h *SCIMHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	startIndex, count, msg := scimPagination(r)
+	if msg != "" {
+		respondSCIMError(w, http.StatusBadRequest, "invalidValue", msg)
+		return
+	}
+
+	clauses, err := parseSCIMFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		respondSCIMError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+		return
+	}
+
+	filter := entity.UserFilter{}
+	userID := ""
+	for _, clause := range clauses {
+		switch {
+		case clause.attr == "username" && clause.op == "eq":
+			filter.Username = clause.value
+		case clause.attr == "username" && clause.op == "sw":
+			filter.NamePrefix = clause.value
+		case (clause.attr == "emails" || clause.attr == "emails.value") && clause.op == "eq":
+			filter.Email = clause.value
+		case clause.attr == "active" && clause.op == "eq":
+			isActive, err := strconv.ParseBool(clause.value)
+			if err != nil {
+				respondSCIMError(w, http.StatusBadRequest, "invalidFilter", "active must be compared with true or false")
+				return
+			}
+			filter.IsActive = &isActive
+		case clause.attr == "id" && clause.op == "eq":
+			userID = clause.value
+		default:
+			respondSCIMError(w, http.StatusBadRequest, "invalidFilter", "unsupported filter: "+clause.attr+" "+clause.op)
+			return
+		}
+	}
+
+	if userID != "" {
+		h.listUserByID(w, r, userID, filter, startIndex)
+		return
+	}
+
+	// При count=0 клиенту нужно только общее число
+	filter.Offset = startIndex - 1
+	filter.Limit = count
+	if count == 0 {
+		filter.Limit = 1
+	}
+
+	page, err := h.userUseCase.ListUsers(r.Context(), filter)
+	if err != nil {
+		handleSCIMError(w, err)
+		return
+	}
+
+	resources := make([]dto.SCIMUser, 0, len(page.Users))
+	if count > 0 {
+		for _, user := range page.Users {
+			resources = append(resources, dto.ToSCIMUser(&user.User))
+		}
+	}
+
+	respondSCIMList(w, page.Total, startIndex, resources)
+}
+
+// listUserByID отвечает на поиск по id: не более одного ресурса, остальные условия проверяются на нём
+func (h *SCIMHandler) listUserByID(w http.ResponseWriter, r *http.Request, userID string, filter entity.UserFilter, startIndex int) {
+	resources := []dto.SCIMUser{}
+
+	user, err := h.userUseCase.GetUser(r.Context(), userID)
+	if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+		handleSCIMError(w, err)
+		return
+	}
+
+	if err == nil && matchesUserFilter(&user.User, filter) {
+		resources = append(resources, dto.ToSCIMUser(&user.User))
+	}
+
+	total := len(resources)
+	if startIndex > 1 {
+		resources = resources[:0]
+	}
+
+	respondSCIMList(w, total, startIndex, resources)
+}
+
+// matchesUserFilter проверяет пользователя на условиях фильтра без пагинации
+func matchesUserFilter(user *entity.User, filter entity.UserFilter) bool {
+	if filter.Username != "" && !strings.EqualFold(user.Username, filter.Username) {
+		return false
+	}
+	if filter.NamePrefix != "" && !hasPrefixFold(user.Username, filter.NamePrefix) && !hasPrefixFold(user.DisplayName, filter.NamePrefix) {
+		return false
+	}
+	if filter.Email != "" && !strings.EqualFold(user.Email, filter.Email) {
+		return false
+	}
+	if filter.IsActive != nil && user.IsActive != *filter.IsActive {
+		return false
+	}
+
+	return true
+}
+
+// hasPrefixFold проверяет префикс без учёта регистра
+func hasPrefixFold(s, prefix string) bool {
+	return strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix))
+}
+
+// PatchUser обрабатывает PATCH /scim/v2/Users/{id}.
+// Деактивация (active=false) передаёт открытые ревью пользователя, как POST /users/setIsActive с hand_off.
+// Операции применяются атомарно: при ошибке любой из них пользователь не меняется.
+func (h *SCIMHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	userID := scimResourceID(r)
+
+	var req dto.SCIMPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondSCIMError(w, http.StatusBadRequest, "invalidSyntax", "invalid request body")
+		return
+	}
+
+	patch := &scimUserPatch{}
+	for _, op := range req.Operations {
+		if err := patch.apply(op); err != nil {
+			handleSCIMError(w, err)
+			return
+		}
+	}
+
+	value := func(field *string) string {
+		if field == nil {
+			return ""
+		}
+		return *field
+	}
+	if msg := validateProfile(value(patch.update.DisplayName), value(patch.update.Email),
+		value(patch.update.ChatHandle), value(patch.update.Timezone)); msg != "" {
+		respondSCIMError(w, http.StatusBadRequest, "invalidValue", msg)
+		return
+	}
+
+	var update *usecase.UserUpdate
+	if patch.changed {
+		update = &patch.update
+	}
+
+	user, err := h.userUseCase.PatchUser(r.Context(), userID, update, patch.active)
+	if err != nil {
+		handleSCIMError(w, err)
+		return
+	}
+
+	respondSCIM(w, http.StatusOK, dto.ToSCIMUser(user))
+}
+
+// DeleteUser обрабатывает DELETE /scim/v2/Users/{id}.
+// История ревью ссылается на пользователя, поэтому он не удаляется, а деактивируется с передачей ревью.
+func (h *SCIMHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID := scimResourceID(r)
+
+	user, err := h.userUseCase.GetUser(r.Context(), userID)
+	if err != nil {
+		handleSCIMError(w, err)
+		return
+	}
+
+	if user.IsActive {
+		if _, _, err := h.userUseCase.SetIsActive(r.Context(), userID, false, true, ""); err != nil {
+			handleSCIMError(w, err)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// scimPatchError ошибка разбора операции PATCH
+type scimPatchError struct {
+	scimType string
+	detail   string
+}
+
+// Error реализует error; handleSCIMError отвечает на scimPatchError статусом 400 с его scimType
+func (e *scimPatchError) Error() string {
+	return e.detail
+}
+
+// scimUserPatch накопленные изменения пользователя из операций PATCH
+type scimUserPatch struct {
+	update  usecase.UserUpdate
+	active  *bool
+	changed bool
+}
+
+// scimValuePathPattern путь вида emails[type eq "work"].value
+var scimValuePathPattern = regexp.MustCompile(`^(\w+)\[[^\]]*\](?:\.(\w+))?$`)
+
+// apply разбирает одну операцию; операция без path задаёт объект атрибутов
+func (p *scimUserPatch) apply(op dto.SCIMPatchOperation) error {
+	kind := strings.ToLower(op.Op)
+	if kind != "add" && kind != "replace" && kind != "remove" {
+		return &scimPatchError{"invalidSyntax", "unsupported patch op: " + op.Op}
+	}
+
+	if op.Path == "" {
+		if kind == "remove" {
+			return &scimPatchError{"noTarget", "remove requires a path"}
+		}
+
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return &scimPatchError{"invalidValue", "patch value must be an object when path is omitted"}
+		}
+
+		// Атрибуты вне поддерживаемого подмножества игнорируются, как при создании
+		for attr, value := range attrs {
+			var patchErr *scimPatchError
+			if err := p.set(attr, value, false); err != nil && !(errors.As(err, &patchErr) && patchErr.scimType == "invalidPath") {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return p.set(op.Path, op.Value, kind == "remove")
+}
+
+// set применяет значение атрибута; remove очищает атрибут
+func (p *scimUserPatch) set(path string, value json.RawMessage, remove bool) error {
+	attr := strings.ToLower(path)
+	if match := scimValuePathPattern.FindStringSubmatch(attr); match != nil {
+		attr = match[1]
+	}
+	attr = strings.TrimSuffix(attr, ".value")
+
+	var target **string
+	switch attr {
+	case "active":
+		if remove {
+			return &scimPatchError{"mutability", "active cannot be removed"}
+		}
+		active, err := parseSCIMBool(value)
+		if err != nil {
+			return &scimPatchError{"invalidValue", "active must be a boolean"}
+		}
+		p.active = &active
+		return nil
+	case "username":
+		target = &p.update.Username
+	case "displayname":
+		target = &p.update.DisplayName
+	case "timezone":
+		target = &p.update.Timezone
+	case "emails":
+		target = &p.update.Email
+	case "ims":
+		target = &p.update.ChatHandle
+	default:
+		return &scimPatchError{"invalidPath", "unsupported attribute: " + path}
+	}
+
+	text := ""
+	if !remove {
+		var err error
+		text, err = parseSCIMString(value)
+		if err != nil {
+			return &scimPatchError{"invalidValue", path + " must be a string or a multi-valued attribute"}
+		}
+	}
+
+	if attr == "username" && text == "" {
+		return &scimPatchError{"invalidValue", "userName must not be empty"}
+	}
+
+	*target = &text
+	p.changed = true
+	return nil
+}
+
+// parseSCIMBool разбирает булево значение; некоторые IdP присылают его строкой "False"
+func parseSCIMBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(s)
+}
+
+// parseSCIMString разбирает строку, объект {value} или список таких объектов
+func parseSCIMString(value json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s, nil
+	}
+
+	var item dto.SCIMMultiValue
+	if err := json.Unmarshal(value, &item); err == nil {
+		return item.Value, nil
+	}
+
+	var items []dto.SCIMMultiValue
+	if err := json.Unmarshal(value, &items); err != nil {
+		return "", err
+	}
+	return primaryValue(items), nil
+}
+
+// primaryValue возвращает основное значение многозначного атрибута, иначе первое
+func primaryValue(items []dto.SCIMMultiValue) string {
+	for _, item := range items {
+		if item.Primary {
+			return item.Value
+		}
+	}
+	if len(items) > 0 {
+		return items[0].Value
+	}
+	return ""
+}
+
+// scimFilterClause условие фильтра SCIM вида attr op value
+type scimFilterClause struct {
+	attr  string
+	op    string
+	value string
+}
+
+// scimFilterPattern одно условие фильтра и необязательный and после него
+var scimFilterPattern = regexp.MustCompile(`(?i)^\s*([\w.]+)\s+(eq|sw)\s+("(?:[^"\\]|\\.)*"|true|false)\s*(and\s+|$)`)
+
+// parseSCIMFilter разбирает фильтр из условий eq/sw, объединённых через and; атрибуты приводятся к нижнему регистру
+func parseSCIMFilter(filter string) ([]scimFilterClause, error) {
+	var clauses []scimFilterClause
+
+	rest := strings.TrimSpace(filter)
+	for rest != "" {
+		match := scimFilterPattern.FindStringSubmatch(rest)
+		if match == nil {
+			return nil, fmt.Errorf("unsupported filter: %s", rest)
+		}
+
+		value := match[3]
+		if strings.HasPrefix(value, `"`) {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid filter value: %s", value)
+			}
+			value = unquoted
+		}
+
+		clauses = append(clauses, scimFilterClause{
+			attr:  strings.ToLower(match[1]),
+			op:    strings.ToLower(match[2]),
+			value: value,
+		})
+
+		rest = rest[len(match[0]):]
+		if match[4] != "" && rest == "" {
+			return nil, fmt.Errorf("filter must not end with and")
+		}
+	}
+
+	return clauses, nil
+}
+
+// scimPagination разбирает startIndex (с единицы) и count; пустая строка — без ошибок
+func scimPagination(r *http.Request) (int, int, string) {
+	query := r.URL.Query()
+	startIndex, count := 1, defaultSCIMPageSize
+
+	if raw := query.Get("startIndex"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return 0, 0, "startIndex must be an integer"
+		}
+		// По RFC 7644 значения меньше 1 трактуются как 1
+		if value > 1 {
+			startIndex = value
+		}
+	}
+
+	if raw := query.Get("count"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return 0, 0, "count must be an integer"
+		}
+		count = min(max(value, 0), maxSCIMPageSize)
+	}
+
+	return startIndex, count, ""
+}
+
+// scimResourceID возвращает id ресурса из пути
+func scimResourceID(r *http.Request) string {
+	id := chi.URLParam(r, "id")
+	if unescaped, err := url.PathUnescape(id); err == nil {
+		return unescaped
+	}
+	return id
+}
+
+// respondSCIM отправляет ресурс SCIM
+func respondSCIM(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}
+}
+
+// respondSCIMList отправляет страницу результатов поиска
+func respondSCIMList[T any](w http.ResponseWriter, total, startIndex int, resources []T) {
+	respondSCIM(w, http.StatusOK, dto.SCIMListResponse{
+		Schemas:      []string{dto.SCIMListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// respondSCIMError отправляет ошибку в формате SCIM
+func respondSCIMError(w http.ResponseWriter, status int, scimType, detail string) {
+	respondSCIM(w, status, dto.SCIMError{
+		Schemas:  []string{dto.SCIMErrorSchema},
+		Status:   strconv.Itoa(status),
+		SCIMType: scimType,
+		Detail:   detail,
+	})
+}
+
+// handleSCIMError отображает ошибки разбора PATCH и ошибки usecase слоя в ошибки SCIM
+func handleSCIMError(w http.ResponseWriter, err error) {
+	var patchErr *scimPatchError
+	if errors.As(err, &patchErr) {
+		respondSCIMError(w, http.StatusBadRequest, patchErr.scimType, patchErr.detail)
+		return
+	}
+
+	var domainErr *domainErrors.DomainError
+	if !errors.As(err, &domainErr) {
+		respondSCIMError(w, http.StatusInternalServerError, "", "internal server error")
+		return
+	}
+
+	switch domainErr.Code {
+	case "USER_EXISTS", "TEAM_EXISTS":
+		respondSCIMError(w, http.StatusConflict, "uniqueness", domainErr.Message)
+	case "INVALID_INPUT":
+		respondSCIMError(w, http.StatusBadRequest, "invalidValue", domainErr.Message)
+	default:
+		respondSCIMError(w, getStatusCodeByErrorCode(domainErr.Code), "", domainErr.Message)
+	}
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// scimMemberPathPattern путь вида members[value eq "u1"]
+var scimMemberPathPattern = regexp.MustCompile(`(?i)^members\[value\s+eq\s+"([^"]*)"\]$`)
+
+// CreateGroup обрабатывает POST /scim/v2/Groups: команда создаётся из существующих пользователей
+func (h *SCIMHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var req dto.SCIMGroup
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondSCIMError(w, http.StatusBadRequest, "invalidSyntax", "invalid request body")
+		return
+	}
+
+	if req.DisplayName == "" {
+		respondSCIMError(w, http.StatusBadRequest, "invalidValue", "displayName is required")
+		return
+	}
+
+	team, err := h.teamUseCase.CreateTeamWithUsers(r.Context(), req.DisplayName, memberValues(req.Members))
+	if err != nil {
+		handleSCIMError(w, err)
+		return
+	}
+
+	resource := dto.ToSCIMGroup(team)
+	w.Header().Set("Location", resource.Meta.Location)
+	respondSCIM(w, http.StatusCreated, resource)
+}
+
+// GetGroup обрабатывает GET /scim/v2/Groups/{id}
+func (h *SCIMHandler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	team, err := h.teamUseCase.GetTeamWithMembers(r.Context(), scimResourceID(r))
+	if err != nil {
+		handleSCIMError(w, err)
+		return
+	}
+
+	respondSCIM(w, http.StatusOK, dto.ToSCIMGroup(team))
+}
+
+// ListGroups обрабатывает GET /scim/v2/Groups. Фильтр поддерживает displayName eq/sw и id eq.
+func (h *SCIMHandler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	startIndex, count, msg := scimPagination(r)
+	if msg != "" {
+		respondSCIMError(w, http.StatusBadRequest, "invalidValue", msg)
+		return
+	}
+
+	clauses, err := parseSCIMFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		respondSCIMError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+		return
+	}
+
+	for _, clause := range clauses {
+		supported := clause.attr == "displayname" || (clause.attr == "id" && clause.op == "eq")
+		if !supported {
+			respondSCIMError(w, http.StatusBadRequest, "invalidFilter", "unsupported filter: "+clause.attr+" "+clause.op)
+			return
+		}
+	}
+
+	teams, err := h.teamUseCase.ListTeams(r.Context())
+	if err != nil {
+		handleSCIMError(w, err)
+		return
+	}
+
+	var matched []*entity.TeamWithMembers
+	for _, team := range teams {
+		if matchesGroupFilter(team, clauses) {
+			matched = append(matched, team)
+		}
+	}
+
+	resources := []dto.SCIMGroup{}
+	for i := startIndex - 1; i < len(matched) && len(resources) < count; i++ {
+		resources = append(resources, dto.ToSCIMGroup(matched[i]))
+	}
+
+	respondSCIMList(w, len(matched), startIndex, resources)
+}
+
+// matchesGroupFilter проверяет команду на условиях фильтра; id и displayName — имя команды
+func matchesGroupFilter(team *entity.TeamWithMembers, clauses []scimFilterClause) bool {
+	for _, clause := range clauses {
+		switch clause.op {
+		case "eq":
+			if team.TeamName != clause.value {
+				return false
+			}
+		case "sw":
+			if !strings.HasPrefix(team.TeamName, clause.value) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// PatchGroup обрабатывает PATCH /scim/v2/Groups/{id}.
+// Участники добавляются членствами; исключённые передают открытые ревью команды оставшимся, как POST /team/removeMember.
+// Операции применяются атомарно: при ошибке любой из них команда не меняется.
+func (h *SCIMHandler) PatchGroup(w http.ResponseWriter, r *http.Request) {
+	teamName := scimResourceID(r)
+
+	var req dto.SCIMPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondSCIMError(w, http.StatusBadRequest, "invalidSyntax", "invalid request body")
+		return
+	}
+
+	ops := make([]usecase.TeamPatchOperation, 0, len(req.Operations))
+	for _, op := range req.Operations {
+		teamOp, err := parseGroupOperation(op)
+		if err != nil {
+			handleSCIMError(w, err)
+			return
+		}
+		ops = append(ops, teamOp)
+	}
+
+	team, err := h.teamUseCase.PatchTeam(r.Context(), teamName, ops)
+	if err != nil {
+		handleSCIMError(w, err)
+		return
+	}
+
+	respondSCIM(w, http.StatusOK, dto.ToSCIMGroup(team))
+}
+
+// scimMembersPatch соответствие операций SCIM изменениям участников команды
+var scimMembersPatch = map[string]usecase.TeamMembersPatch{
+	"add":     usecase.TeamMembersAdd,
+	"remove":  usecase.TeamMembersRemove,
+	"replace": usecase.TeamMembersReplace,
+}
+
+// parseGroupOperation разбирает одну операцию PATCH над группой в операцию над командой
+func parseGroupOperation(op dto.SCIMPatchOperation) (usecase.TeamPatchOperation, error) {
+	kind := strings.ToLower(op.Op)
+	path := strings.ToLower(op.Path)
+
+	patch, ok := scimMembersPatch[kind]
+	switch {
+	case !ok:
+		return usecase.TeamPatchOperation{}, &scimPatchError{"invalidSyntax", "unsupported patch op: " + op.Op}
+
+	case path == "" && kind != "remove":
+		var attrs struct {
+			DisplayName string               `json:"displayName"`
+			Members     []dto.SCIMMultiValue `json:"members"`
+		}
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return usecase.TeamPatchOperation{}, &scimPatchError{"invalidValue", "patch value must be an object when path is omitted"}
+		}
+
+		teamOp := usecase.TeamPatchOperation{NewName: attrs.DisplayName}
+		if attrs.Members != nil {
+			teamOp.MembersPatch = patch
+			teamOp.UserIDs = memberValues(attrs.Members)
+		}
+		return teamOp, nil
+
+	case path == "displayname":
+		if kind == "remove" {
+			return usecase.TeamPatchOperation{}, &scimPatchError{"mutability", "displayName cannot be removed"}
+		}
+		newName, err := parseSCIMString(op.Value)
+		if err != nil || newName == "" {
+			return usecase.TeamPatchOperation{}, &scimPatchError{"invalidValue", "displayName must be a non-empty string"}
+		}
+		return usecase.TeamPatchOperation{NewName: newName}, nil
+
+	case path == "members":
+		var members []dto.SCIMMultiValue
+		if len(op.Value) > 0 {
+			if err := json.Unmarshal(op.Value, &members); err != nil {
+				return usecase.TeamPatchOperation{}, &scimPatchError{"invalidValue", "members must be a list of {value}"}
+			}
+		}
+		if kind == "remove" && members == nil {
+			// remove без значения исключает всех участников
+			return usecase.TeamPatchOperation{MembersPatch: usecase.TeamMembersReplace, UserIDs: []string{}}, nil
+		}
+		return usecase.TeamPatchOperation{MembersPatch: patch, UserIDs: memberValues(members)}, nil
+
+	case kind == "remove" && scimMemberPathPattern.MatchString(op.Path):
+		userID := scimMemberPathPattern.FindStringSubmatch(op.Path)[1]
+		return usecase.TeamPatchOperation{MembersPatch: patch, UserIDs: []string{userID}}, nil
+
+	default:
+		return usecase.TeamPatchOperation{}, &scimPatchError{"invalidPath", "unsupported path: " + op.Path}
+	}
+}
+
+// DeleteGroup обрабатывает DELETE /scim/v2/Groups/{id}. Участники остаются, но теряют членство в команде.
+func (h *SCIMHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.teamUseCase.DeleteTeam(r.Context(), scimResourceID(r), true); err != nil {
+		handleSCIMError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// memberValues возвращает id участников из многозначного атрибута members
+func memberValues(members []dto.SCIMMultiValue) []string {
+	userIDs := make([]string, 0, len(members))
+	for _, m := range members {
+		if m.Value != "" {
+			userIDs = append(userIDs, m.Value)
+		}
+	}
+	return userIDs
+}
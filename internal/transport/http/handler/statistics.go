@@ -28,3 +28,20 @@ func (h *StatisticsHandler) GetStatistics(w http.ResponseWriter, r *http.Request
 
 	respondJSON(w, http.StatusOK, stats)
 }
+
+// GetTeamStatistics обрабатывает GET /statistics/team
+func (h *StatisticsHandler) GetTeamStatistics(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name query parameter is required")
+		return
+	}
+
+	stats, err := h.statsUseCase.GetTeamStatistics(r.Context(), teamName)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, stats)
+}
@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	"github.com/StepanK17/pr-reviewer-service/internal/orgsync"
 	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
 	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
 )
@@ -39,6 +44,21 @@ func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	for _, member := range req.Members {
+		if !isValidUserKind(member.Kind) {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", "kind must be HUMAN or BOT")
+			return
+		}
+		if msg := validateMembership(member.Role, member.ReviewWeight); msg != "" {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", msg)
+			return
+		}
+		if msg := validateProfile(member.DisplayName, member.Email, member.ChatHandle, member.Timezone); msg != "" {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", msg)
+			return
+		}
+	}
+
 	teamEntity := dto.ToTeamEntity(&req)
 	team, err := h.teamUseCase.CreateTeam(r.Context(), teamEntity)
 	if err != nil {
@@ -75,6 +95,19 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 
 // DeactivateTeamMembers обрабатывает POST /team/deactivateMembers
 func (h *TeamHandler) DeactivateTeamMembers(w http.ResponseWriter, r *http.Request) {
+	h.changeTeamActivation(w, r, h.teamUseCase.DeactivateTeamMembers)
+}
+
+// ReactivateTeamMembers обрабатывает POST /team/reactivateMembers
+func (h *TeamHandler) ReactivateTeamMembers(w http.ResponseWriter, r *http.Request) {
+	h.changeTeamActivation(w, r, h.teamUseCase.ReactivateTeamMembers)
+}
+
+func (h *TeamHandler) changeTeamActivation(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(ctx context.Context, teamName string, excludeUserIDs []string, strict, dryRun bool) (*usecase.ActivationChangeResult, error),
+) {
 	var req dto.DeactivateTeamMembersRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -87,17 +120,578 @@ func (h *TeamHandler) DeactivateTeamMembers(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	result, err := h.teamUseCase.DeactivateTeamMembers(r.Context(), req.TeamName)
+	result, err := change(r.Context(), req.TeamName, req.ExcludeUserIDs, req.Strict, req.DryRun)
 	if err != nil {
 		handleUseCaseError(w, err)
 		return
 	}
 
-	response := dto.DeactivateTeamMembersResponse{
+	respondJSON(w, http.StatusOK, activationChangeResponse(result))
+}
+
+// DeactivateUsers обрабатывает POST /users/deactivate
+func (h *TeamHandler) DeactivateUsers(w http.ResponseWriter, r *http.Request) {
+	h.changeUsersActivation(w, r, h.teamUseCase.DeactivateUsers)
+}
+
+// ReactivateUsers обрабатывает POST /users/reactivate
+func (h *TeamHandler) ReactivateUsers(w http.ResponseWriter, r *http.Request) {
+	h.changeUsersActivation(w, r, h.teamUseCase.ReactivateUsers)
+}
+
+func (h *TeamHandler) changeUsersActivation(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(ctx context.Context, userIDs []string, strict, dryRun bool) (*usecase.ActivationChangeResult, error),
+) {
+	var req dto.ChangeUsersActivationRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if len(req.UserIDs) == 0 {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "user_ids is required")
+		return
+	}
+
+	result, err := change(r.Context(), req.UserIDs, req.Strict, req.DryRun)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, activationChangeResponse(result))
+}
+
+// GetSizePolicy обрабатывает GET /team/sizePolicy
+func (h *TeamHandler) GetSizePolicy(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name query parameter is required")
+		return
+	}
+
+	policy, err := h.teamUseCase.GetSizePolicy(r.Context(), teamName)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToSizePolicyDTO(policy))
+}
+
+// SetSizePolicy обрабатывает POST /team/sizePolicy
+func (h *TeamHandler) SetSizePolicy(w http.ResponseWriter, r *http.Request) {
+	var req dto.SetSizePolicyRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name is required")
+		return
+	}
+
+	if len(req.Buckets) == 0 {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "buckets is required")
+		return
+	}
+
+	policy, err := h.teamUseCase.SetSizePolicy(r.Context(), req.TeamName, dto.ToSizeBucketEntities(req.Buckets))
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.SetSizePolicyResponse{
+		Policy: dto.ToSizePolicyDTO(policy),
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// RebalanceTeam обрабатывает POST /team/rebalance
+func (h *TeamHandler) RebalanceTeam(w http.ResponseWriter, r *http.Request) {
+	var req dto.RebalanceTeamRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name is required")
+		return
+	}
+
+	result, err := h.teamUseCase.RebalanceTeam(r.Context(), req.TeamName, req.DryRun)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	moves := make([]dto.RebalanceMoveDTO, 0, len(result.Moves))
+	for _, move := range result.Moves {
+		moves = append(moves, dto.RebalanceMoveDTO{
+			PullRequestID: move.PullRequestID,
+			FromUserID:    move.FromUserID,
+			ToUserID:      move.ToUserID,
+		})
+	}
+
+	response := dto.RebalanceTeamResponse{
+		TeamName:   result.TeamName,
+		DryRun:     result.DryRun,
+		Moves:      moves,
+		LoadBefore: result.LoadBefore,
+		LoadAfter:  result.LoadAfter,
+		Rules:      dto.ToReviewRuleDTOs(result.Rules),
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetEscalationContacts обрабатывает GET /team/escalation
+func (h *TeamHandler) GetEscalationContacts(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name query parameter is required")
+		return
+	}
+
+	contacts, err := h.teamUseCase.GetEscalationContacts(r.Context(), teamName)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToEscalationContactsDTO(teamName, contacts))
+}
+
+// SetEscalationContacts обрабатывает POST /team/escalation
+func (h *TeamHandler) SetEscalationContacts(w http.ResponseWriter, r *http.Request) {
+	var req dto.EscalationContactsDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name is required")
+		return
+	}
+
+	contacts, err := h.teamUseCase.SetEscalationContacts(r.Context(), req.TeamName, req.Leads, req.EscalationContacts)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.SetEscalationContactsResponse{
+		Escalation: dto.ToEscalationContactsDTO(req.TeamName, contacts),
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// AddTeamMembers обрабатывает POST /team/addMembers
+func (h *TeamHandler) AddTeamMembers(w http.ResponseWriter, r *http.Request) {
+	var req dto.AddTeamMembersRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name is required")
+		return
+	}
+
+	if len(req.Members) == 0 {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "members is required")
+		return
+	}
+
+	for _, member := range req.Members {
+		if member.UserID == "" {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", "user_id is required")
+			return
+		}
+		if !isValidUserKind(member.Kind) {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", "kind must be HUMAN or BOT")
+			return
+		}
+		if msg := validateMembership(member.Role, member.ReviewWeight); msg != "" {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", msg)
+			return
+		}
+		if msg := validateProfile(member.DisplayName, member.Email, member.ChatHandle, member.Timezone); msg != "" {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", msg)
+			return
+		}
+	}
+
+	team, err := h.teamUseCase.AddTeamMembers(r.Context(), req.TeamName, dto.ToTeamMemberEntities(req.Members))
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.AddTeamMembersResponse{
+		Team: dto.ToTeamDTO(team),
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// RemoveTeamMember обрабатывает POST /team/removeMember
+func (h *TeamHandler) RemoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	var req dto.RemoveTeamMemberRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" || req.UserID == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name and user_id are required")
+		return
+	}
+
+	result, err := h.teamUseCase.RemoveTeamMember(r.Context(), req.TeamName, req.UserID)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, membershipChangeResponse(result))
+}
+
+// MoveTeamMember обрабатывает POST /team/moveMember
+func (h *TeamHandler) MoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	var req dto.MoveTeamMemberRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.UserID == "" || req.TeamName == "" || req.TargetTeamName == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "user_id, team_name and target_team_name are required")
+		return
+	}
+
+	policy, ok := parseReviewHandOffPolicy(req.ReviewPolicy)
+	if !ok {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "review_policy must be KEEP or REASSIGN")
+		return
+	}
+
+	result, err := h.teamUseCase.MoveTeamMember(r.Context(), req.UserID, req.TeamName, req.TargetTeamName, policy)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, membershipChangeResponse(result))
+}
+
+// TransferUser обрабатывает POST /users/transfer
+func (h *TeamHandler) TransferUser(w http.ResponseWriter, r *http.Request) {
+	var req dto.TransferUserRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.UserID == "" || req.TargetTeamName == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "user_id and target_team_name are required")
+		return
+	}
+
+	policy, ok := parseReviewHandOffPolicy(req.ReviewPolicy)
+	if !ok {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "review_policy must be KEEP or REASSIGN")
+		return
+	}
+
+	result, err := h.teamUseCase.TransferUser(r.Context(), req.UserID, req.TargetTeamName, policy)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, membershipChangeResponse(result))
+}
+
+// SetMembership обрабатывает POST /team/setMembership
+func (h *TeamHandler) SetMembership(w http.ResponseWriter, r *http.Request) {
+	var req dto.SetMembershipRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" || req.UserID == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name and user_id are required")
+		return
+	}
+
+	if msg := validateMembership(req.Role, req.ReviewWeight); msg != "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", msg)
+		return
+	}
+
+	team, err := h.teamUseCase.SetMembership(r.Context(), req.TeamName, req.UserID, entity.TeamRole(req.Role), req.ReviewWeight)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.TeamResponse{Team: dto.ToTeamDTO(team)})
+}
+
+// RenameTeam обрабатывает POST /team/rename
+func (h *TeamHandler) RenameTeam(w http.ResponseWriter, r *http.Request) {
+	var req dto.RenameTeamRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" || req.NewTeamName == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name and new_team_name are required")
+		return
+	}
+
+	team, err := h.teamUseCase.RenameTeam(r.Context(), req.TeamName, req.NewTeamName)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.TeamResponse{Team: dto.ToTeamDTO(team)})
+}
+
+// SetTeamParent обрабатывает POST /team/setParent
+func (h *TeamHandler) SetTeamParent(w http.ResponseWriter, r *http.Request) {
+	var req dto.SetTeamParentRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name is required")
+		return
+	}
+
+	team, err := h.teamUseCase.SetTeamParent(r.Context(), req.TeamName, req.ParentTeamName)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.TeamResponse{Team: dto.ToTeamDTO(team)})
+}
+
+// ArchiveTeam обрабатывает POST /team/archive
+func (h *TeamHandler) ArchiveTeam(w http.ResponseWriter, r *http.Request) {
+	h.setTeamArchived(w, r, true)
+}
+
+// UnarchiveTeam обрабатывает POST /team/unarchive
+func (h *TeamHandler) UnarchiveTeam(w http.ResponseWriter, r *http.Request) {
+	h.setTeamArchived(w, r, false)
+}
+
+// setTeamArchived меняет признак архивности команды
+func (h *TeamHandler) setTeamArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	var req dto.ArchiveTeamRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name is required")
+		return
+	}
+
+	team, err := h.teamUseCase.SetTeamArchived(r.Context(), req.TeamName, archived)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.TeamResponse{Team: dto.ToTeamDTO(team)})
+}
+
+// DeleteTeam обрабатывает POST /team/delete
+func (h *TeamHandler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	var req dto.DeleteTeamRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name is required")
+		return
+	}
+
+	result, err := h.teamUseCase.DeleteTeam(r.Context(), req.TeamName, req.Force)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.DeleteTeamResponse{
+		TeamName:      result.TeamName,
+		DetachedUsers: result.DetachedUsers,
+		OpenPRs:       result.OpenPRs,
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// parseReviewHandOffPolicy разбирает политику передачи ревью, по умолчанию REASSIGN
+func parseReviewHandOffPolicy(policy string) (usecase.ReviewHandOffPolicy, bool) {
+	switch usecase.ReviewHandOffPolicy(policy) {
+	case "", usecase.ReviewHandOffReassign:
+		return usecase.ReviewHandOffReassign, true
+	case usecase.ReviewHandOffKeep:
+		return usecase.ReviewHandOffKeep, true
+	default:
+		return "", false
+	}
+}
+
+// maxOrgSyncBodySize ограничение размера файла оргструктуры
+const maxOrgSyncBodySize = 10 << 20
+
+// SyncOrg обрабатывает POST /team/sync.
+// Тело — файл оргструктуры; формат задаётся параметром format (yaml или csv)
+// или заголовком Content-Type: text/csv, dry_run=true возвращает план без изменений.
+func (h *TeamHandler) SyncOrg(w http.ResponseWriter, r *http.Request) {
+	formatName := r.URL.Query().Get("format")
+	if formatName == "" && strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		formatName = string(orgsync.FormatCSV)
+	}
+
+	format, err := orgsync.ParseFormat(formatName)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", err.Error())
+		return
+	}
+
+	dryRun := false
+	if raw := r.URL.Query().Get("dry_run"); raw != "" {
+		dryRun, err = strconv.ParseBool(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", "dry_run must be true or false")
+			return
+		}
+	}
+
+	teams, err := orgsync.Parse(format, http.MaxBytesReader(w, r.Body, maxOrgSyncBodySize))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", err.Error())
+		return
+	}
+
+	result, err := h.teamUseCase.SyncOrg(r.Context(), teams, dryRun)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, orgSyncResponse(result))
+}
+
+// orgSyncResponse строит ответ с планом синхронизации
+func orgSyncResponse(result *usecase.OrgSyncResult) dto.OrgSyncResponse {
+	actions := make([]dto.OrgSyncActionDTO, 0, len(result.Actions))
+	for _, action := range result.Actions {
+		actions = append(actions, dto.OrgSyncActionDTO{
+			Action:         string(action.Type),
+			TeamName:       action.TeamName,
+			ParentTeamName: action.ParentTeamName,
+			UserID:         action.UserID,
+			FromTeam:       action.FromTeam,
+			Fields:         action.Fields,
+		})
+	}
+
+	return dto.OrgSyncResponse{
+		DryRun:   result.DryRun,
+		Actions:  actions,
+		HandOffs: reviewHandOffDTOs(result.HandOffs),
+	}
+}
+
+// membershipChangeResponse формирует ответ на изменение состава команды
+func membershipChangeResponse(result *usecase.MembershipChangeResult) dto.MembershipChangeResponse {
+	return dto.MembershipChangeResponse{
+		UserID:        result.UserID,
+		FromTeam:      result.FromTeam,
+		ToTeam:        result.ToTeam,
+		ReviewPolicy:  string(result.Policy),
+		ReassignedPRs: result.ReassignedPRs,
+		EscalatedPRs:  result.EscalatedPRs,
+		UnassignedPRs: result.UnassignedPRs,
+		KeptPRs:       result.KeptPRs,
+		HandOffs:      reviewHandOffDTOs(result.HandOffs),
+	}
+}
+
+func activationChangeResponse(result *usecase.ActivationChangeResult) dto.ActivationChangeResponse {
+	users := make([]dto.MemberActivationDTO, 0, len(result.Users))
+	for _, change := range result.Users {
+		users = append(users, dto.MemberActivationDTO{
+			UserID: change.UserID,
+			Action: string(change.Action),
+		})
+	}
+
+	return dto.ActivationChangeResponse{
 		DeactivatedCount: result.DeactivatedCount,
+		ReactivatedCount: result.ReactivatedCount,
 		ReassignedPRs:    result.ReassignedPRs,
+		EscalatedPRs:     result.EscalatedPRs,
+		UnassignedPRs:    result.UnassignedPRs,
+		FailedPRs:        result.FailedPRs,
+		DryRun:           result.DryRun,
 		UserIDs:          result.UserIDs,
+		UnreviewedPRs:    result.UnreviewedPRs,
+		Users:            users,
+		HandOffs:         reviewHandOffDTOs(result.HandOffs),
 	}
+}
 
-	respondJSON(w, http.StatusOK, response)
+func reviewHandOffDTOs(handOffs []usecase.ReviewHandOff) []dto.ReviewHandOffDTO {
+	dtos := make([]dto.ReviewHandOffDTO, 0, len(handOffs))
+	for _, handOff := range handOffs {
+		dtos = append(dtos, dto.ReviewHandOffDTO{
+			PullRequestID: handOff.PullRequestID,
+			OldReviewerID: handOff.OldReviewerID,
+			Action:        string(handOff.Action),
+			NewReviewerID: handOff.NewReviewerID,
+			Escalated:     handOff.Escalated,
+			ErrorCode:     handOff.ErrorCode,
+		})
+	}
+	return dtos
 }
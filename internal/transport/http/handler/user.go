@@ -3,7 +3,9 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
 	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
 	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
 )
@@ -34,7 +36,7 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.userUseCase.SetIsActive(r.Context(), req.UserID, req.IsActive)
+	user, report, err := h.userUseCase.SetIsActive(r.Context(), req.UserID, req.IsActive, req.HandOff, req.SuccessorID)
 	if err != nil {
 		handleUseCaseError(w, err)
 		return
@@ -43,6 +45,131 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	response := dto.SetIsActiveResponse{
 		User: dto.ToUserDTO(user),
 	}
+	if report != nil {
+		handOff := activationChangeResponse(report)
+		response.HandOff = &handOff
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetUser обрабатывает GET /users/get
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "user_id query parameter is required")
+		return
+	}
+
+	user, err := h.userUseCase.GetUser(r.Context(), userID)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.GetUserResponse{
+		User: dto.ToUserWithOpenReviewsDTO(user),
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// ListUsers обрабатывает GET /users/list
+func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := entity.UserFilter{
+		TeamName:   query.Get("team_name"),
+		NamePrefix: query.Get("name_prefix"),
+	}
+
+	if raw := query.Get("is_active"); raw != "" {
+		isActive, err := strconv.ParseBool(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", "is_active must be true or false")
+			return
+		}
+		filter.IsActive = &isActive
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", "limit must be a positive integer")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	if raw := query.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", "offset must be a non-negative integer")
+			return
+		}
+		filter.Offset = offset
+	}
+
+	page, err := h.userUseCase.ListUsers(r.Context(), filter)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.ListUsersResponse{
+		Users:  dto.ToUserWithOpenReviewsDTOs(page.Users),
+		Total:  page.Total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// UpdateUser обрабатывает POST /users/update
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	var req dto.UpdateUserRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.UserID == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "user_id is required")
+		return
+	}
+
+	if req.Username != nil && *req.Username == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "username must not be empty")
+		return
+	}
+
+	value := func(field *string) string {
+		if field == nil {
+			return ""
+		}
+		return *field
+	}
+	if msg := validateProfile(value(req.DisplayName), value(req.Email), value(req.ChatHandle), value(req.Timezone)); msg != "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", msg)
+		return
+	}
+
+	user, err := h.userUseCase.UpdateUser(r.Context(), req.UserID, usecase.UserUpdate{
+		Username:    req.Username,
+		DisplayName: req.DisplayName,
+		Email:       req.Email,
+		ChatHandle:  req.ChatHandle,
+		Timezone:    req.Timezone,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.UserResponse{
+		User: dto.ToUserDTO(user),
+	}
 
 	respondJSON(w, http.StatusOK, response)
 }
@@ -68,3 +195,30 @@ func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusOK, response)
 }
+
+// RegisterBot обрабатывает POST /users/registerBot
+func (h *UserHandler) RegisterBot(w http.ResponseWriter, r *http.Request) {
+	var req dto.RegisterBotRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.UserID == "" || req.Username == "" || req.OwningTeam == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "user_id, username and owning_team are required")
+		return
+	}
+
+	user, err := h.userUseCase.RegisterBot(r.Context(), req.UserID, req.Username, req.OwningTeam)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	response := dto.RegisterBotResponse{
+		User: dto.ToUserDTO(user),
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// maxWebhookBodySize ограничение размера тела вебхука хостинга кода
+const maxWebhookBodySize = 5 << 20
+
+// VCSHandler обрабатывает запросы привязки логинов хостингов кода к пользователям
+// и просмотра отправок ревьюверов на хостинги
+type VCSHandler struct {
+	vcsUseCase  *usecase.VCSUseCase
+	syncUseCase *usecase.VCSReviewerSyncUseCase
+}
+
+// NewVCSHandler создает новый handler для интеграции с хостингами кода
+func NewVCSHandler(vcsUseCase *usecase.VCSUseCase, syncUseCase *usecase.VCSReviewerSyncUseCase) *VCSHandler {
+	return &VCSHandler{
+		vcsUseCase:  vcsUseCase,
+		syncUseCase: syncUseCase,
+	}
+}
+
+// SetIdentity обрабатывает POST /vcs/identities
+func (h *VCSHandler) SetIdentity(w http.ResponseWriter, r *http.Request) {
+	var req dto.SetVCSIdentityRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.Provider == "" || req.Login == "" || req.UserID == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "provider, login and user_id are required")
+		return
+	}
+
+	identity, err := h.vcsUseCase.SetIdentity(r.Context(), vcsProvider(req.Provider), req.Login, req.UserID)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.VCSIdentityResponse{
+		Identity: dto.ToVCSIdentityDTO(identity),
+	})
+}
+
+// ListIdentities обрабатывает GET /vcs/identities
+func (h *VCSHandler) ListIdentities(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	identities, err := h.vcsUseCase.ListIdentities(r.Context(), vcsProvider(query.Get("provider")), query.Get("user_id"))
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.VCSIdentitiesResponse{
+		Identities: dto.ToVCSIdentityDTOs(identities),
+	})
+}
+
+// DeleteIdentity обрабатывает POST /vcs/identities/delete
+func (h *VCSHandler) DeleteIdentity(w http.ResponseWriter, r *http.Request) {
+	var req dto.DeleteVCSIdentityRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.Provider == "" || req.Login == "" {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "provider and login are required")
+		return
+	}
+
+	if err := h.vcsUseCase.DeleteIdentity(r.Context(), vcsProvider(req.Provider), req.Login); err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListReviewerRequests обрабатывает GET /vcs/reviewerRequests
+func (h *VCSHandler) ListReviewerRequests(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	status := entity.VCSReviewerRequestStatus(r.URL.Query().Get("status"))
+
+	switch status {
+	case "", entity.VCSReviewerRequestPending, entity.VCSReviewerRequestSent,
+		entity.VCSReviewerRequestFailed, entity.VCSReviewerRequestSkipped:
+	default:
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "status must be PENDING, SENT, FAILED or SKIPPED")
+		return
+	}
+
+	requests, err := h.syncUseCase.ListReviewerRequests(r.Context(), prID, status)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.VCSReviewerRequestsResponse{
+		Requests: dto.ToVCSReviewerRequestDTOs(requests),
+	})
+}
+
+// vcsProvider приводит имя провайдера из запроса к entity.VCSProvider без учёта регистра
+func vcsProvider(name string) entity.VCSProvider {
+	return entity.VCSProvider(strings.ToUpper(name))
+}
+
+// respondWebhookResult отправляет результат обработки события хостинга кода
+func respondWebhookResult(w http.ResponseWriter, result *usecase.VCSEventResult) {
+	response := dto.WebhookResponse{
+		DeliveryID:    result.DeliveryID,
+		Outcome:       string(result.Outcome),
+		PullRequestID: result.PullRequestID,
+	}
+	if result.PR != nil {
+		pr := dto.ToPullRequestDTO(result.PR)
+		response.PullRequest = &pr
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// WebhookHandler обрабатывает запросы управления исходящими вебхуками
+type WebhookHandler struct {
+	webhookUseCase *usecase.WebhookUseCase
+}
+
+// NewWebhookHandler создает новый handler для исходящих вебхуков
+func NewWebhookHandler(webhookUseCase *usecase.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUseCase: webhookUseCase,
+	}
+}
+
+// CreateSubscription обрабатывает POST /webhooks/subscriptions
+func (h *WebhookHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateWebhookSubscriptionRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.URL == "" || len(req.EventTypes) == 0 {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "url and event_types are required")
+		return
+	}
+
+	eventTypes := make([]entity.EventType, 0, len(req.EventTypes))
+	for _, eventType := range req.EventTypes {
+		eventTypes = append(eventTypes, entity.EventType(eventType))
+	}
+
+	subscription, err := h.webhookUseCase.CreateSubscription(r.Context(), req.URL, req.Secret, eventTypes, req.TeamName)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	// Секрет показывается один раз, чтобы подписчик мог проверять подпись
+	response := dto.WebhookSubscriptionResponse{
+		Subscription: dto.ToWebhookSubscriptionDTO(subscription),
+	}
+	response.Subscription.Secret = subscription.Secret
+
+	respondJSON(w, http.StatusCreated, response)
+}
+
+// ListSubscriptions обрабатывает GET /webhooks/subscriptions
+func (h *WebhookHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.webhookUseCase.ListSubscriptions(r.Context())
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.WebhookSubscriptionsResponse{
+		Subscriptions: dto.ToWebhookSubscriptionDTOs(subscriptions),
+	})
+}
+
+// DeleteSubscription обрабатывает POST /webhooks/subscriptions/delete
+func (h *WebhookHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	var req dto.DeleteWebhookSubscriptionRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.SubscriptionID <= 0 {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "subscription_id is required")
+		return
+	}
+
+	if err := h.webhookUseCase.DeleteSubscription(r.Context(), req.SubscriptionID); err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries обрабатывает GET /webhooks/deliveries
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var subscriptionID int64
+	if raw := query.Get("subscription_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id <= 0 {
+			respondError(w, http.StatusBadRequest, "INVALID_INPUT", "subscription_id must be a positive integer")
+			return
+		}
+		subscriptionID = id
+	}
+
+	status := entity.WebhookDeliveryStatus(query.Get("status"))
+	switch status {
+	case "", entity.WebhookDeliveryPending, entity.WebhookDeliveryDelivered, entity.WebhookDeliveryFailed:
+	default:
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "status must be PENDING, DELIVERED or FAILED")
+		return
+	}
+
+	deliveries, err := h.webhookUseCase.ListDeliveries(r.Context(), subscriptionID, status)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.WebhookDeliveriesResponse{
+		Deliveries: dto.ToWebhookDeliveryDTOs(deliveries),
+	})
+}
+
+// Redeliver обрабатывает POST /webhooks/deliveries/redeliver
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	var req dto.RedeliverWebhookRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "invalid request body")
+		return
+	}
+
+	if req.DeliveryID <= 0 {
+		respondError(w, http.StatusBadRequest, "INVALID_INPUT", "delivery_id is required")
+		return
+	}
+
+	delivery, err := h.webhookUseCase.Redeliver(r.Context(), req.DeliveryID)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, dto.WebhookDeliveryResponse{
+		Delivery: dto.ToWebhookDeliveryDTO(delivery),
+	})
+}
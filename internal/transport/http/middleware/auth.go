@@ -3,6 +3,7 @@ package middleware
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/StepanK17/pr-reviewer-service/internal/transport/http/dto"
@@ -47,3 +48,26 @@ func respondError(w http.ResponseWriter, status int, code, message string) {
 
 	json.NewEncoder(w).Encode(response)
 }
+
+// SCIMAuth проверяет токен клиента SCIM и отвечает ошибкой в формате SCIM
+func SCIMAuth(scimToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			const prefix = "Bearer "
+			authHeader := r.Header.Get("Authorization")
+
+			if !strings.HasPrefix(authHeader, prefix) || strings.TrimPrefix(authHeader, prefix) != scimToken {
+				w.Header().Set("Content-Type", "application/scim+json")
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(dto.SCIMError{
+					Schemas: []string{dto.SCIMErrorSchema},
+					Status:  strconv.Itoa(http.StatusUnauthorized),
+					Detail:  "missing or invalid SCIM token",
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -10,12 +10,21 @@ import (
 
 // RouterConfig содержит конфигурацию для роутера
 type RouterConfig struct {
-	TeamHandler        *handler.TeamHandler
-	UserHandler        *handler.UserHandler
-	PullRequestHandler *handler.PullRequestHandler
-	HealthHandler      *handler.HealthHandler
-	StatisticsHandler  *handler.StatisticsHandler
-	AdminToken         string
+	TeamHandler               *handler.TeamHandler
+	UserHandler               *handler.UserHandler
+	PullRequestHandler        *handler.PullRequestHandler
+	HealthHandler             *handler.HealthHandler
+	StatisticsHandler         *handler.StatisticsHandler
+	ReviewRuleHandler         *handler.ReviewRuleHandler
+	ActivationScheduleHandler *handler.ActivationScheduleHandler
+	SCIMHandler               *handler.SCIMHandler
+	VCSHandler                *handler.VCSHandler
+	WebhookHandler            *handler.WebhookHandler
+	OutboxHandler             *handler.OutboxHandler
+	GitHubWebhookHandler      *handler.GitHubWebhookHandler
+	GitLabWebhookHandler      *handler.GitLabWebhookHandler
+	AdminToken                string
+	SCIMToken                 string
 }
 
 // NewRouter создает и настраивает роутер
@@ -33,22 +42,99 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 
 	// Statistics
 	r.Get("/statistics", cfg.StatisticsHandler.GetStatistics)
+	r.Get("/statistics/team", cfg.StatisticsHandler.GetTeamStatistics)
 
 	// Teams
 	r.Post("/team/add", cfg.TeamHandler.CreateTeam)
 	r.Get("/team/get", cfg.TeamHandler.GetTeam)
 	r.Post("/team/add", cfg.TeamHandler.CreateTeam)
 	r.Get("/team/get", cfg.TeamHandler.GetTeam)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/setParent", cfg.TeamHandler.SetTeamParent)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/rename", cfg.TeamHandler.RenameTeam)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/archive", cfg.TeamHandler.ArchiveTeam)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/unarchive", cfg.TeamHandler.UnarchiveTeam)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/delete", cfg.TeamHandler.DeleteTeam)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/addMembers", cfg.TeamHandler.AddTeamMembers)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/removeMember", cfg.TeamHandler.RemoveTeamMember)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/moveMember", cfg.TeamHandler.MoveTeamMember)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/setMembership", cfg.TeamHandler.SetMembership)
 	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/deactivateMembers", cfg.TeamHandler.DeactivateTeamMembers)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/reactivateMembers", cfg.TeamHandler.ReactivateTeamMembers)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/users/deactivate", cfg.TeamHandler.DeactivateUsers)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/users/reactivate", cfg.TeamHandler.ReactivateUsers)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/users/scheduleActivation", cfg.ActivationScheduleHandler.ScheduleActivation)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Get("/users/scheduledActivations", cfg.ActivationScheduleHandler.ListScheduledActivations)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/users/cancelScheduledActivation", cfg.ActivationScheduleHandler.CancelScheduledActivation)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Get("/users/activationAudit", cfg.ActivationScheduleHandler.GetActivationAudit)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/rebalance", cfg.TeamHandler.RebalanceTeam)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/sync", cfg.TeamHandler.SyncOrg)
+	r.Get("/team/escalation", cfg.TeamHandler.GetEscalationContacts)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/escalation", cfg.TeamHandler.SetEscalationContacts)
+	r.Get("/team/sizePolicy", cfg.TeamHandler.GetSizePolicy)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/team/sizePolicy", cfg.TeamHandler.SetSizePolicy)
 
 	// Users
 	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/users/setIsActive", cfg.UserHandler.SetIsActive)
 	r.Get("/users/getReview", cfg.UserHandler.GetReview)
+	r.Get("/users/get", cfg.UserHandler.GetUser)
+	r.Get("/users/list", cfg.UserHandler.ListUsers)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/users/update", cfg.UserHandler.UpdateUser)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/users/registerBot", cfg.UserHandler.RegisterBot)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/users/transfer", cfg.TeamHandler.TransferUser)
 
 	// Pull Requests
 	r.Post("/pullRequest/create", cfg.PullRequestHandler.CreatePR)
 	r.Post("/pullRequest/merge", cfg.PullRequestHandler.MergePR)
 	r.Post("/pullRequest/reassign", cfg.PullRequestHandler.ReassignReviewer)
+	r.Get("/pullRequest/history", cfg.PullRequestHandler.GetHistory)
+
+	// Review rules
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Get("/reviewRules/list", cfg.ReviewRuleHandler.ListRules)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Get("/reviewRules/get", cfg.ReviewRuleHandler.GetRule)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/reviewRules/create", cfg.ReviewRuleHandler.CreateRule)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/reviewRules/update", cfg.ReviewRuleHandler.UpdateRule)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/reviewRules/delete", cfg.ReviewRuleHandler.DeleteRule)
+
+	// Хостинги кода: привязка логинов и вебхуки
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Get("/vcs/identities", cfg.VCSHandler.ListIdentities)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/vcs/identities", cfg.VCSHandler.SetIdentity)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/vcs/identities/delete", cfg.VCSHandler.DeleteIdentity)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Get("/vcs/reviewerRequests", cfg.VCSHandler.ListReviewerRequests)
+	if cfg.GitHubWebhookHandler != nil {
+		r.Post("/webhooks/github", cfg.GitHubWebhookHandler.HandleWebhook)
+	}
+	if cfg.GitLabWebhookHandler != nil {
+		r.Post("/webhooks/gitlab", cfg.GitLabWebhookHandler.HandleWebhook)
+	}
+
+	// Исходящие вебхуки
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Get("/webhooks/subscriptions", cfg.WebhookHandler.ListSubscriptions)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/webhooks/subscriptions", cfg.WebhookHandler.CreateSubscription)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/webhooks/subscriptions/delete", cfg.WebhookHandler.DeleteSubscription)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Get("/webhooks/deliveries", cfg.WebhookHandler.ListDeliveries)
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Post("/webhooks/deliveries/redeliver", cfg.WebhookHandler.Redeliver)
+
+	// Outbox доменных событий
+	r.With(customMiddleware.AdminAuth(cfg.AdminToken)).Get("/outbox/messages", cfg.OutboxHandler.ListMessages)
+
+	// SCIM 2.0
+	r.Route("/scim/v2", func(r chi.Router) {
+		r.Use(customMiddleware.SCIMAuth(cfg.SCIMToken))
+
+		r.Get("/ServiceProviderConfig", cfg.SCIMHandler.ServiceProviderConfig)
+
+		r.Get("/Users", cfg.SCIMHandler.ListUsers)
+		r.Post("/Users", cfg.SCIMHandler.CreateUser)
+		r.Get("/Users/{id}", cfg.SCIMHandler.GetUser)
+		r.Patch("/Users/{id}", cfg.SCIMHandler.PatchUser)
+		r.Delete("/Users/{id}", cfg.SCIMHandler.DeleteUser)
+
+		r.Get("/Groups", cfg.SCIMHandler.ListGroups)
+		r.Post("/Groups", cfg.SCIMHandler.CreateGroup)
+		r.Get("/Groups/{id}", cfg.SCIMHandler.GetGroup)
+		r.Patch("/Groups/{id}", cfg.SCIMHandler.PatchGroup)
+		r.Delete("/Groups/{id}", cfg.SCIMHandler.DeleteGroup)
+	})
 
 	return r
 }
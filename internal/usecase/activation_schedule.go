@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+	"github.com/StepanK17/pr-reviewer-service/internal/repository"
+)
+
+// ActivationScheduleUseCase реализует отложенные изменения активности пользователей
+type ActivationScheduleUseCase struct {
+	scheduleRepo repository.ActivationScheduleRepository
+	auditRepo    repository.ActivationAuditRepository
+	userRepo     repository.UserRepository
+	txManager    repository.TransactionManager
+	userUC       *UserUseCase
+}
+
+// NewActivationScheduleUseCase создает новый usecase для расписания активности
+func NewActivationScheduleUseCase(
+	scheduleRepo repository.ActivationScheduleRepository,
+	auditRepo repository.ActivationAuditRepository,
+	userRepo repository.UserRepository,
+	txManager repository.TransactionManager,
+	userUC *UserUseCase,
+) *ActivationScheduleUseCase {
+	return &ActivationScheduleUseCase{
+		scheduleRepo: scheduleRepo,
+		auditRepo:    auditRepo,
+		userRepo:     userRepo,
+		txManager:    txManager,
+		userUC:       userUC,
+	}
+}
+
+// ScheduleActivation планирует изменение активности пользователя на момент from.
+// Если задан until, в этот момент планируется обратное изменение (например, «деактивировать с 1 по 14 ноября»),
+// связанное с исходным через ReverseOfID.
+// Передача ревью при деактивации выполняется так же, как в SetIsActive.
+func (uc *ActivationScheduleUseCase) ScheduleActivation(
+	ctx context.Context,
+	userID string,
+	isActive bool,
+	from time.Time,
+	until *time.Time,
+	handOffReviews bool,
+	successorID string,
+) ([]*entity.ScheduledActivationChange, error) {
+	if successorID != "" {
+		handOffReviews = true
+	}
+
+	if handOffReviews && isActive {
+		return nil, invalidInput("reviews are handed off only on deactivation")
+	}
+
+	if until != nil && !until.After(from) {
+		return nil, invalidInput("until must be after from")
+	}
+
+	changes := make([]*entity.ScheduledActivationChange, 0, 2)
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		for _, id := range []string{userID, successorID} {
+			if id == "" {
+				continue
+			}
+			if _, err := uc.userRepo.GetByID(ctx, id); err != nil {
+				if errors.Is(err, domainErrors.ErrNotFound) {
+					return domainErrors.NewDomainError(
+						"NOT_FOUND",
+						"user "+id+" not found",
+						domainErrors.ErrNotFound,
+					)
+				}
+				return fmt.Errorf("failed to get user %s: %w", id, err)
+			}
+		}
+
+		now := time.Now().UTC()
+		changes = append(changes, &entity.ScheduledActivationChange{
+			UserID:         userID,
+			IsActive:       isActive,
+			ApplyAt:        from.UTC(),
+			HandOffReviews: handOffReviews,
+			SuccessorID:    successorID,
+			Status:         entity.ActivationChangePending,
+			CreatedAt:      now,
+		})
+
+		if err := uc.scheduleRepo.Create(ctx, changes[0]); err != nil {
+			return fmt.Errorf("failed to schedule activation change: %w", err)
+		}
+
+		if until != nil {
+			reverse := &entity.ScheduledActivationChange{
+				UserID:      userID,
+				IsActive:    !isActive,
+				ApplyAt:     until.UTC(),
+				ReverseOfID: changes[0].ID,
+				Status:      entity.ActivationChangePending,
+				CreatedAt:   now,
+			}
+			if err := uc.scheduleRepo.Create(ctx, reverse); err != nil {
+				return fmt.Errorf("failed to schedule reverse activation change: %w", err)
+			}
+			changes = append(changes, reverse)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// ListScheduledActivations возвращает запланированные изменения; пустые userID и status не фильтруют
+func (uc *ActivationScheduleUseCase) ListScheduledActivations(
+	ctx context.Context,
+	userID string,
+	status entity.ActivationChangeStatus,
+) ([]*entity.ScheduledActivationChange, error) {
+	changes, err := uc.scheduleRepo.List(ctx, userID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled activation changes: %w", err)
+	}
+
+	if changes == nil {
+		changes = []*entity.ScheduledActivationChange{}
+	}
+
+	return changes, nil
+}
+
+// CancelScheduledActivation отменяет ещё не применённое изменение вместе с ожидающей второй половиной пары from/until.
+// Возвращает все отменённые изменения.
+func (uc *ActivationScheduleUseCase) CancelScheduledActivation(ctx context.Context, changeID int64) ([]*entity.ScheduledActivationChange, error) {
+	var cancelled []*entity.ScheduledActivationChange
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		change, err := uc.scheduleRepo.GetByID(ctx, changeID)
+		if err != nil {
+			if errors.Is(err, domainErrors.ErrNotFound) {
+				return domainErrors.NewDomainError(
+					"NOT_FOUND",
+					"scheduled activation change not found",
+					domainErrors.ErrNotFound,
+				)
+			}
+			return fmt.Errorf("failed to get scheduled activation change: %w", err)
+		}
+
+		if change.Status != entity.ActivationChangePending {
+			return domainErrors.NewDomainError(
+				"ACTIVATION_CHANGE_NOT_PENDING",
+				"scheduled activation change is already "+string(change.Status),
+				domainErrors.ErrActivationChangeNotPending,
+			)
+		}
+
+		pair, err := uc.getPair(ctx, change)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		for _, c := range []*entity.ScheduledActivationChange{change, pair} {
+			if c == nil || c.Status != entity.ActivationChangePending {
+				continue
+			}
+			if err := uc.scheduleRepo.SetStatus(ctx, c.ID, entity.ActivationChangeCancelled, now); err != nil {
+				return fmt.Errorf("failed to cancel scheduled activation change: %w", err)
+			}
+
+			c.Status = entity.ActivationChangeCancelled
+			c.ProcessedAt = &now
+			cancelled = append(cancelled, c)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cancelled, nil
+}
+
+// getPair возвращает вторую половину пары from/until или nil, если изменение запланировано без until
+func (uc *ActivationScheduleUseCase) getPair(
+	ctx context.Context,
+	change *entity.ScheduledActivationChange,
+) (*entity.ScheduledActivationChange, error) {
+	var (
+		pair *entity.ScheduledActivationChange
+		err  error
+	)
+
+	if change.ReverseOfID != 0 {
+		pair, err = uc.scheduleRepo.GetByID(ctx, change.ReverseOfID)
+	} else {
+		pair, err = uc.scheduleRepo.GetReverse(ctx, change.ID)
+	}
+
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get paired activation change: %w", err)
+	}
+
+	return pair, nil
+}
+
+// GetActivationAudit возвращает аудит применённых изменений активности пользователя
+func (uc *ActivationScheduleUseCase) GetActivationAudit(ctx context.Context, userID string) ([]*entity.ActivationAuditEntry, error) {
+	entries, err := uc.auditRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activation audit: %w", err)
+	}
+
+	if entries == nil {
+		entries = []*entity.ActivationAuditEntry{}
+	}
+
+	return entries, nil
+}
+
+// ApplyDueActivations применяет все наступившие к моменту now изменения, по одному в транзакции.
+// Неудачное изменение помечается FAILED и не мешает остальным. Возвращает число обработанных изменений.
+func (uc *ActivationScheduleUseCase) ApplyDueActivations(ctx context.Context, now time.Time) (int, error) {
+	processed := 0
+	for {
+		applied := false
+
+		err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+			change, err := uc.scheduleRepo.LockNextDue(ctx, now.UTC())
+			if err != nil {
+				return err
+			}
+			if change == nil {
+				return nil
+			}
+
+			applied = true
+			return uc.applyChange(ctx, change)
+		})
+
+		if err != nil {
+			return processed, err
+		}
+		if !applied {
+			return processed, nil
+		}
+		processed++
+	}
+}
+
+// applyChange применяет одно изменение в точке сохранения и записывает его итог в аудит
+func (uc *ActivationScheduleUseCase) applyChange(ctx context.Context, change *entity.ScheduledActivationChange) error {
+	audit := &entity.ActivationAuditEntry{
+		ChangeID: change.ID,
+		UserID:   change.UserID,
+		IsActive: change.IsActive,
+		Outcome:  entity.ActivationChangeApplied,
+	}
+
+	err := uc.txManager.RunInSavepoint(ctx, func(ctx context.Context) error {
+		_, report, err := uc.userUC.SetIsActive(ctx, change.UserID, change.IsActive, change.HandOffReviews, change.SuccessorID)
+		if err != nil {
+			return err
+		}
+
+		if report != nil {
+			audit.ReassignedPRs = report.ReassignedPRs
+			audit.UnassignedPRs = report.UnassignedPRs
+			audit.FailedPRs = report.FailedPRs
+		}
+		return nil
+	})
+
+	if err != nil {
+		audit.Outcome = entity.ActivationChangeFailed
+		audit.ErrorCode = errorCode(err)
+		audit.ErrorMessage = err.Error()
+	}
+
+	now := time.Now().UTC()
+	if err := uc.scheduleRepo.SetStatus(ctx, change.ID, audit.Outcome, now); err != nil {
+		return fmt.Errorf("failed to update scheduled activation change: %w", err)
+	}
+
+	audit.CreatedAt = now
+	if err := uc.auditRepo.Add(ctx, audit); err != nil {
+		return fmt.Errorf("failed to write activation audit: %w", err)
+	}
+
+	return nil
+}
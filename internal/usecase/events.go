@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+	"github.com/StepanK17/pr-reviewer-service/internal/repository"
+)
+
+// EventPublisher принимает доменные события.
+// Publish вызывается внутри транзакции изменения, поэтому откат транзакции отменяет и событие.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *entity.DomainEvent) error
+}
+
+// eventEnvelope JSON-представление события для вебхуков и внешних получателей
+type eventEnvelope struct {
+	ID            string                 `json:"id"`
+	Type          entity.EventType       `json:"type"`
+	OccurredAt    string                 `json:"occurred_at"`
+	TeamName      string                 `json:"team_name,omitempty"`
+	PullRequestID string                 `json:"pull_request_id,omitempty"`
+	Data          map[string]interface{} `json:"data"`
+}
+
+// EncodeEvent кодирует событие в JSON: {"id", "type", "occurred_at", "team_name", "pull_request_id", "data"}
+func EncodeEvent(event *entity.DomainEvent) ([]byte, error) {
+	body, err := json.Marshal(eventEnvelope{
+		ID:            event.ID,
+		Type:          event.Type,
+		OccurredAt:    event.OccurredAt.UTC().Format(time.RFC3339),
+		TeamName:      event.TeamName,
+		PullRequestID: event.PullRequestID,
+		Data:          event.Data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+	return body, nil
+}
+
+// publishEvent создает событие с новым ID и передаёт его издателю
+func publishEvent(
+	ctx context.Context,
+	publisher EventPublisher,
+	eventType entity.EventType,
+	teamName, prID string,
+	data map[string]interface{},
+) error {
+	id, err := randomHex(16)
+	if err != nil {
+		return err
+	}
+
+	if err := publisher.Publish(ctx, &entity.DomainEvent{
+		ID:            id,
+		Type:          eventType,
+		TeamName:      teamName,
+		PullRequestID: prID,
+		OccurredAt:    time.Now().UTC(),
+		Data:          data,
+	}); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", eventType, err)
+	}
+
+	return nil
+}
+
+// publishPREvent публикует событие PR с его текущим состоянием
+func publishPREvent(ctx context.Context, publisher EventPublisher, eventType entity.EventType, teamName string, pr *entity.PullRequest) error {
+	return publishEvent(ctx, publisher, eventType, teamName, pr.PullRequestID, map[string]interface{}{
+		"pull_request_id":    pr.PullRequestID,
+		"pull_request_name":  pr.PullRequestName,
+		"author_id":          pr.AuthorID,
+		"status":             string(pr.Status),
+		"assigned_reviewers": pr.AssignedReviewers,
+	})
+}
+
+// publishReassignedEvent публикует замену ревьювера PR
+func publishReassignedEvent(
+	ctx context.Context,
+	publisher EventPublisher,
+	teamName, prID, oldReviewerID, newReviewerID, reason string,
+	escalated bool,
+) error {
+	return publishEvent(ctx, publisher, entity.EventReviewerReassigned, teamName, prID, map[string]interface{}{
+		"pull_request_id": prID,
+		"old_reviewer_id": oldReviewerID,
+		"new_reviewer_id": newReviewerID,
+		"escalated":       escalated,
+		"reason":          reason,
+	})
+}
+
+// authorTeam возвращает основную команду автора PR, по которой фильтруются события PR
+func authorTeam(ctx context.Context, userRepo repository.UserRepository, authorID string) (string, error) {
+	author, err := userRepo.GetByID(ctx, authorID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get author: %w", err)
+	}
+
+	return author.TeamName, nil
+}
+
+// randomHex возвращает n случайных байт в hex
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,440 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+)
+
+// OrgSyncActionType вид изменения при синхронизации оргструктуры
+type OrgSyncActionType string
+
+const (
+	OrgSyncCreateTeam     OrgSyncActionType = "CREATE_TEAM"
+	OrgSyncSetParent      OrgSyncActionType = "SET_PARENT"
+	OrgSyncCreateUser     OrgSyncActionType = "CREATE_USER"
+	OrgSyncUpdateUser     OrgSyncActionType = "UPDATE_USER"
+	OrgSyncMoveUser       OrgSyncActionType = "MOVE_USER"
+	OrgSyncSetMembership  OrgSyncActionType = "SET_MEMBERSHIP"
+	OrgSyncDeactivateUser OrgSyncActionType = "DEACTIVATE_USER"
+)
+
+// OrgSyncAction одно изменение плана синхронизации.
+// FromTeam — прежняя команда (MOVE_USER) или прежний родитель (SET_PARENT), Fields — изменённые поля (UPDATE_USER).
+type OrgSyncAction struct {
+	Type           OrgSyncActionType
+	TeamName       string
+	ParentTeamName string
+	UserID         string
+	FromTeam       string
+	Fields         []string
+}
+
+// OrgSyncResult план синхронизации и передача ревью при его применении
+type OrgSyncResult struct {
+	DryRun   bool
+	Actions  []OrgSyncAction
+	HandOffs []ReviewHandOff
+}
+
+// orgSyncPlan план синхронизации с данными для применения
+type orgSyncPlan struct {
+	actions     []OrgSyncAction
+	newTeams    []*entity.Team
+	parents     []OrgSyncAction
+	moves       []OrgSyncAction
+	users       []*entity.User
+	memberships []*entity.TeamMembership
+	deactivate  []string
+}
+
+// SyncOrg приводит команды и участников к описанию teams и применяет план в одной транзакции.
+// Первая команда пользователя в описании становится основной, остальные — дополнительными.
+// Переведённые пользователи передают ревью PR старой команды (REASSIGN), а активные пользователи
+// описанных команд, которых нет в описании, деактивируются с передачей ревью, как при деактивации.
+// Не указанные поля профиля и родитель команды не меняются. Команды, не упомянутые в описании, не затрагиваются.
+// В режиме dryRun план применяется и откатывается.
+func (uc *TeamUseCase) SyncOrg(ctx context.Context, teams []*entity.TeamWithMembers, dryRun bool) (*OrgSyncResult, error) {
+	if err := validateOrgSpec(teams); err != nil {
+		return nil, err
+	}
+
+	var result *OrgSyncResult
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		plan, err := uc.planOrgSync(ctx, teams)
+		if err != nil {
+			return err
+		}
+
+		result = &OrgSyncResult{
+			DryRun:   dryRun,
+			Actions:  plan.actions,
+			HandOffs: make([]ReviewHandOff, 0),
+		}
+
+		if err := uc.applyOrgSync(ctx, plan, result); err != nil {
+			return err
+		}
+
+		if dryRun {
+			return errDryRunRollback
+		}
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errDryRunRollback) {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// validateOrgSpec проверяет роли, веса и профили участников описания
+func validateOrgSpec(teams []*entity.TeamWithMembers) error {
+	if len(teams) == 0 {
+		return invalidInput("org description has no teams")
+	}
+
+	for _, team := range teams {
+		for _, member := range team.Members {
+			if !member.Role.IsValid() {
+				return invalidInput("user " + member.UserID + ": role must be MEMBER or LEAD")
+			}
+			if member.ReviewWeight != nil && (*member.ReviewWeight <= 0 || *member.ReviewWeight > entity.MaxReviewWeight) {
+				return invalidInput("user " + member.UserID + ": review_weight must be greater than 0 and at most 10")
+			}
+			if err := member.UserProfile.Validate(); err != nil {
+				return invalidInput("user " + member.UserID + ": " + err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// planOrgSync сравнивает описание с текущим состоянием
+func (uc *TeamUseCase) planOrgSync(ctx context.Context, teams []*entity.TeamWithMembers) (*orgSyncPlan, error) {
+	plan := &orgSyncPlan{actions: make([]OrgSyncAction, 0)}
+
+	if err := uc.planOrgTeams(ctx, teams, plan); err != nil {
+		return nil, err
+	}
+
+	// Основная команда пользователя — первая, где он указан
+	primary := make(map[string]string)
+	listed := make([]entity.TeamMember, 0)
+	for _, team := range teams {
+		for _, member := range team.Members {
+			if _, ok := primary[member.UserID]; !ok {
+				primary[member.UserID] = team.TeamName
+				listed = append(listed, member)
+			}
+		}
+	}
+
+	now := time.Now()
+	for _, member := range listed {
+		if err := uc.planOrgUser(ctx, member, primary[member.UserID], now, plan); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, team := range teams {
+		for _, member := range team.Members {
+			if err := uc.planOrgMembership(ctx, team.TeamName, member, primary[member.UserID] == team.TeamName, now, plan); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Активные участники описанных команд, которых нет в описании
+	for _, team := range teams {
+		users, err := uc.userRepo.GetByTeam(ctx, team.TeamName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team members: %w", err)
+		}
+
+		for _, user := range users {
+			if _, ok := primary[user.UserID]; ok || user.TeamName != team.TeamName || !user.IsActive || user.IsBot() {
+				continue
+			}
+
+			plan.deactivate = append(plan.deactivate, user.UserID)
+			plan.actions = append(plan.actions, OrgSyncAction{
+				Type:     OrgSyncDeactivateUser,
+				TeamName: team.TeamName,
+				UserID:   user.UserID,
+			})
+		}
+	}
+
+	return plan, nil
+}
+
+// planOrgTeams планирует создание команд (родители раньше потомков) и смену родителей
+func (uc *TeamUseCase) planOrgTeams(ctx context.Context, teams []*entity.TeamWithMembers, plan *orgSyncPlan) error {
+	described := make(map[string]bool, len(teams))
+	for _, team := range teams {
+		described[team.TeamName] = true
+	}
+
+	pending := make([]*entity.TeamWithMembers, 0)
+	for _, spec := range teams {
+		team, err := uc.teamRepo.GetByName(ctx, spec.TeamName)
+		if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+			return fmt.Errorf("failed to get team %s: %w", spec.TeamName, err)
+		}
+
+		if spec.ParentTeamName != "" && !described[spec.ParentTeamName] {
+			if err := uc.ensureTeamExists(ctx, spec.ParentTeamName); err != nil {
+				return err
+			}
+		}
+
+		if team == nil {
+			pending = append(pending, spec)
+			continue
+		}
+
+		if spec.ParentTeamName != "" && spec.ParentTeamName != team.ParentTeamName {
+			action := OrgSyncAction{
+				Type:           OrgSyncSetParent,
+				TeamName:       spec.TeamName,
+				ParentTeamName: spec.ParentTeamName,
+				FromTeam:       team.ParentTeamName,
+			}
+			plan.parents = append(plan.parents, action)
+			plan.actions = append(plan.actions, action)
+		}
+	}
+
+	created := make(map[string]bool)
+	waiting := make(map[string]bool, len(pending))
+	for _, spec := range pending {
+		waiting[spec.TeamName] = true
+	}
+
+	now := time.Now()
+	for len(pending) > 0 {
+		next := make([]*entity.TeamWithMembers, 0, len(pending))
+		for _, spec := range pending {
+			if waiting[spec.ParentTeamName] && !created[spec.ParentTeamName] {
+				next = append(next, spec)
+				continue
+			}
+
+			created[spec.TeamName] = true
+			plan.newTeams = append(plan.newTeams, &entity.Team{
+				TeamName:       spec.TeamName,
+				ParentTeamName: spec.ParentTeamName,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			})
+			plan.actions = append(plan.actions, OrgSyncAction{
+				Type:           OrgSyncCreateTeam,
+				TeamName:       spec.TeamName,
+				ParentTeamName: spec.ParentTeamName,
+			})
+		}
+
+		if len(next) == len(pending) {
+			return invalidInput("team hierarchy in org description has a cycle")
+		}
+		pending = next
+	}
+
+	return nil
+}
+
+// planOrgUser планирует создание, изменение, перевод или деактивацию пользователя
+func (uc *TeamUseCase) planOrgUser(ctx context.Context, member entity.TeamMember, teamName string, now time.Time, plan *orgSyncPlan) error {
+	existing, err := uc.userRepo.GetByID(ctx, member.UserID)
+	if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if existing == nil {
+		plan.users = append(plan.users, &entity.User{
+			UserID:      member.UserID,
+			Username:    member.Username,
+			TeamName:    teamName,
+			IsActive:    member.IsActive,
+			Kind:        entity.UserKindHuman,
+			UserProfile: member.UserProfile,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+		plan.actions = append(plan.actions, OrgSyncAction{
+			Type:     OrgSyncCreateUser,
+			TeamName: teamName,
+			UserID:   member.UserID,
+		})
+		return nil
+	}
+
+	if existing.IsBot() {
+		return invalidInput("user " + member.UserID + " is a bot and cannot be synced as a team member")
+	}
+
+	if existing.TeamName != teamName {
+		action := OrgSyncAction{
+			Type:     OrgSyncMoveUser,
+			TeamName: teamName,
+			UserID:   member.UserID,
+			FromTeam: existing.TeamName,
+		}
+		plan.moves = append(plan.moves, action)
+		plan.actions = append(plan.actions, action)
+	}
+
+	updated := *existing
+	updated.TeamName = teamName
+	updated.Username = member.Username
+	updated.UserProfile = existing.UserProfile.Merge(member.UserProfile)
+	updated.UpdatedAt = now
+
+	var fields []string
+	if updated.Username != existing.Username {
+		fields = append(fields, "username")
+	}
+	if updated.DisplayName != existing.DisplayName {
+		fields = append(fields, "display_name")
+	}
+	if updated.Email != existing.Email {
+		fields = append(fields, "email")
+	}
+	if updated.ChatHandle != existing.ChatHandle {
+		fields = append(fields, "chat_handle")
+	}
+	if updated.Timezone != existing.Timezone {
+		fields = append(fields, "timezone")
+	}
+	// Реактивация — простое изменение флага, деактивация передаёт ревью
+	if member.IsActive && !existing.IsActive {
+		updated.IsActive = true
+		fields = append(fields, "is_active")
+	}
+
+	if len(fields) > 0 {
+		plan.users = append(plan.users, &updated)
+		plan.actions = append(plan.actions, OrgSyncAction{
+			Type:     OrgSyncUpdateUser,
+			TeamName: teamName,
+			UserID:   member.UserID,
+			Fields:   fields,
+		})
+	}
+
+	if !member.IsActive && existing.IsActive {
+		plan.deactivate = append(plan.deactivate, member.UserID)
+		plan.actions = append(plan.actions, OrgSyncAction{
+			Type:     OrgSyncDeactivateUser,
+			TeamName: teamName,
+			UserID:   member.UserID,
+		})
+	}
+
+	return nil
+}
+
+// planOrgMembership планирует роль и вес участника; дополнительное членство создаётся, если его нет
+func (uc *TeamUseCase) planOrgMembership(
+	ctx context.Context,
+	teamName string,
+	member entity.TeamMember,
+	isPrimary bool,
+	now time.Time,
+	plan *orgSyncPlan,
+) error {
+	if isPrimary && member.Role == "" && member.ReviewWeight == nil {
+		return nil
+	}
+
+	existing, err := uc.membershipRepo.Get(ctx, teamName, member.UserID)
+	if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+		return fmt.Errorf("failed to get team membership: %w", err)
+	}
+
+	role := member.Role
+	if role == "" {
+		role = entity.TeamRoleMember
+	}
+
+	if existing != nil && existing.Role == role && sameReviewWeight(existing.ReviewWeight, member.ReviewWeight) {
+		return nil
+	}
+
+	plan.memberships = append(plan.memberships, &entity.TeamMembership{
+		TeamName:     teamName,
+		UserID:       member.UserID,
+		Role:         role,
+		ReviewWeight: member.ReviewWeight,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+	plan.actions = append(plan.actions, OrgSyncAction{
+		Type:     OrgSyncSetMembership,
+		TeamName: teamName,
+		UserID:   member.UserID,
+	})
+
+	return nil
+}
+
+// applyOrgSync применяет план: команды, переводы с передачей ревью, пользователи, членства, деактивации
+func (uc *TeamUseCase) applyOrgSync(ctx context.Context, plan *orgSyncPlan, result *OrgSyncResult) error {
+	for _, team := range plan.newTeams {
+		if err := uc.teamRepo.Create(ctx, team); err != nil {
+			return fmt.Errorf("failed to create team %s: %w", team.TeamName, err)
+		}
+	}
+
+	for _, action := range plan.parents {
+		if _, err := uc.SetTeamParent(ctx, action.TeamName, action.ParentTeamName); err != nil {
+			return err
+		}
+	}
+
+	for _, action := range plan.moves {
+		moved, err := uc.changeMembership(ctx, action.UserID, action.FromTeam, action.TeamName, ReviewHandOffReassign, "org sync to team "+action.TeamName)
+		if err != nil {
+			return err
+		}
+		result.HandOffs = append(result.HandOffs, moved.HandOffs...)
+	}
+
+	if len(plan.users) > 0 {
+		if err := uc.userRepo.UpsertBatch(ctx, plan.users); err != nil {
+			return fmt.Errorf("failed to upsert users: %w", err)
+		}
+	}
+
+	for _, membership := range plan.memberships {
+		if err := uc.membershipRepo.Upsert(ctx, membership); err != nil {
+			return fmt.Errorf("failed to save membership of user %s: %w", membership.UserID, err)
+		}
+	}
+
+	if len(plan.deactivate) > 0 {
+		deactivated, err := uc.DeactivateUsers(ctx, plan.deactivate, false, false)
+		if err != nil {
+			return err
+		}
+		result.HandOffs = append(result.HandOffs, deactivated.HandOffs...)
+	}
+
+	return nil
+}
+
+// sameReviewWeight сравнивает веса с учётом отсутствия значения
+func sameReviewWeight(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
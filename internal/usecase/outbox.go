@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	"github.com/StepanK17/pr-reviewer-service/internal/repository"
+)
+
+// EventSink получатель событий из outbox.
+// Send вызывается вне транзакции и может быть вызван повторно для того же события,
+// получатель различает события по ID.
+type EventSink interface {
+	Name() string
+	Send(ctx context.Context, event *entity.DomainEvent) error
+}
+
+// OutboxUseCase записывает доменные события в outbox и публикует их получателям
+type OutboxUseCase struct {
+	outboxRepo   repository.OutboxRepository
+	txManager    repository.TransactionManager
+	sinks        []EventSink
+	retryBackoff time.Duration
+	maxAttempts  int
+}
+
+// NewOutboxUseCase создает usecase outbox
+func NewOutboxUseCase(
+	outboxRepo repository.OutboxRepository,
+	txManager repository.TransactionManager,
+	sinks []EventSink,
+	retryBackoff time.Duration,
+	maxAttempts int,
+) *OutboxUseCase {
+	return &OutboxUseCase{
+		outboxRepo:   outboxRepo,
+		txManager:    txManager,
+		sinks:        sinks,
+		retryBackoff: retryBackoff,
+		maxAttempts:  maxAttempts,
+	}
+}
+
+// Publish записывает событие в outbox в транзакции из ctx
+func (uc *OutboxUseCase) Publish(ctx context.Context, event *entity.DomainEvent) error {
+	now := time.Now().UTC()
+	return uc.outboxRepo.Create(ctx, &entity.OutboxMessage{
+		Event:         *event,
+		Status:        entity.OutboxPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
+}
+
+// DispatchDue публикует все готовые события и возвращает число обработанных.
+// Событие захватывается в короткой транзакции и передаётся получателям после её коммита, чтобы медленный
+// получатель не держал соединение с базой и блокировку. Получатели, принявшие событие, запоминаются
+// и при повторе пропускаются; событие опубликовано, когда его приняли все получатели, иначе оно откладывается
+// с удвоением паузы, а после maxAttempts получает статус FAILED и перестаёт задерживать события своего PR.
+func (uc *OutboxUseCase) DispatchDue(ctx context.Context, now time.Time) (int, error) {
+	processed := 0
+
+	for {
+		message, err := uc.claimNextDue(ctx, now)
+		if err != nil {
+			return processed, err
+		}
+
+		if message == nil {
+			return processed, nil
+		}
+
+		uc.dispatch(ctx, message, now)
+
+		if err := uc.outboxRepo.Update(ctx, message); err != nil {
+			return processed, err
+		}
+
+		processed++
+	}
+}
+
+// claimNextDue захватывает готовое событие, откладывая его на claimLease, чтобы другие обработчики
+// не взяли его во время публикации. Возвращает nil, если готовых событий нет.
+func (uc *OutboxUseCase) claimNextDue(ctx context.Context, now time.Time) (*entity.OutboxMessage, error) {
+	var message *entity.OutboxMessage
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		locked, err := uc.outboxRepo.LockNextDue(ctx, now)
+		if err != nil || locked == nil {
+			return err
+		}
+
+		locked.NextAttemptAt = now.Add(claimLease)
+		if err := uc.outboxRepo.Update(ctx, locked); err != nil {
+			return err
+		}
+
+		message = locked
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// dispatch передаёт событие получателям, ещё не принявшим его, и записывает результат в message
+func (uc *OutboxUseCase) dispatch(ctx context.Context, message *entity.OutboxMessage, now time.Time) {
+	published := make(map[string]bool, len(message.PublishedSinks))
+	for _, name := range message.PublishedSinks {
+		published[name] = true
+	}
+
+	var failures []string
+	for _, sink := range uc.sinks {
+		if published[sink.Name()] {
+			continue
+		}
+
+		if err := sink.Send(ctx, &message.Event); err != nil {
+			failures = append(failures, sink.Name()+": "+err.Error())
+			continue
+		}
+
+		message.PublishedSinks = append(message.PublishedSinks, sink.Name())
+	}
+
+	message.Attempts++
+
+	if len(failures) > 0 {
+		message.LastError = strings.Join(failures, "; ")
+		if message.Attempts >= uc.maxAttempts {
+			message.Status = entity.OutboxFailed
+		} else {
+			message.NextAttemptAt = now.Add(retryDelay(uc.retryBackoff, message.Attempts))
+		}
+		return
+	}
+
+	message.Status = entity.OutboxPublished
+	message.LastError = ""
+	message.PublishedAt = &now
+}
+
+// ListMessages возвращает события outbox; пустые prID и status не фильтруют
+func (uc *OutboxUseCase) ListMessages(ctx context.Context, prID string, status entity.OutboxStatus) ([]*entity.OutboxMessage, error) {
+	messages, err := uc.outboxRepo.List(ctx, prID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if messages == nil {
+		messages = []*entity.OutboxMessage{}
+	}
+
+	return messages, nil
+}
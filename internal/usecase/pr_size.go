@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+	"github.com/StepanK17/pr-reviewer-service/internal/repository"
+)
+
+// defaultReviewerCount количество ревьюверов, если размер PR не передан
+const defaultReviewerCount = 2
+
+// maxReviewerCount верхняя граница количества ревьюверов в классе размера
+const maxReviewerCount = 10
+
+// maxSizeClassLength максимальная длина названия класса размера (size_class VARCHAR(20))
+const maxSizeClassLength = 20
+
+// defaultSizeBuckets возвращает таблицу классов размера по умолчанию
+func defaultSizeBuckets() []*entity.SizeBucket {
+	limit := func(v int) *int { return &v }
+
+	return []*entity.SizeBucket{
+		{SizeClass: "XS", MaxLines: limit(10), ReviewerCount: 1, SLAHours: 4},
+		{SizeClass: "S", MaxLines: limit(100), ReviewerCount: 2, SLAHours: 24},
+		{SizeClass: "M", MaxLines: limit(500), ReviewerCount: 2, SLAHours: 48},
+		{SizeClass: "L", MaxLines: limit(1000), ReviewerCount: 3, SLAHours: 72},
+		{SizeClass: "XL", ReviewerCount: 3, SLAHours: 96},
+	}
+}
+
+// loadSizePolicy возвращает таблицу классов размера команды, ближайшего предка с настроенной таблицей
+// или таблицу по умолчанию
+func loadSizePolicy(
+	ctx context.Context,
+	teamRepo repository.TeamRepository,
+	repo repository.SizePolicyRepository,
+	teamName string,
+) (*entity.SizePolicy, error) {
+	buckets, err := repo.GetByTeam(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get size buckets: %w", err)
+	}
+
+	if len(buckets) > 0 {
+		return &entity.SizePolicy{
+			TeamName: teamName,
+			Buckets:  buckets,
+		}, nil
+	}
+
+	ancestors, err := teamAncestorNames(ctx, teamRepo, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ancestor := range ancestors {
+		buckets, err := repo.GetByTeam(ctx, ancestor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get size buckets: %w", err)
+		}
+
+		if len(buckets) > 0 {
+			return &entity.SizePolicy{
+				TeamName:      teamName,
+				Buckets:       buckets,
+				InheritedFrom: ancestor,
+			}, nil
+		}
+	}
+
+	return &entity.SizePolicy{
+		TeamName:  teamName,
+		Buckets:   defaultSizeBuckets(),
+		IsDefault: true,
+	}, nil
+}
+
+// classifySize выбирает класс размера PR по упорядоченной таблице.
+// Если PR больше всех ограничений, используется последний класс.
+func classifySize(buckets []*entity.SizeBucket, size entity.PRSize) *entity.SizeBucket {
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	for _, bucket := range buckets {
+		if bucket.MaxLines != nil && size.TotalLines() > *bucket.MaxLines {
+			continue
+		}
+		if bucket.MaxFiles != nil && size.FilesChanged > *bucket.MaxFiles {
+			continue
+		}
+		return bucket
+	}
+
+	return buckets[len(buckets)-1]
+}
+
+// validateSizeBuckets проверяет таблицу классов размера и упорядочивает её по max_lines
+func validateSizeBuckets(buckets []*entity.SizeBucket) error {
+	if len(buckets) == 0 {
+		return invalidInput("buckets must not be empty")
+	}
+
+	classes := make(map[string]bool, len(buckets))
+	limits := make(map[int]bool, len(buckets))
+	unbounded := 0
+
+	for _, bucket := range buckets {
+		if bucket.SizeClass == "" {
+			return invalidInput("size_class is required")
+		}
+		if len([]rune(bucket.SizeClass)) > maxSizeClassLength {
+			return invalidInput(fmt.Sprintf("size_class must be at most %d characters", maxSizeClassLength))
+		}
+		if classes[bucket.SizeClass] {
+			return invalidInput("duplicate size_class " + bucket.SizeClass)
+		}
+		classes[bucket.SizeClass] = true
+
+		if bucket.ReviewerCount < 0 || bucket.ReviewerCount > maxReviewerCount {
+			return invalidInput(fmt.Sprintf("reviewer_count must be between 0 and %d", maxReviewerCount))
+		}
+		if bucket.SLAHours <= 0 {
+			return invalidInput("sla_hours must be positive")
+		}
+		if bucket.MaxFiles != nil && *bucket.MaxFiles < 0 {
+			return invalidInput("max_files must not be negative")
+		}
+
+		if bucket.MaxLines == nil {
+			unbounded++
+			continue
+		}
+		if *bucket.MaxLines < 0 {
+			return invalidInput("max_lines must not be negative")
+		}
+		if limits[*bucket.MaxLines] {
+			return invalidInput(fmt.Sprintf("duplicate max_lines %d", *bucket.MaxLines))
+		}
+		limits[*bucket.MaxLines] = true
+	}
+
+	if unbounded > 1 {
+		return invalidInput("only one bucket may omit max_lines")
+	}
+
+	sort.SliceStable(buckets, func(i, j int) bool {
+		if buckets[i].MaxLines == nil {
+			return false
+		}
+		if buckets[j].MaxLines == nil {
+			return true
+		}
+		return *buckets[i].MaxLines < *buckets[j].MaxLines
+	})
+
+	return nil
+}
+
+// invalidInput создает доменную ошибку INVALID_INPUT
+func invalidInput(message string) error {
+	return domainErrors.NewDomainError(
+		"INVALID_INPUT",
+		message,
+		domainErrors.ErrInvalidInput,
+	)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
 	"time"
 
 	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
@@ -14,9 +13,18 @@ import (
 
 // PullRequestUseCase реализует бизнес-логику для PR
 type PullRequestUseCase struct {
-	prRepo    repository.PullRequestRepository
-	userRepo  repository.UserRepository
-	txManager repository.TransactionManager
+	prRepo          repository.PullRequestRepository
+	userRepo        repository.UserRepository
+	txManager       repository.TransactionManager
+	sizePolicyRepo  repository.SizePolicyRepository
+	escalationRepo  repository.EscalationRepository
+	historyRepo     repository.PRHistoryRepository
+	ruleRepo        repository.ReviewRuleRepository
+	teamRepo        repository.TeamRepository
+	membershipRepo  repository.MembershipRepository
+	prLinkRepo      repository.VCSPullRequestRepository
+	reviewerReqRepo repository.VCSReviewerRequestRepository
+	events          EventPublisher
 }
 
 // NewPullRequestUseCase создает новый usecase для PR
@@ -24,18 +32,46 @@ func NewPullRequestUseCase(
 	prRepo repository.PullRequestRepository,
 	userRepo repository.UserRepository,
 	txManager repository.TransactionManager,
+	sizePolicyRepo repository.SizePolicyRepository,
+	escalationRepo repository.EscalationRepository,
+	historyRepo repository.PRHistoryRepository,
+	ruleRepo repository.ReviewRuleRepository,
+	teamRepo repository.TeamRepository,
+	membershipRepo repository.MembershipRepository,
+	prLinkRepo repository.VCSPullRequestRepository,
+	reviewerReqRepo repository.VCSReviewerRequestRepository,
+	events EventPublisher,
 ) *PullRequestUseCase {
 	return &PullRequestUseCase{
-		prRepo:    prRepo,
-		userRepo:  userRepo,
-		txManager: txManager,
+		prRepo:          prRepo,
+		userRepo:        userRepo,
+		txManager:       txManager,
+		sizePolicyRepo:  sizePolicyRepo,
+		escalationRepo:  escalationRepo,
+		historyRepo:     historyRepo,
+		ruleRepo:        ruleRepo,
+		teamRepo:        teamRepo,
+		membershipRepo:  membershipRepo,
+		prLinkRepo:      prLinkRepo,
+		reviewerReqRepo: reviewerReqRepo,
+		events:          events,
 	}
 }
 
-// CreatePullRequest создает PR и автоматически назначает ревьюверов
+// ReassignReviewerResult результат переназначения ревьювера.
+// Escalated означает, что в команде не нашлось кандидата и назначен лид или контакт эскалации.
+type ReassignReviewerResult struct {
+	PR            *entity.PullRequest
+	NewReviewerID string
+	Escalated     bool
+}
+
+// CreatePullRequest создает PR и автоматически назначает ревьюверов.
+// Количество ревьюверов и SLA определяются классом размера PR по таблице команды автора.
 func (uc *PullRequestUseCase) CreatePullRequest(
 	ctx context.Context,
 	prID, prName, authorID string,
+	size entity.PRSize,
 ) (*entity.PullRequest, error) {
 	var result *entity.PullRequest
 
@@ -67,27 +103,65 @@ func (uc *PullRequestUseCase) CreatePullRequest(
 			return fmt.Errorf("failed to get author: %w", err)
 		}
 
-		// Получаем активных пользователей команды автора (исключая автора)
-		reviewers, err := uc.selectReviewers(ctx, author.TeamName, authorID)
-		if err != nil {
-			return fmt.Errorf("failed to select reviewers: %w", err)
+		now := time.Now()
+		pr := &entity.PullRequest{
+			PullRequestID:   prID,
+			PullRequestName: prName,
+			AuthorID:        authorID,
+			Status:          entity.PRStatusOpen,
+			Size:            size,
+			CreatedAt:       now,
+			MergedAt:        nil,
 		}
 
-		// Создаем PR
-		pr := &entity.PullRequest{
-			PullRequestID:     prID,
-			PullRequestName:   prName,
-			AuthorID:          authorID,
-			Status:            entity.PRStatusOpen,
-			AssignedReviewers: reviewers,
-			CreatedAt:         time.Now(),
-			MergedAt:          nil,
+		// Определяем класс размера, если размер передан
+		reviewerCount := defaultReviewerCount
+		if !size.IsEmpty() {
+			policy, err := loadSizePolicy(ctx, uc.teamRepo, uc.sizePolicyRepo, author.TeamName)
+			if err != nil {
+				return err
+			}
+
+			bucket := classifySize(policy.Buckets, size)
+			reviewerCount = bucket.ReviewerCount
+			pr.SizeClass = bucket.SizeClass
+			dueAt := now.Add(time.Duration(bucket.SLAHours) * time.Hour)
+			pr.ReviewDueAt = &dueAt
 		}
 
+		// Получаем активных пользователей команды автора (исключая автора).
+		// Для ботов это команда-владелец, к которой привязан сервисный аккаунт.
+		reviewers, err := uc.selectReviewers(ctx, author.TeamName, authorID, reviewerCount)
+		if err != nil {
+			return fmt.Errorf("failed to select reviewers: %w", err)
+		}
+		pr.AssignedReviewers = reviewers
+
 		if err := uc.prRepo.Create(ctx, pr); err != nil {
 			return fmt.Errorf("failed to create PR: %w", err)
 		}
 
+		if err := uc.historyRepo.Add(ctx, &entity.PRHistoryEntry{
+			PullRequestID: pr.PullRequestID,
+			EventType:     entity.PRHistoryCreated,
+			CreatedAt:     now,
+		}); err != nil {
+			return fmt.Errorf("failed to write PR history: %w", err)
+		}
+
+		if err := publishPREvent(ctx, uc.events, entity.EventPRCreated, author.TeamName, pr); err != nil {
+			return err
+		}
+
+		for _, reviewerID := range pr.AssignedReviewers {
+			if err := publishEvent(ctx, uc.events, entity.EventReviewerAssigned, author.TeamName, pr.PullRequestID, map[string]interface{}{
+				"pull_request_id": pr.PullRequestID,
+				"reviewer_id":     reviewerID,
+			}); err != nil {
+				return err
+			}
+		}
+
 		result = pr
 		return nil
 	})
@@ -123,6 +197,14 @@ func (uc *PullRequestUseCase) MergePullRequest(ctx context.Context, prID string)
 			return nil
 		}
 
+		if pr.Status == entity.PRStatusClosed {
+			return domainErrors.NewDomainError(
+				"PR_CLOSED",
+				"cannot merge closed PR",
+				domainErrors.ErrPRClosed,
+			)
+		}
+
 		// Помечаем как merged
 		now := time.Now()
 		pr.Status = entity.PRStatusMerged
@@ -132,7 +214,93 @@ func (uc *PullRequestUseCase) MergePullRequest(ctx context.Context, prID string)
 			return fmt.Errorf("failed to update PR: %w", err)
 		}
 
+		if err := uc.historyRepo.Add(ctx, &entity.PRHistoryEntry{
+			PullRequestID: pr.PullRequestID,
+			EventType:     entity.PRHistoryMerged,
+			CreatedAt:     now,
+		}); err != nil {
+			return fmt.Errorf("failed to write PR history: %w", err)
+		}
+
+		teamName, err := authorTeam(ctx, uc.userRepo, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+
+		if err := publishPREvent(ctx, uc.events, entity.EventPRMerged, teamName, pr); err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ClosePullRequest закрывает PR без слияния (идемпотентная операция).
+// Назначенные ревьюверы сохраняются, но закрытый PR не считается открытым ревью.
+func (uc *PullRequestUseCase) ClosePullRequest(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	return uc.changeStatus(ctx, prID, entity.PRStatusClosed, entity.PRHistoryClosed)
+}
+
+// ReopenPullRequest возвращает закрытый PR в работу с прежними ревьюверами (идемпотентная операция)
+func (uc *PullRequestUseCase) ReopenPullRequest(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	return uc.changeStatus(ctx, prID, entity.PRStatusOpen, entity.PRHistoryReopened)
+}
+
+// changeStatus переводит PR между OPEN и CLOSED и пишет событие в историю; merged PR не меняется
+func (uc *PullRequestUseCase) changeStatus(
+	ctx context.Context,
+	prID string,
+	status entity.PRStatus,
+	event entity.PRHistoryEvent,
+) (*entity.PullRequest, error) {
+	var result *entity.PullRequest
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		pr, err := uc.prRepo.GetByID(ctx, prID)
+		if err != nil {
+			if errors.Is(err, domainErrors.ErrNotFound) {
+				return domainErrors.NewDomainError(
+					"NOT_FOUND",
+					"PR not found",
+					domainErrors.ErrNotFound,
+				)
+			}
+			return fmt.Errorf("failed to get PR: %w", err)
+		}
+
+		if pr.Status == entity.PRStatusMerged {
+			return domainErrors.NewDomainError(
+				"PR_MERGED",
+				"cannot change status of merged PR",
+				domainErrors.ErrPRMerged,
+			)
+		}
+
 		result = pr
+		if pr.Status == status {
+			return nil
+		}
+
+		pr.Status = status
+		if err := uc.prRepo.Update(ctx, pr); err != nil {
+			return fmt.Errorf("failed to update PR: %w", err)
+		}
+
+		if err := uc.historyRepo.Add(ctx, &entity.PRHistoryEntry{
+			PullRequestID: pr.PullRequestID,
+			EventType:     event,
+			CreatedAt:     time.Now(),
+		}); err != nil {
+			return fmt.Errorf("failed to write PR history: %w", err)
+		}
+
 		return nil
 	})
 
@@ -143,13 +311,13 @@ func (uc *PullRequestUseCase) MergePullRequest(ctx context.Context, prID string)
 	return result, nil
 }
 
-// ReassignReviewer переназначает ревьювера
+// ReassignReviewer переназначает ревьювера.
+// Если в команде заменяемого ревьювера и её предках нет кандидатов, назначается лид или контакт эскалации.
 func (uc *PullRequestUseCase) ReassignReviewer(
 	ctx context.Context,
 	prID, oldUserID string,
-) (*entity.PullRequest, string, error) {
-	var result *entity.PullRequest
-	var newReviewerID string
+) (*ReassignReviewerResult, error) {
+	var result *ReassignReviewerResult
 
 	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
 		// Получаем PR
@@ -174,6 +342,14 @@ func (uc *PullRequestUseCase) ReassignReviewer(
 			)
 		}
 
+		if pr.Status == entity.PRStatusClosed {
+			return domainErrors.NewDomainError(
+				"PR_CLOSED",
+				"cannot reassign on closed PR",
+				domainErrors.ErrPRClosed,
+			)
+		}
+
 		// Проверяем, что oldUserID назначен ревьювером
 		oldUserIndex := -1
 		for i, reviewerID := range pr.AssignedReviewers {
@@ -204,36 +380,18 @@ func (uc *PullRequestUseCase) ReassignReviewer(
 			return fmt.Errorf("failed to get old reviewer: %w", err)
 		}
 
-		// Получаем активных пользователей команды заменяемого ревьювера
-		candidates, err := uc.userRepo.GetActiveByTeam(ctx, oldReviewer.TeamName)
+		rules, err := loadReviewRules(ctx, uc.ruleRepo, pr.AuthorID)
 		if err != nil {
-			return fmt.Errorf("failed to get team members: %w", err)
+			return err
 		}
 
-		// Фильтруем кандидатов (исключаем автора и уже назначенных ревьюверов)
-		var availableCandidates []*entity.User
-		for _, candidate := range candidates {
-			// Исключаем автора
-			if candidate.UserID == pr.AuthorID {
-				continue
-			}
-
-			// Исключаем уже назначенных ревьюверов
-			alreadyAssigned := false
-			for _, reviewerID := range pr.AssignedReviewers {
-				if candidate.UserID == reviewerID {
-					alreadyAssigned = true
-					break
-				}
-			}
-
-			if !alreadyAssigned {
-				availableCandidates = append(availableCandidates, candidate)
-			}
+		// Выбираем замену: пара автора, кандидат из команды заменяемого ревьювера или лид
+		newReviewer, escalated, err := pickReplacement(ctx, uc.teamRepo, uc.userRepo, uc.membershipRepo, uc.escalationRepo, rules, oldReviewer.TeamName, pr)
+		if err != nil {
+			return err
 		}
 
-		// Проверяем наличие доступных кандидатов
-		if len(availableCandidates) == 0 {
+		if newReviewer == nil {
 			return domainErrors.NewDomainError(
 				"NO_CANDIDATE",
 				"no active replacement candidate in team",
@@ -241,64 +399,138 @@ func (uc *PullRequestUseCase) ReassignReviewer(
 			)
 		}
 
-		// Выбираем случайного кандидата
-		newReviewer := availableCandidates[rand.Intn(len(availableCandidates))]
-		newReviewerID = newReviewer.UserID
-
 		// Заменяем ревьювера
-		pr.AssignedReviewers[oldUserIndex] = newReviewerID
+		pr.AssignedReviewers[oldUserIndex] = newReviewer.UserID
 
 		// Обновляем PR
 		if err := uc.prRepo.Update(ctx, pr); err != nil {
 			return fmt.Errorf("failed to update PR: %w", err)
 		}
 
-		result = pr
+		if err := uc.historyRepo.Add(ctx, &entity.PRHistoryEntry{
+			PullRequestID: pr.PullRequestID,
+			EventType:     entity.PRHistoryReviewerReassigned,
+			OldReviewerID: oldUserID,
+			NewReviewerID: newReviewer.UserID,
+			Escalated:     escalated,
+			Reason:        "manual reassign",
+			CreatedAt:     time.Now(),
+		}); err != nil {
+			return fmt.Errorf("failed to write PR history: %w", err)
+		}
+
+		if err := enqueueReviewerRequest(ctx, uc.prLinkRepo, uc.reviewerReqRepo, pr.PullRequestID,
+			[]string{newReviewer.UserID}, []string{oldUserID}); err != nil {
+			return err
+		}
+
+		teamName, err := authorTeam(ctx, uc.userRepo, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+
+		if err := publishReassignedEvent(ctx, uc.events, teamName, pr.PullRequestID, oldUserID, newReviewer.UserID,
+			"manual reassign", escalated); err != nil {
+			return err
+		}
+
+		result = &ReassignReviewerResult{
+			PR:            pr,
+			NewReviewerID: newReviewer.UserID,
+			Escalated:     escalated,
+		}
 		return nil
 	})
 
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
-	return result, newReviewerID, nil
+	return result, nil
 }
 
-// selectReviewers выбирает до 2 активных ревьюверов из команды (исключая автора)
-func (uc *PullRequestUseCase) selectReviewers(ctx context.Context, teamName, authorID string) ([]string, error) {
-	// Получаем активных пользователей команды
-	users, err := uc.userRepo.GetActiveByTeam(ctx, teamName)
+// GetHistory возвращает историю PR
+func (uc *PullRequestUseCase) GetHistory(ctx context.Context, prID string) ([]*entity.PRHistoryEntry, error) {
+	exists, err := uc.prRepo.Exists(ctx, prID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get active team members: %w", err)
+		return nil, fmt.Errorf("failed to check PR existence: %w", err)
 	}
 
-	// Фильтруем автора
-	var candidates []*entity.User
-	for _, user := range users {
-		if user.UserID != authorID {
-			candidates = append(candidates, user)
-		}
+	if !exists {
+		return nil, domainErrors.NewDomainError(
+			"NOT_FOUND",
+			"PR not found",
+			domainErrors.ErrNotFound,
+		)
 	}
 
-	// Если кандидатов нет, возвращаем пустой список
-	if len(candidates) == 0 {
-		return []string{}, nil
+	entries, err := uc.historyRepo.GetByPR(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR history: %w", err)
 	}
 
-	// Перемешиваем кандидатов для случайного выбора
-	rand.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
-	})
+	if entries == nil {
+		entries = []*entity.PRHistoryEntry{}
+	}
+
+	return entries, nil
+}
+
+// selectReviewers выбирает до count активных ревьюверов из команды (исключая автора).
+// Обязательные пары автора назначаются в первую очередь, запрещённые правилами ревьюверы исключаются.
+// Если в команде не хватает кандидатов, недостающие берутся из команд-предков, начиная с родителя.
+func (uc *PullRequestUseCase) selectReviewers(ctx context.Context, teamName, authorID string, count int) ([]string, error) {
+	rules, err := loadReviewRules(ctx, uc.ruleRepo, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	partners, err := pairedCandidates(ctx, uc.userRepo, rules, authorID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers := make([]string, 0, count)
+	for _, partner := range partners {
+		if len(reviewers) == count {
+			return reviewers, nil
+		}
+		reviewers = append(reviewers, partner.UserID)
+	}
 
-	// Выбираем до 2 ревьюверов
-	maxReviewers := 2
-	if len(candidates) < maxReviewers {
-		maxReviewers = len(candidates)
+	ancestors, err := teamAncestorNames(ctx, uc.teamRepo, teamName)
+	if err != nil {
+		return nil, err
 	}
 
-	reviewers := make([]string, maxReviewers)
-	for i := 0; i < maxReviewers; i++ {
-		reviewers[i] = candidates[i].UserID
+	for _, poolTeam := range append([]string{teamName}, ancestors...) {
+		if len(reviewers) >= count {
+			break
+		}
+
+		// Получаем активных пользователей команды
+		users, err := uc.userRepo.GetActiveByTeam(ctx, poolTeam)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get active team members: %w", err)
+		}
+
+		// Фильтруем автора, ботов, уже выбранных и запрещённых правилами
+		candidates := filterCandidates(users, authorID, reviewers, rules)
+
+		weights, err := loadReviewWeights(ctx, uc.membershipRepo, poolTeam)
+		if err != nil {
+			return nil, err
+		}
+
+		// Перемешиваем кандидатов с учётом веса: участники с меньшим весом выбираются реже
+		weightedShuffle(candidates, weights)
+
+		for _, candidate := range candidates {
+			if len(reviewers) == count {
+				break
+			}
+			reviewers = append(reviewers, candidate.UserID)
+		}
 	}
 
 	return reviewers, nil
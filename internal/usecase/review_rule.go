@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+	"github.com/StepanK17/pr-reviewer-service/internal/repository"
+)
+
+// ReviewRuleUseCase реализует бизнес-логику правил выбора ревьюверов
+type ReviewRuleUseCase struct {
+	ruleRepo  repository.ReviewRuleRepository
+	userRepo  repository.UserRepository
+	txManager repository.TransactionManager
+}
+
+// NewReviewRuleUseCase создает новый usecase для правил выбора ревьюверов
+func NewReviewRuleUseCase(
+	ruleRepo repository.ReviewRuleRepository,
+	userRepo repository.UserRepository,
+	txManager repository.TransactionManager,
+) *ReviewRuleUseCase {
+	return &ReviewRuleUseCase{
+		ruleRepo:  ruleRepo,
+		userRepo:  userRepo,
+		txManager: txManager,
+	}
+}
+
+// CreateRule создает правило
+func (uc *ReviewRuleUseCase) CreateRule(ctx context.Context, rule *entity.ReviewRule) (*entity.ReviewRule, error) {
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.validateRule(ctx, rule); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		rule.CreatedAt = now
+		rule.UpdatedAt = now
+
+		if err := uc.ruleRepo.Create(ctx, rule); err != nil {
+			return fmt.Errorf("failed to create review rule: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// UpdateRule обновляет правило
+func (uc *ReviewRuleUseCase) UpdateRule(ctx context.Context, rule *entity.ReviewRule) (*entity.ReviewRule, error) {
+	var result *entity.ReviewRule
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		existing, err := uc.getRule(ctx, rule.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.validateRule(ctx, rule); err != nil {
+			return err
+		}
+
+		existing.Type = rule.Type
+		existing.UserID = rule.UserID
+		existing.OtherUserID = rule.OtherUserID
+		existing.Reason = rule.Reason
+		existing.UpdatedAt = time.Now()
+
+		if err := uc.ruleRepo.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update review rule: %w", err)
+		}
+
+		result = existing
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteRule удаляет правило
+func (uc *ReviewRuleUseCase) DeleteRule(ctx context.Context, ruleID int64) error {
+	if err := uc.ruleRepo.Delete(ctx, ruleID); err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return ruleNotFound()
+		}
+		return fmt.Errorf("failed to delete review rule: %w", err)
+	}
+
+	return nil
+}
+
+// GetRule возвращает правило по ID
+func (uc *ReviewRuleUseCase) GetRule(ctx context.Context, ruleID int64) (*entity.ReviewRule, error) {
+	return uc.getRule(ctx, ruleID)
+}
+
+// ListRules возвращает все правила
+func (uc *ReviewRuleUseCase) ListRules(ctx context.Context) ([]*entity.ReviewRule, error) {
+	rules, err := uc.ruleRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review rules: %w", err)
+	}
+
+	if rules == nil {
+		rules = []*entity.ReviewRule{}
+	}
+
+	return rules, nil
+}
+
+// getRule возвращает правило или доменную ошибку NOT_FOUND
+func (uc *ReviewRuleUseCase) getRule(ctx context.Context, ruleID int64) (*entity.ReviewRule, error) {
+	rule, err := uc.ruleRepo.GetByID(ctx, ruleID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, ruleNotFound()
+		}
+		return nil, fmt.Errorf("failed to get review rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// validateRule проверяет правило: тип, существование пользователей,
+// отсутствие дубликатов и противоречия между NEVER_REVIEWS и ALWAYS_PAIR
+func (uc *ReviewRuleUseCase) validateRule(ctx context.Context, rule *entity.ReviewRule) error {
+	if rule.Type != entity.ReviewRuleNeverReviews && rule.Type != entity.ReviewRuleAlwaysPair {
+		return invalidInput("rule_type must be NEVER_REVIEWS or ALWAYS_PAIR")
+	}
+
+	if rule.UserID == "" || rule.OtherUserID == "" {
+		return invalidInput("user_id and other_user_id are required")
+	}
+
+	if rule.UserID == rule.OtherUserID {
+		return invalidInput("user_id and other_user_id must differ")
+	}
+
+	for _, userID := range []string{rule.UserID, rule.OtherUserID} {
+		if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+			if errors.Is(err, domainErrors.ErrNotFound) {
+				return domainErrors.NewDomainError(
+					"NOT_FOUND",
+					"user "+userID+" not found",
+					domainErrors.ErrNotFound,
+				)
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+	}
+
+	existing, err := uc.ruleRepo.GetByUsers(ctx, []string{rule.UserID})
+	if err != nil {
+		return fmt.Errorf("failed to get review rules: %w", err)
+	}
+
+	for _, other := range existing {
+		if other.ID == rule.ID {
+			continue
+		}
+
+		samePair := other.UserID == rule.UserID && other.OtherUserID == rule.OtherUserID
+		reversedPair := other.UserID == rule.OtherUserID && other.OtherUserID == rule.UserID
+		if !samePair && !reversedPair {
+			continue
+		}
+
+		// NEVER_REVIEWS направленное, ALWAYS_PAIR симметричное
+		duplicate := other.Type == rule.Type &&
+			(samePair || rule.Type == entity.ReviewRuleAlwaysPair)
+		if duplicate {
+			return domainErrors.NewDomainError(
+				"RULE_EXISTS",
+				"review rule already exists",
+				domainErrors.ErrRuleExists,
+			)
+		}
+
+		if other.Type != rule.Type {
+			return invalidInput(fmt.Sprintf("rule contradicts review rule %d", other.ID))
+		}
+	}
+
+	return nil
+}
+
+// ruleNotFound создает доменную ошибку для отсутствующего правила
+func ruleNotFound() error {
+	return domainErrors.NewDomainError(
+		"NOT_FOUND",
+		"review rule not found",
+		domainErrors.ErrNotFound,
+	)
+}
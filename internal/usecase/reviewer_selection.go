@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+	"github.com/StepanK17/pr-reviewer-service/internal/repository"
+)
+
+// reviewRuleSet правила выбора ревьюверов, загруженные для набора авторов.
+// Нулевой указатель означает отсутствие правил.
+type reviewRuleSet struct {
+	rules     []*entity.ReviewRule
+	forbidden map[string]map[string]bool
+	partners  map[string][]string
+}
+
+// loadReviewRules загружает правила, в которых участвуют авторы
+func loadReviewRules(ctx context.Context, repo repository.ReviewRuleRepository, authorIDs ...string) (*reviewRuleSet, error) {
+	rules, err := repo.GetByUsers(ctx, authorIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review rules: %w", err)
+	}
+
+	return newReviewRuleSet(rules), nil
+}
+
+// newReviewRuleSet строит индексы правил
+func newReviewRuleSet(rules []*entity.ReviewRule) *reviewRuleSet {
+	set := &reviewRuleSet{
+		rules:     rules,
+		forbidden: make(map[string]map[string]bool),
+		partners:  make(map[string][]string),
+	}
+
+	for _, rule := range rules {
+		switch rule.Type {
+		case entity.ReviewRuleNeverReviews:
+			// UserID не ревьюит PR автора OtherUserID
+			if set.forbidden[rule.OtherUserID] == nil {
+				set.forbidden[rule.OtherUserID] = make(map[string]bool)
+			}
+			set.forbidden[rule.OtherUserID][rule.UserID] = true
+		case entity.ReviewRuleAlwaysPair:
+			set.partners[rule.UserID] = append(set.partners[rule.UserID], rule.OtherUserID)
+			set.partners[rule.OtherUserID] = append(set.partners[rule.OtherUserID], rule.UserID)
+		}
+	}
+
+	return set
+}
+
+// allows проверяет, что правила не запрещают reviewerID ревьюить PR автора authorID
+func (s *reviewRuleSet) allows(authorID, reviewerID string) bool {
+	if s == nil {
+		return true
+	}
+	return !s.forbidden[authorID][reviewerID]
+}
+
+// partnersOf возвращает обязательных ревьюверов автора по правилам ALWAYS_PAIR
+func (s *reviewRuleSet) partnersOf(authorID string) []string {
+	if s == nil {
+		return nil
+	}
+	return s.partners[authorID]
+}
+
+// isPartner сообщает, что reviewerID — обязательная пара автора
+func (s *reviewRuleSet) isPartner(authorID, reviewerID string) bool {
+	for _, partnerID := range s.partnersOf(authorID) {
+		if partnerID == reviewerID {
+			return true
+		}
+	}
+	return false
+}
+
+// rulesFor возвращает правила, влияющие на выбор ревьюверов для PR перечисленных авторов
+func (s *reviewRuleSet) rulesFor(authorIDs ...string) []*entity.ReviewRule {
+	result := make([]*entity.ReviewRule, 0)
+	if s == nil {
+		return result
+	}
+
+	authors := make(map[string]bool, len(authorIDs))
+	for _, id := range authorIDs {
+		authors[id] = true
+	}
+
+	for _, rule := range s.rules {
+		switch rule.Type {
+		case entity.ReviewRuleNeverReviews:
+			if authors[rule.OtherUserID] {
+				result = append(result, rule)
+			}
+		case entity.ReviewRuleAlwaysPair:
+			if authors[rule.UserID] || authors[rule.OtherUserID] {
+				result = append(result, rule)
+			}
+		}
+	}
+
+	return result
+}
+
+// filterCandidates оставляет пользователей, которые могут быть назначены ревьюверами PR:
+// исключает ботов, автора, уже назначенных ревьюверов и запрещённых правилами
+func filterCandidates(users []*entity.User, authorID string, assigned []string, rules *reviewRuleSet) []*entity.User {
+	var candidates []*entity.User
+	for _, user := range users {
+		if user.IsBot() || user.UserID == authorID || !rules.allows(authorID, user.UserID) {
+			continue
+		}
+
+		alreadyAssigned := false
+		for _, reviewerID := range assigned {
+			if user.UserID == reviewerID {
+				alreadyAssigned = true
+				break
+			}
+		}
+
+		if !alreadyAssigned {
+			candidates = append(candidates, user)
+		}
+	}
+
+	return candidates
+}
+
+// canTakeReview проверяет, можно ли назначить пользователя ревьювером PR
+func canTakeReview(pr *entity.PullRequest, userID string, rules *reviewRuleSet) bool {
+	if pr.AuthorID == userID || !rules.allows(pr.AuthorID, userID) {
+		return false
+	}
+	for _, reviewerID := range pr.AssignedReviewers {
+		if reviewerID == userID {
+			return false
+		}
+	}
+	return true
+}
+
+// replaceReviewer заменяет ревьювера PR, сохраняя позицию в списке
+func replaceReviewer(pr *entity.PullRequest, oldUserID, newUserID string) {
+	for i, reviewerID := range pr.AssignedReviewers {
+		if reviewerID == oldUserID {
+			pr.AssignedReviewers[i] = newUserID
+			return
+		}
+	}
+}
+
+// pairedCandidates возвращает активных обязательных пар автора, которых можно назначить ревьюверами.
+// Пара может состоять в другой команде.
+func pairedCandidates(
+	ctx context.Context,
+	userRepo repository.UserRepository,
+	rules *reviewRuleSet,
+	authorID string,
+	assigned []string,
+) ([]*entity.User, error) {
+	var partners []*entity.User
+	for _, partnerID := range rules.partnersOf(authorID) {
+		user, err := userRepo.GetByID(ctx, partnerID)
+		if err != nil {
+			if errors.Is(err, domainErrors.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get paired reviewer %s: %w", partnerID, err)
+		}
+
+		if !user.IsActive {
+			continue
+		}
+
+		excluded := append([]string{}, assigned...)
+		for _, p := range partners {
+			excluded = append(excluded, p.UserID)
+		}
+		partners = append(partners, filterCandidates([]*entity.User{user}, authorID, excluded, rules)...)
+	}
+
+	return partners, nil
+}
+
+// teamAncestorNames возвращает имена предков команды, начиная с родителя
+func teamAncestorNames(ctx context.Context, teamRepo repository.TeamRepository, teamName string) ([]string, error) {
+	if teamName == "" {
+		return nil, nil
+	}
+
+	ancestors, err := teamRepo.GetAncestors(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team ancestors: %w", err)
+	}
+
+	names := make([]string, 0, len(ancestors))
+	for _, ancestor := range ancestors {
+		names = append(names, ancestor.TeamName)
+	}
+
+	return names, nil
+}
+
+// pickReplacement выбирает замену ревьювера PR: обязательную пару автора, иначе случайного кандидата с учётом веса
+// из команды teamName, затем из команд-предков, иначе лида или контакт эскалации.
+// Возвращает nil, если заменить некем; escalated отмечает выбор последней надежды.
+func pickReplacement(
+	ctx context.Context,
+	teamRepo repository.TeamRepository,
+	userRepo repository.UserRepository,
+	membershipRepo repository.MembershipRepository,
+	escalationRepo repository.EscalationRepository,
+	rules *reviewRuleSet,
+	teamName string,
+	pr *entity.PullRequest,
+) (*entity.User, bool, error) {
+	partners, err := pairedCandidates(ctx, userRepo, rules, pr.AuthorID, pr.AssignedReviewers)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(partners) > 0 {
+		return partners[0], false, nil
+	}
+
+	ancestors, err := teamAncestorNames(ctx, teamRepo, teamName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Сначала команда заменяемого ревьювера, затем общий резерв предков
+	for _, poolTeam := range append([]string{teamName}, ancestors...) {
+		users, err := userRepo.GetActiveByTeam(ctx, poolTeam)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get team members: %w", err)
+		}
+
+		// Фильтруем кандидатов (исключаем ботов, автора, уже назначенных ревьюверов и запрещённых правилами)
+		candidates := filterCandidates(users, pr.AuthorID, pr.AssignedReviewers, rules)
+		if len(candidates) > 0 {
+			weights, err := loadReviewWeights(ctx, membershipRepo, poolTeam)
+			if err != nil {
+				return nil, false, err
+			}
+
+			// Выбираем случайного кандидата с вероятностью, пропорциональной весу
+			weightedShuffle(candidates, weights)
+			return candidates[0], false, nil
+		}
+	}
+
+	// Кандидатов нет — пробуем лида или контакт эскалации
+	lead, err := pickEscalationReviewer(ctx, escalationRepo, userRepo, rules, append([]string{teamName}, ancestors...), pr)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return lead, lead != nil, nil
+}
+
+// pickEscalationReviewer выбирает ревьювера последней надежды: первого доступного лида,
+// затем первого доступного контакта эскалации. Контакты берутся у первой команды из teamNames,
+// где они настроены, — так дочерние команды наследуют контакты предков.
+// Возвращает nil, если никто не подходит.
+func pickEscalationReviewer(
+	ctx context.Context,
+	escalationRepo repository.EscalationRepository,
+	userRepo repository.UserRepository,
+	rules *reviewRuleSet,
+	teamNames []string,
+	pr *entity.PullRequest,
+) (*entity.User, error) {
+	var contacts []*entity.EscalationContact
+	for _, teamName := range teamNames {
+		teamContacts, err := escalationRepo.GetByTeam(ctx, teamName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get escalation contacts: %w", err)
+		}
+		if len(teamContacts) > 0 {
+			contacts = teamContacts
+			break
+		}
+	}
+
+	for _, contact := range contacts {
+		if !canTakeReview(pr, contact.UserID, rules) {
+			continue
+		}
+
+		user, err := userRepo.GetByID(ctx, contact.UserID)
+		if err != nil {
+			if errors.Is(err, domainErrors.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get escalation contact %s: %w", contact.UserID, err)
+		}
+
+		if user.IsActive && !user.IsBot() {
+			return user, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// defaultReviewWeight вес участника команды, для которого вес не задан
+const defaultReviewWeight = 1.0
+
+// loadReviewWeights возвращает заданные веса ревью участников команды
+func loadReviewWeights(ctx context.Context, membershipRepo repository.MembershipRepository, teamName string) (map[string]float64, error) {
+	memberships, err := membershipRepo.GetByTeam(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team memberships: %w", err)
+	}
+
+	weights := make(map[string]float64, len(memberships))
+	for _, membership := range memberships {
+		if membership.ReviewWeight != nil {
+			weights[membership.UserID] = *membership.ReviewWeight
+		}
+	}
+
+	return weights, nil
+}
+
+// reviewWeight возвращает вес пользователя, по умолчанию defaultReviewWeight
+func reviewWeight(weights map[string]float64, userID string) float64 {
+	if weight, ok := weights[userID]; ok && weight > 0 {
+		return weight
+	}
+	return defaultReviewWeight
+}
+
+// weightedShuffle случайно упорядочивает кандидатов так, что вероятность оказаться впереди
+// пропорциональна весу (ключ u^(1/w), метод Эфраимидиса — Спиракиса)
+func weightedShuffle(candidates []*entity.User, weights map[string]float64) {
+	keys := make(map[string]float64, len(candidates))
+	for _, candidate := range candidates {
+		keys[candidate.UserID] = math.Pow(rand.Float64(), 1/reviewWeight(weights, candidate.UserID))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return keys[candidates[i].UserID] > keys[candidates[j].UserID]
+	})
+}
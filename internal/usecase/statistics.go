@@ -5,18 +5,21 @@ import (
 	"fmt"
 
 	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
 	"github.com/StepanK17/pr-reviewer-service/internal/repository"
 )
 
 // StatisticsUseCase реализует бизнес-логику для статистики
 type StatisticsUseCase struct {
 	statsRepo repository.StatisticsRepository
+	teamRepo  repository.TeamRepository
 }
 
 // NewStatisticsUseCase создает новый usecase для статистики
-func NewStatisticsUseCase(statsRepo repository.StatisticsRepository) *StatisticsUseCase {
+func NewStatisticsUseCase(statsRepo repository.StatisticsRepository, teamRepo repository.TeamRepository) *StatisticsUseCase {
 	return &StatisticsUseCase{
 		statsRepo: statsRepo,
+		teamRepo:  teamRepo,
 	}
 }
 
@@ -29,3 +32,32 @@ func (uc *StatisticsUseCase) GetStatistics(ctx context.Context) (*entity.Statist
 
 	return stats, nil
 }
+
+// GetTeamStatistics возвращает сводную статистику по команде и всем её потомкам
+func (uc *StatisticsUseCase) GetTeamStatistics(ctx context.Context, teamName string) (*entity.TeamStatistics, error) {
+	subtree, err := uc.teamRepo.GetSubtree(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team subtree: %w", err)
+	}
+
+	if len(subtree) == 0 {
+		return nil, domainErrors.NewDomainError(
+			"NOT_FOUND",
+			"team not found",
+			domainErrors.ErrNotFound,
+		)
+	}
+
+	teamNames := make([]string, 0, len(subtree))
+	for _, team := range subtree {
+		teamNames = append(teamNames, team.TeamName)
+	}
+
+	stats, err := uc.statsRepo.GetTeamStatistics(ctx, teamNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team statistics: %w", err)
+	}
+
+	stats.TeamName = teamName
+	return stats, nil
+}
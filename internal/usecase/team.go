@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -13,10 +12,18 @@ import (
 
 // TeamUseCase реализует бизнес-логику для команд
 type TeamUseCase struct {
-	teamRepo  repository.TeamRepository
-	userRepo  repository.UserRepository
-	txManager repository.TransactionManager
-	prRepo    repository.PullRequestRepository
+	teamRepo        repository.TeamRepository
+	userRepo        repository.UserRepository
+	txManager       repository.TransactionManager
+	prRepo          repository.PullRequestRepository
+	sizePolicyRepo  repository.SizePolicyRepository
+	escalationRepo  repository.EscalationRepository
+	historyRepo     repository.PRHistoryRepository
+	ruleRepo        repository.ReviewRuleRepository
+	membershipRepo  repository.MembershipRepository
+	prLinkRepo      repository.VCSPullRequestRepository
+	reviewerReqRepo repository.VCSReviewerRequestRepository
+	events          EventPublisher
 }
 
 // NewTeamUseCase создает новый usecase для команд
@@ -25,12 +32,28 @@ func NewTeamUseCase(
 	userRepo repository.UserRepository,
 	txManager repository.TransactionManager,
 	prRepo repository.PullRequestRepository,
+	sizePolicyRepo repository.SizePolicyRepository,
+	escalationRepo repository.EscalationRepository,
+	historyRepo repository.PRHistoryRepository,
+	ruleRepo repository.ReviewRuleRepository,
+	membershipRepo repository.MembershipRepository,
+	prLinkRepo repository.VCSPullRequestRepository,
+	reviewerReqRepo repository.VCSReviewerRequestRepository,
+	events EventPublisher,
 ) *TeamUseCase {
 	return &TeamUseCase{
-		teamRepo:  teamRepo,
-		userRepo:  userRepo,
-		txManager: txManager,
-		prRepo:    prRepo,
+		teamRepo:        teamRepo,
+		userRepo:        userRepo,
+		txManager:       txManager,
+		prRepo:          prRepo,
+		sizePolicyRepo:  sizePolicyRepo,
+		escalationRepo:  escalationRepo,
+		historyRepo:     historyRepo,
+		ruleRepo:        ruleRepo,
+		membershipRepo:  membershipRepo,
+		prLinkRepo:      prLinkRepo,
+		reviewerReqRepo: reviewerReqRepo,
+		events:          events,
 	}
 }
 
@@ -53,36 +76,39 @@ func (uc *TeamUseCase) CreateTeam(ctx context.Context, teamWithMembers *entity.T
 			)
 		}
 
+		// Родительская команда должна существовать
+		if teamWithMembers.ParentTeamName != "" {
+			if err := uc.ensureTeamExists(ctx, teamWithMembers.ParentTeamName); err != nil {
+				return err
+			}
+		}
+
 		// Создаем команду
 		team := &entity.Team{
-			TeamName:  teamWithMembers.TeamName,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+			TeamName:       teamWithMembers.TeamName,
+			ParentTeamName: teamWithMembers.ParentTeamName,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
 		}
 
 		if err := uc.teamRepo.Create(ctx, team); err != nil {
 			return fmt.Errorf("failed to create team: %w", err)
 		}
 
-		// Создаем/обновляем пользователей
-		now := time.Now()
-		users := make([]*entity.User, 0, len(teamWithMembers.Members))
-		for _, member := range teamWithMembers.Members {
-			user := &entity.User{
-				UserID:    member.UserID,
-				Username:  member.Username,
-				TeamName:  teamWithMembers.TeamName,
-				IsActive:  member.IsActive,
-				CreatedAt: now,
-				UpdatedAt: now,
-			}
-			users = append(users, user)
+		// Создаем/обновляем пользователей; участники других команд переводятся явно
+		users, err := uc.membersToUsers(ctx, teamWithMembers.TeamName, teamWithMembers.Members)
+		if err != nil {
+			return err
 		}
 
 		if err := uc.userRepo.UpsertBatch(ctx, users); err != nil {
 			return fmt.Errorf("failed to upsert users: %w", err)
 		}
 
+		if err := uc.saveMemberRoles(ctx, teamWithMembers.TeamName, teamWithMembers.Members); err != nil {
+			return err
+		}
+
 		result = teamWithMembers
 		return nil
 	})
@@ -94,19 +120,31 @@ func (uc *TeamUseCase) CreateTeam(ctx context.Context, teamWithMembers *entity.T
 	return result, nil
 }
 
+// ListTeams возвращает все команды с участниками
+func (uc *TeamUseCase) ListTeams(ctx context.Context) ([]*entity.TeamWithMembers, error) {
+	teams, err := uc.teamRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	result := make([]*entity.TeamWithMembers, 0, len(teams))
+	for _, team := range teams {
+		withMembers, err := uc.GetTeamWithMembers(ctx, team.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, withMembers)
+	}
+
+	return result, nil
+}
+
 // GetTeamWithMembers возвращает команду со списком участников
 func (uc *TeamUseCase) GetTeamWithMembers(ctx context.Context, teamName string) (*entity.TeamWithMembers, error) {
 	// Проверяем существование команды
-	_, err := uc.teamRepo.GetByName(ctx, teamName)
+	team, err := uc.getTeam(ctx, teamName)
 	if err != nil {
-		if errors.Is(err, domainErrors.ErrNotFound) {
-			return nil, domainErrors.NewDomainError(
-				"NOT_FOUND",
-				"team not found",
-				domainErrors.ErrNotFound,
-			)
-		}
-		return nil, fmt.Errorf("failed to get team: %w", err)
+		return nil, err
 	}
 
 	// Получаем всех пользователей команды
@@ -115,18 +153,62 @@ func (uc *TeamUseCase) GetTeamWithMembers(ctx context.Context, teamName string)
 		return nil, fmt.Errorf("failed to get team members: %w", err)
 	}
 
+	ancestors, err := uc.teamRepo.GetAncestors(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team ancestors: %w", err)
+	}
+
+	// Предки от корня к родителю
+	ancestorNames := make([]string, 0, len(ancestors))
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		ancestorNames = append(ancestorNames, ancestors[i].TeamName)
+	}
+
+	children, err := uc.teamRepo.GetChildren(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get child teams: %w", err)
+	}
+
+	childNames := make([]string, 0, len(children))
+	for _, child := range children {
+		childNames = append(childNames, child.TeamName)
+	}
+
+	memberships, err := uc.membershipRepo.GetByTeam(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team memberships: %w", err)
+	}
+
+	membershipByUser := make(map[string]*entity.TeamMembership, len(memberships))
+	for _, membership := range memberships {
+		membershipByUser[membership.UserID] = membership
+	}
+
 	// Преобразуем в TeamMembers
 	members := make([]entity.TeamMember, 0, len(users))
 	for _, user := range users {
-		members = append(members, entity.TeamMember{
-			UserID:   user.UserID,
-			Username: user.Username,
-			IsActive: user.IsActive,
-		})
+		member := entity.TeamMember{
+			UserID:      user.UserID,
+			Username:    user.Username,
+			IsActive:    user.IsActive,
+			Kind:        user.Kind,
+			UserProfile: user.UserProfile,
+			Role:        entity.TeamRoleMember,
+			Primary:     user.TeamName == teamName,
+		}
+		if membership, ok := membershipByUser[user.UserID]; ok {
+			member.Role = membership.Role
+			member.ReviewWeight = membership.ReviewWeight
+		}
+		members = append(members, member)
 	}
 
 	return &entity.TeamWithMembers{
-		TeamName: teamName,
-		Members:  members,
+		TeamName:       teamName,
+		ParentTeamName: team.ParentTeamName,
+		Ancestors:      ancestorNames,
+		Children:       childNames,
+		Archived:       team.IsArchived(),
+		Members:        members,
 	}, nil
 }
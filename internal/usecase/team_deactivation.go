@@ -4,162 +4,508 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
 	"time"
 
 	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
 	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
 )
 
-// DeactivateTeamMembersResult результат массовой деактивации
-type DeactivateTeamMembersResult struct {
+// MemberActivationAction что произошло с пользователем при изменении активности
+type MemberActivationAction string
+
+const (
+	MemberDeactivated MemberActivationAction = "DEACTIVATED"
+	MemberReactivated MemberActivationAction = "REACTIVATED"
+	// MemberUnchanged пользователь уже был в нужном состоянии
+	MemberUnchanged MemberActivationAction = "UNCHANGED"
+	// MemberExcluded пользователь исключён из операции над командой
+	MemberExcluded MemberActivationAction = "EXCLUDED"
+)
+
+// MemberActivationChange изменение активности одного пользователя
+type MemberActivationChange struct {
+	UserID string
+	Action MemberActivationAction
+}
+
+// ActivationChangeResult результат деактивации или реактивации пользователей
+type ActivationChangeResult struct {
 	DeactivatedCount int
+	ReactivatedCount int
 	ReassignedPRs    int
+	EscalatedPRs     int
+	UnassignedPRs    int
+	FailedPRs        int
+	DryRun           bool
 	UserIDs          []string
+	// UnreviewedPRs открытые PR, у которых не осталось ни одного ревьювера
+	UnreviewedPRs []string
+	Users         []MemberActivationChange
+	HandOffs      []ReviewHandOff
+}
+
+// reassignOutcome итог переназначения деактивированного ревьювера на одном PR
+type reassignOutcome struct {
+	NewReviewerID string
+	Escalated     bool
+	Removed       bool
+	// Skipped ревьювер уже не назначен на PR, ревью не менялось
+	Skipped bool
+}
+
+// handOff описывает итог переназначения как изменение ревью PR; nil, если PR пропущен
+func (o *reassignOutcome) handOff(prID, oldUserID string) *ReviewHandOff {
+	if o.Skipped {
+		return nil
+	}
+
+	handOff := &ReviewHandOff{PullRequestID: prID, OldReviewerID: oldUserID}
+	if o.Removed {
+		handOff.Action = ReviewHandOffUnassigned
+	} else {
+		handOff.Action = ReviewHandOffReassigned
+		handOff.NewReviewerID = o.NewReviewerID
+		handOff.Escalated = o.Escalated
+	}
+	return handOff
 }
 
-// DeactivateTeamMembers массово деактивирует пользователей команды и переназначает их PR
-func (uc *TeamUseCase) DeactivateTeamMembers(ctx context.Context, teamName string) (*DeactivateTeamMembersResult, error) {
-	var result DeactivateTeamMembersResult
+// errDryRunRollback откатывает транзакцию пробного запуска
+var errDryRunRollback = errors.New("dry run rollback")
+
+// DeactivateTeamMembers массово деактивирует пользователей команды, кроме excludeUserIDs, и переназначает их PR.
+// PR, ревью которых передать не удалось, попадают в отчёт как FAILED; в режиме strict такая ошибка откатывает всю операцию.
+// В режиме dryRun операция выполняется целиком и откатывается, возвращая план.
+func (uc *TeamUseCase) DeactivateTeamMembers(
+	ctx context.Context,
+	teamName string,
+	excludeUserIDs []string,
+	strict, dryRun bool,
+) (*ActivationChangeResult, error) {
+	return uc.changeTeamActivation(ctx, teamName, excludeUserIDs, false, strict, dryRun)
+}
+
+// ReactivateTeamMembers возвращает в работу неактивных пользователей команды, кроме excludeUserIDs.
+// Реактивация не меняет назначения, поэтому strict ни на что не влияет.
+func (uc *TeamUseCase) ReactivateTeamMembers(
+	ctx context.Context,
+	teamName string,
+	excludeUserIDs []string,
+	strict, dryRun bool,
+) (*ActivationChangeResult, error) {
+	return uc.changeTeamActivation(ctx, teamName, excludeUserIDs, true, strict, dryRun)
+}
+
+// DeactivateUsers деактивирует перечисленных пользователей и переназначает их PR в их основных командах
+func (uc *TeamUseCase) DeactivateUsers(ctx context.Context, userIDs []string, strict, dryRun bool) (*ActivationChangeResult, error) {
+	return uc.changeUsersActivation(ctx, userIDs, false, strict, dryRun)
+}
+
+// ReactivateUsers возвращает в работу перечисленных пользователей
+func (uc *TeamUseCase) ReactivateUsers(ctx context.Context, userIDs []string, strict, dryRun bool) (*ActivationChangeResult, error) {
+	return uc.changeUsersActivation(ctx, userIDs, true, strict, dryRun)
+}
+
+// runActivation выполняет изменение активности в транзакции.
+// При dryRun транзакция всегда откатывается, а результат возвращается как план.
+func (uc *TeamUseCase) runActivation(
+	ctx context.Context,
+	dryRun bool,
+	fn func(ctx context.Context) (*ActivationChangeResult, error),
+) (*ActivationChangeResult, error) {
+	var result *ActivationChangeResult
 
 	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
-		// 1. Проверяем существование команды
-		_, err := uc.teamRepo.GetByName(ctx, teamName)
+		var err error
+		result, err = fn(ctx)
 		if err != nil {
-			if errors.Is(err, domainErrors.ErrNotFound) {
-				return domainErrors.NewDomainError(
-					"NOT_FOUND",
-					"team not found",
-					domainErrors.ErrNotFound,
-				)
-			}
-			return fmt.Errorf("failed to get team: %w", err)
+			return err
+		}
+
+		if dryRun {
+			result.DryRun = true
+			return errDryRunRollback
+		}
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errDryRunRollback) {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// changeTeamActivation меняет активность участников команды в одной транзакции.
+// Ревью деактивированных передаются участникам этой команды.
+func (uc *TeamUseCase) changeTeamActivation(
+	ctx context.Context,
+	teamName string,
+	excludeUserIDs []string,
+	active, strict, dryRun bool,
+) (*ActivationChangeResult, error) {
+	return uc.runActivation(ctx, dryRun, func(ctx context.Context) (*ActivationChangeResult, error) {
+		// Проверяем существование команды и что она не в архиве
+		if err := uc.ensureTeamWritable(ctx, teamName); err != nil {
+			return nil, err
 		}
 
-		// 2. Получаем всех пользователей команды
 		users, err := uc.userRepo.GetByTeam(ctx, teamName)
 		if err != nil {
-			return fmt.Errorf("failed to get team members: %w", err)
+			return nil, fmt.Errorf("failed to get team members: %w", err)
 		}
 
-		// 3. Деактивируем активных пользователей
-		deactivatedUserIDs := make([]string, 0)
-		now := time.Now()
-
+		members := make(map[string]bool, len(users))
 		for _, user := range users {
-			if user.IsActive {
-				user.IsActive = false
-				user.UpdatedAt = now
-				if err := uc.userRepo.Update(ctx, user); err != nil {
-					return fmt.Errorf("failed to deactivate user %s: %w", user.UserID, err)
-				}
-				deactivatedUserIDs = append(deactivatedUserIDs, user.UserID)
+			members[user.UserID] = true
+		}
+
+		excluded := make(map[string]bool, len(excludeUserIDs))
+		for _, userID := range excludeUserIDs {
+			if !members[userID] {
+				return nil, invalidInput("user " + userID + " is not a member of team " + teamName)
+			}
+			excluded[userID] = true
+		}
+
+		result, err := uc.applyActivation(ctx, users, excluded, active, strict, handOffTarget{
+			teamFor: func(*entity.User) string { return teamName },
+			reason:  "team deactivation",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if !active && result.DeactivatedCount > 0 {
+			if err := publishEvent(ctx, uc.events, entity.EventTeamDeactivated, teamName, "", map[string]interface{}{
+				"team_name":      teamName,
+				"user_ids":       result.UserIDs,
+				"reassigned_prs": result.ReassignedPRs,
+				"unassigned_prs": result.UnassignedPRs,
+				"failed_prs":     result.FailedPRs,
+				"unreviewed_prs": result.UnreviewedPRs,
+			}); err != nil {
+				return nil, err
 			}
 		}
 
-		result.DeactivatedCount = len(deactivatedUserIDs)
-		result.UserIDs = deactivatedUserIDs
+		return result, nil
+	})
+}
+
+// changeUsersActivation меняет активность перечисленных пользователей в одной транзакции.
+// Ревью деактивированных передаются участникам их основных команд.
+func (uc *TeamUseCase) changeUsersActivation(ctx context.Context, userIDs []string, active, strict, dryRun bool) (*ActivationChangeResult, error) {
+	return uc.runActivation(ctx, dryRun, func(ctx context.Context) (*ActivationChangeResult, error) {
+		users := make([]*entity.User, 0, len(userIDs))
+		seen := make(map[string]bool, len(userIDs))
+		for _, userID := range userIDs {
+			if seen[userID] {
+				continue
+			}
+			seen[userID] = true
 
-		// 4. Находим и переназначаем открытые PR
-		reassignedCount := 0
-		for _, userID := range deactivatedUserIDs {
-			// Получаем PR где пользователь - ревьювер
-			prs, err := uc.prRepo.GetByReviewer(ctx, userID)
+			user, err := uc.userRepo.GetByID(ctx, userID)
 			if err != nil {
-				return fmt.Errorf("failed to get PRs for user %s: %w", userID, err)
+				if errors.Is(err, domainErrors.ErrNotFound) {
+					return nil, domainErrors.NewDomainError(
+						"NOT_FOUND",
+						"user "+userID+" not found",
+						domainErrors.ErrNotFound,
+					)
+				}
+				return nil, fmt.Errorf("failed to get user %s: %w", userID, err)
+			}
+			users = append(users, user)
+		}
+
+		return uc.applyActivation(ctx, users, nil, active, strict, handOffTarget{
+			teamFor: primaryTeam,
+			reason:  "user deactivation",
+		})
+	})
+}
+
+// handOffTarget куда передаются открытые ревью деактивированных пользователей
+type handOffTarget struct {
+	teamFor   func(*entity.User) string
+	successor *entity.User
+	reason    string
+}
+
+// primaryTeam возвращает основную команду пользователя
+func primaryTeam(user *entity.User) string {
+	return user.TeamName
+}
+
+// DeactivateUser деактивирует одного пользователя и передаёт его открытые ревью
+// преемнику successorID (если задан и может взять ревью), иначе участникам его основной команды
+func (uc *TeamUseCase) DeactivateUser(ctx context.Context, userID, successorID string) (*ActivationChangeResult, error) {
+	if successorID == userID {
+		return nil, invalidInput("successor must differ from the deactivated user")
+	}
+
+	return uc.runActivation(ctx, false, func(ctx context.Context) (*ActivationChangeResult, error) {
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, domainErrors.ErrNotFound) {
+				return nil, domainErrors.NewDomainError(
+					"NOT_FOUND",
+					"user not found",
+					domainErrors.ErrNotFound,
+				)
 			}
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
 
-			// Переназначаем только открытые PR
-			for _, prShort := range prs {
-				if prShort.Status == entity.PRStatusOpen {
-					// Получаем полный PR
-					pr, err := uc.prRepo.GetByID(ctx, prShort.PullRequestID)
-					if err != nil {
-						return fmt.Errorf("failed to get PR %s: %w", prShort.PullRequestID, err)
-					}
-
-					// Пытаемся переназначить ревьювера
-					if err := uc.reassignDeactivatedReviewer(ctx, pr, userID); err != nil {
-
-						continue
-					}
-					reassignedCount++
+		target := handOffTarget{teamFor: primaryTeam, reason: "user deactivation"}
+		if successorID != "" {
+			successor, err := uc.userRepo.GetByID(ctx, successorID)
+			if err != nil {
+				if errors.Is(err, domainErrors.ErrNotFound) {
+					return nil, domainErrors.NewDomainError(
+						"NOT_FOUND",
+						"successor not found",
+						domainErrors.ErrNotFound,
+					)
 				}
+				return nil, fmt.Errorf("failed to get successor: %w", err)
+			}
+
+			if !successor.IsActive || successor.IsBot() {
+				return nil, invalidInput("successor must be an active human user")
 			}
+
+			target.successor = successor
+			target.reason = "user deactivation, successor " + successorID
 		}
 
-		result.ReassignedPRs = reassignedCount
-		return nil
+		return uc.applyActivation(ctx, []*entity.User{user}, nil, false, false, target)
 	})
+}
 
-	if err != nil {
-		return nil, err
+// applyActivation переводит пользователей в состояние active, пропуская excluded.
+// Сначала меняются все флаги, чтобы деактивированные не стали заменой друг другу,
+// затем открытые ревью деактивированных передаются согласно target.
+// Каждый PR обрабатывается в своей точке сохранения, чтобы сбой на одном PR не затрагивал остальные.
+func (uc *TeamUseCase) applyActivation(
+	ctx context.Context,
+	users []*entity.User,
+	excluded map[string]bool,
+	active, strict bool,
+	target handOffTarget,
+) (*ActivationChangeResult, error) {
+	result := &ActivationChangeResult{
+		UserIDs:       make([]string, 0),
+		Users:         make([]MemberActivationChange, 0, len(users)),
+		HandOffs:      make([]ReviewHandOff, 0),
+		UnreviewedPRs: make([]string, 0),
+	}
+
+	now := time.Now()
+	changed := make([]*entity.User, 0, len(users))
+	for _, user := range users {
+		change := MemberActivationChange{UserID: user.UserID}
+
+		switch {
+		case excluded[user.UserID]:
+			change.Action = MemberExcluded
+		case user.IsActive == active:
+			change.Action = MemberUnchanged
+		default:
+			user.IsActive = active
+			user.UpdatedAt = now
+			if err := uc.userRepo.Update(ctx, user); err != nil {
+				return nil, fmt.Errorf("failed to update user %s: %w", user.UserID, err)
+			}
+
+			if active {
+				change.Action = MemberReactivated
+				result.ReactivatedCount++
+			} else {
+				change.Action = MemberDeactivated
+				result.DeactivatedCount++
+			}
+			result.UserIDs = append(result.UserIDs, user.UserID)
+			changed = append(changed, user)
+		}
+
+		result.Users = append(result.Users, change)
+	}
+
+	// Реактивация не затрагивает назначения
+	if active {
+		return result, nil
+	}
+
+	// Находим и переназначаем открытые PR
+	for _, user := range changed {
+		prs, err := uc.prRepo.GetByReviewer(ctx, user.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PRs for user %s: %w", user.UserID, err)
+		}
+
+		for _, prShort := range prs {
+			if prShort.Status != entity.PRStatusOpen {
+				continue
+			}
+
+			// Пытаемся переназначить ревьювера
+			var handOff *ReviewHandOff
+			err := uc.txManager.RunInSavepoint(ctx, func(ctx context.Context) error {
+				pr, err := uc.prRepo.GetByID(ctx, prShort.PullRequestID)
+				if err != nil {
+					return fmt.Errorf("failed to get PR %s: %w", prShort.PullRequestID, err)
+				}
+
+				outcome, err := uc.handOffReview(ctx, pr, user.UserID, target.teamFor(user), target.successor, target.reason)
+				if err != nil {
+					return err
+				}
+
+				handOff = outcome.handOff(pr.PullRequestID, user.UserID)
+				if outcome.Removed && len(pr.AssignedReviewers) == 0 {
+					result.UnreviewedPRs = append(result.UnreviewedPRs, pr.PullRequestID)
+				}
+				return nil
+			})
+
+			if err != nil {
+				if strict {
+					return nil, err
+				}
+				handOff = &ReviewHandOff{
+					PullRequestID: prShort.PullRequestID,
+					OldReviewerID: user.UserID,
+					Action:        ReviewHandOffFailed,
+					ErrorCode:     errorCode(err),
+				}
+			}
+
+			// Ревьювер уже снят с PR, в отчёт он не попадает
+			if handOff == nil {
+				continue
+			}
+
+			switch handOff.Action {
+			case ReviewHandOffUnassigned:
+				result.UnassignedPRs++
+			case ReviewHandOffReassigned:
+				result.ReassignedPRs++
+				if handOff.Escalated {
+					result.EscalatedPRs++
+				}
+			case ReviewHandOffFailed:
+				result.FailedPRs++
+			}
+			result.HandOffs = append(result.HandOffs, *handOff)
+		}
 	}
 
-	return &result, nil
+	return result, nil
 }
 
-// reassignDeactivatedReviewer переназначает деактивированного ревьювера
-func (uc *TeamUseCase) reassignDeactivatedReviewer(ctx context.Context, pr *entity.PullRequest, deactivatedUserID string) error {
-	// Находим индекс деактивированного пользователя
+// handOffReview передаёт ревью PR от ревьювера oldUserID преемнику successor, если он может его взять,
+// иначе другому участнику команды teamName. Если в команде и её предках нет кандидатов,
+// назначается лид или контакт эскалации, иначе ревьювер снимается.
+func (uc *TeamUseCase) handOffReview(
+	ctx context.Context,
+	pr *entity.PullRequest,
+	oldUserID, teamName string,
+	successor *entity.User,
+	reason string,
+) (*reassignOutcome, error) {
+	outcome := &reassignOutcome{}
+
+	// Находим индекс заменяемого ревьювера
 	oldUserIndex := -1
 	for i, reviewerID := range pr.AssignedReviewers {
-		if reviewerID == deactivatedUserID {
+		if reviewerID == oldUserID {
 			oldUserIndex = i
 			break
 		}
 	}
 
 	if oldUserIndex == -1 {
-		return nil // Пользователь не назначен на этот PR
+		outcome.Skipped = true // Пользователь не назначен на этот PR
+		return outcome, nil
 	}
 
-	// Получаем команду деактивированного пользователя
-	oldReviewer, err := uc.userRepo.GetByID(ctx, deactivatedUserID)
+	rules, err := loadReviewRules(ctx, uc.ruleRepo, pr.AuthorID)
 	if err != nil {
-		return fmt.Errorf("failed to get old reviewer: %w", err)
-	}
-
-	// Получаем активных пользователей команды
-	candidates, err := uc.userRepo.GetActiveByTeam(ctx, oldReviewer.TeamName)
-	if err != nil {
-		return fmt.Errorf("failed to get team members: %w", err)
+		return nil, err
 	}
 
-	// Фильтруем кандидатов
-	var availableCandidates []*entity.User
-	for _, candidate := range candidates {
-		if candidate.UserID == pr.AuthorID {
-			continue
-		}
-
-		alreadyAssigned := false
-		for _, reviewerID := range pr.AssignedReviewers {
-			if candidate.UserID == reviewerID {
-				alreadyAssigned = true
-				break
-			}
+	// Выбираем замену: преемник, пара автора, кандидат из команды или лид
+	var newReviewer *entity.User
+	escalated := false
+	if successor != nil && canTakeReview(pr, successor.UserID, rules) {
+		newReviewer = successor
+	} else {
+		newReviewer, escalated, err = pickReplacement(ctx, uc.teamRepo, uc.userRepo, uc.membershipRepo, uc.escalationRepo, rules, teamName, pr)
+		if err != nil {
+			return nil, err
 		}
+	}
+	outcome.Escalated = escalated
 
-		if !alreadyAssigned {
-			availableCandidates = append(availableCandidates, candidate)
-		}
+	history := &entity.PRHistoryEntry{
+		PullRequestID: pr.PullRequestID,
+		OldReviewerID: oldUserID,
+		Escalated:     outcome.Escalated,
+		Reason:        reason,
+		CreatedAt:     time.Now(),
 	}
 
-	// Если нет кандидатов, просто убираем деактивированного
-	if len(availableCandidates) == 0 {
-		// Удаляем ревьювера из списка
+	if newReviewer == nil {
+		// Заменить некем, просто снимаем ревьювера
 		pr.AssignedReviewers = append(pr.AssignedReviewers[:oldUserIndex], pr.AssignedReviewers[oldUserIndex+1:]...)
+		outcome.Removed = true
+		history.EventType = entity.PRHistoryReviewerRemoved
 	} else {
-		// Выбираем случайного кандидата
-		newReviewer := availableCandidates[rand.Intn(len(availableCandidates))]
 		pr.AssignedReviewers[oldUserIndex] = newReviewer.UserID
+		outcome.NewReviewerID = newReviewer.UserID
+		history.EventType = entity.PRHistoryReviewerReassigned
+		history.NewReviewerID = newReviewer.UserID
 	}
 
 	// Обновляем PR
 	if err := uc.prRepo.Update(ctx, pr); err != nil {
-		return fmt.Errorf("failed to update PR: %w", err)
+		return nil, fmt.Errorf("failed to update PR: %w", err)
 	}
 
-	return nil
+	if err := uc.historyRepo.Add(ctx, history); err != nil {
+		return nil, fmt.Errorf("failed to write PR history: %w", err)
+	}
+
+	var added []string
+	if !outcome.Removed {
+		added = []string{outcome.NewReviewerID}
+	}
+	if err := enqueueReviewerRequest(ctx, uc.prLinkRepo, uc.reviewerReqRepo, pr.PullRequestID, added, []string{oldUserID}); err != nil {
+		return nil, err
+	}
+
+	if !outcome.Removed {
+		authorTeamName, err := authorTeam(ctx, uc.userRepo, pr.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := publishReassignedEvent(ctx, uc.events, authorTeamName, pr.PullRequestID, oldUserID, outcome.NewReviewerID,
+			reason, outcome.Escalated); err != nil {
+			return nil, err
+		}
+	}
+
+	return outcome, nil
+}
+
+// errorCode возвращает код доменной ошибки или INTERNAL_ERROR
+func errorCode(err error) string {
+	var domainErr *domainErrors.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+	return "INTERNAL_ERROR"
 }
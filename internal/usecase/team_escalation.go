@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+)
+
+// GetEscalationContacts возвращает лидов и контакты эскалации команды
+func (uc *TeamUseCase) GetEscalationContacts(ctx context.Context, teamName string) ([]*entity.EscalationContact, error) {
+	if err := uc.ensureTeamExists(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	contacts, err := uc.escalationRepo.GetByTeam(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get escalation contacts: %w", err)
+	}
+
+	if contacts == nil {
+		contacts = []*entity.EscalationContact{}
+	}
+
+	return contacts, nil
+}
+
+// SetEscalationContacts заменяет лидов и контакты эскалации команды.
+// Порядок в списках задаёт приоритет при выборе ревьювера последней надежды.
+func (uc *TeamUseCase) SetEscalationContacts(
+	ctx context.Context,
+	teamName string,
+	leadIDs, escalationIDs []string,
+) ([]*entity.EscalationContact, error) {
+	contacts := make([]*entity.EscalationContact, 0, len(leadIDs)+len(escalationIDs))
+	seen := make(map[string]bool)
+
+	appendContacts := func(userIDs []string, role entity.EscalationRole) error {
+		for i, userID := range userIDs {
+			if userID == "" {
+				return invalidInput("user_id must not be empty")
+			}
+			if seen[userID] {
+				return invalidInput("duplicate escalation contact " + userID)
+			}
+			seen[userID] = true
+
+			contacts = append(contacts, &entity.EscalationContact{
+				TeamName: teamName,
+				UserID:   userID,
+				Role:     role,
+				Position: i,
+			})
+		}
+		return nil
+	}
+
+	if err := appendContacts(leadIDs, entity.EscalationRoleLead); err != nil {
+		return nil, err
+	}
+	if err := appendContacts(escalationIDs, entity.EscalationRoleEscalation); err != nil {
+		return nil, err
+	}
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.ensureTeamWritable(ctx, teamName); err != nil {
+			return err
+		}
+
+		// Контакты должны быть существующими людьми
+		for _, contact := range contacts {
+			user, err := uc.userRepo.GetByID(ctx, contact.UserID)
+			if err != nil {
+				if errors.Is(err, domainErrors.ErrNotFound) {
+					return domainErrors.NewDomainError(
+						"NOT_FOUND",
+						"user "+contact.UserID+" not found",
+						domainErrors.ErrNotFound,
+					)
+				}
+				return fmt.Errorf("failed to get user: %w", err)
+			}
+
+			if user.IsBot() {
+				return invalidInput("bot " + contact.UserID + " cannot be an escalation contact")
+			}
+		}
+
+		if err := uc.escalationRepo.ReplaceForTeam(ctx, teamName, contacts); err != nil {
+			return fmt.Errorf("failed to replace escalation contacts: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
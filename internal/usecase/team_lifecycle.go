@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+)
+
+// DeleteTeamResult результат удаления команды
+type DeleteTeamResult struct {
+	TeamName      string
+	DetachedUsers int
+	OpenPRs       int
+}
+
+// RenameTeam переименовывает команду вместе со ссылками на неё в одной транзакции
+func (uc *TeamUseCase) RenameTeam(ctx context.Context, oldName, newName string) (*entity.TeamWithMembers, error) {
+	if oldName == newName {
+		return nil, invalidInput("new team name must differ from the current one")
+	}
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.getTeam(ctx, oldName); err != nil {
+			return err
+		}
+
+		exists, err := uc.teamRepo.Exists(ctx, newName)
+		if err != nil {
+			return fmt.Errorf("failed to check team existence: %w", err)
+		}
+
+		if exists {
+			return domainErrors.NewDomainError(
+				"TEAM_EXISTS",
+				"team_name already exists",
+				domainErrors.ErrTeamExists,
+			)
+		}
+
+		if err := uc.teamRepo.Rename(ctx, oldName, newName); err != nil {
+			return fmt.Errorf("failed to rename team: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetTeamWithMembers(ctx, newName)
+}
+
+// SetTeamArchived архивирует команду или возвращает её из архива.
+// Архивная команда доступна только на чтение и не участвует в выборе ревьюверов.
+func (uc *TeamUseCase) SetTeamArchived(ctx context.Context, teamName string, archived bool) (*entity.TeamWithMembers, error) {
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		team, err := uc.getTeam(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		if team.IsArchived() == archived {
+			return nil
+		}
+
+		var archivedAt *time.Time
+		if archived {
+			now := time.Now()
+			archivedAt = &now
+		}
+
+		if err := uc.teamRepo.SetArchivedAt(ctx, teamName, archivedAt); err != nil {
+			return fmt.Errorf("failed to update team: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetTeamWithMembers(ctx, teamName)
+}
+
+// DeleteTeam удаляет команду. Участники, их PR и история сохраняются, но остаются без команды.
+// Пока у авторов команды есть открытые PR, удаление отклоняется, если не задан force.
+func (uc *TeamUseCase) DeleteTeam(ctx context.Context, teamName string, force bool) (*DeleteTeamResult, error) {
+	result := &DeleteTeamResult{TeamName: teamName}
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.getTeam(ctx, teamName); err != nil {
+			return err
+		}
+
+		openPRs, err := uc.prRepo.CountOpenByAuthorTeam(ctx, teamName)
+		if err != nil {
+			return fmt.Errorf("failed to count open PRs: %w", err)
+		}
+
+		if openPRs > 0 && !force {
+			return domainErrors.NewDomainError(
+				"TEAM_HAS_OPEN_PRS",
+				fmt.Sprintf("team has %d open pull requests, use force to delete anyway", openPRs),
+				domainErrors.ErrTeamHasOpenPRs,
+			)
+		}
+
+		users, err := uc.userRepo.GetByTeam(ctx, teamName)
+		if err != nil {
+			return fmt.Errorf("failed to get team members: %w", err)
+		}
+
+		if err := uc.teamRepo.Delete(ctx, teamName); err != nil {
+			return fmt.Errorf("failed to delete team: %w", err)
+		}
+
+		// Участники из дополнительных членств сохраняют основную команду и не открепляются
+		for _, user := range users {
+			if user.TeamName == teamName {
+				result.DetachedUsers++
+			}
+		}
+		result.OpenPRs = openPRs
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SetTeamParent перемещает команду в иерархии; пустой parentTeamName делает её корневой.
+// Команду нельзя сделать потомком самой себя.
+func (uc *TeamUseCase) SetTeamParent(ctx context.Context, teamName, parentTeamName string) (*entity.TeamWithMembers, error) {
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.ensureTeamWritable(ctx, teamName); err != nil {
+			return err
+		}
+
+		if parentTeamName != "" {
+			if err := uc.ensureTeamExists(ctx, parentTeamName); err != nil {
+				return err
+			}
+
+			subtree, err := uc.teamRepo.GetSubtree(ctx, teamName)
+			if err != nil {
+				return fmt.Errorf("failed to get team subtree: %w", err)
+			}
+
+			for _, team := range subtree {
+				if team.TeamName == parentTeamName {
+					return invalidInput("parent team must not be the team itself or its descendant")
+				}
+			}
+		}
+
+		if err := uc.teamRepo.SetParent(ctx, teamName, parentTeamName); err != nil {
+			return fmt.Errorf("failed to set parent team: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetTeamWithMembers(ctx, teamName)
+}
+
+// ensureTeamWritable возвращает NOT_FOUND для несуществующей команды и TEAM_ARCHIVED для архивной
+func (uc *TeamUseCase) ensureTeamWritable(ctx context.Context, teamName string) error {
+	team, err := uc.getTeam(ctx, teamName)
+	if err != nil {
+		return err
+	}
+
+	if team.IsArchived() {
+		return domainErrors.NewDomainError(
+			"TEAM_ARCHIVED",
+			"team "+teamName+" is archived",
+			domainErrors.ErrTeamArchived,
+		)
+	}
+
+	return nil
+}
+
+// getTeam возвращает команду или доменную ошибку NOT_FOUND
+func (uc *TeamUseCase) getTeam(ctx context.Context, teamName string) (*entity.Team, error) {
+	team, err := uc.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.NewDomainError(
+				"NOT_FOUND",
+				"team not found",
+				domainErrors.ErrNotFound,
+			)
+		}
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	return team, nil
+}
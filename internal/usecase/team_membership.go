@@ -0,0 +1,618 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+)
+
+// ReviewHandOffPolicy определяет судьбу открытых ревью при смене команды
+type ReviewHandOffPolicy string
+
+const (
+	// ReviewHandOffKeep оставляет ревью за пользователем
+	ReviewHandOffKeep ReviewHandOffPolicy = "KEEP"
+	// ReviewHandOffReassign передаёт ревью участникам старой команды
+	ReviewHandOffReassign ReviewHandOffPolicy = "REASSIGN"
+)
+
+// ReviewHandOffAction что произошло с ревью одного PR при смене команды
+type ReviewHandOffAction string
+
+const (
+	ReviewHandOffKept       ReviewHandOffAction = "KEPT"
+	ReviewHandOffReassigned ReviewHandOffAction = "REASSIGNED"
+	ReviewHandOffUnassigned ReviewHandOffAction = "UNASSIGNED"
+	// ReviewHandOffFailed ревью передать не удалось, ревьювер остался прежним
+	ReviewHandOffFailed ReviewHandOffAction = "FAILED"
+)
+
+// ReviewHandOff изменение ревью одного PR при смене команды или деактивации ревьювера
+type ReviewHandOff struct {
+	PullRequestID string
+	OldReviewerID string
+	Action        ReviewHandOffAction
+	NewReviewerID string
+	Escalated     bool
+	ErrorCode     string
+}
+
+// MembershipChangeResult результат удаления или перевода участника команды
+type MembershipChangeResult struct {
+	UserID        string
+	FromTeam      string
+	ToTeam        string
+	Policy        ReviewHandOffPolicy
+	ReassignedPRs int
+	EscalatedPRs  int
+	UnassignedPRs int
+	KeptPRs       int
+	HandOffs      []ReviewHandOff
+}
+
+// AddTeamMembers добавляет участников в существующую команду.
+// Пользователи из других команд не добавляются: для них есть перевод.
+func (uc *TeamUseCase) AddTeamMembers(ctx context.Context, teamName string, members []entity.TeamMember) (*entity.TeamWithMembers, error) {
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.ensureTeamWritable(ctx, teamName); err != nil {
+			return err
+		}
+
+		users, err := uc.membersToUsers(ctx, teamName, members)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.userRepo.UpsertBatch(ctx, users); err != nil {
+			return fmt.Errorf("failed to upsert users: %w", err)
+		}
+
+		return uc.saveMemberRoles(ctx, teamName, members)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetTeamWithMembers(ctx, teamName)
+}
+
+// RemoveTeamMember исключает пользователя из команды.
+// Его открытые ревью PR этой команды передаются оставшимся участникам.
+// Если команда была основной, основной становится следующая из его команд.
+func (uc *TeamUseCase) RemoveTeamMember(ctx context.Context, teamName, userID string) (*MembershipChangeResult, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.NewDomainError(
+				"NOT_FOUND",
+				"user not found",
+				domainErrors.ErrNotFound,
+			)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.TeamName != teamName {
+		return uc.removeSecondaryMembership(ctx, teamName, userID)
+	}
+
+	memberships, err := uc.membershipRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user memberships: %w", err)
+	}
+
+	nextTeam := ""
+	for _, membership := range memberships {
+		if membership.TeamName != teamName {
+			nextTeam = membership.TeamName
+			break
+		}
+	}
+
+	return uc.changeMembership(ctx, userID, teamName, nextTeam, ReviewHandOffReassign, "team member removal")
+}
+
+// SetMembership добавляет пользователя в команду или меняет его роль и вес в ней.
+// Для пользователя без основной команды она становится основной.
+func (uc *TeamUseCase) SetMembership(
+	ctx context.Context,
+	teamName, userID string,
+	role entity.TeamRole,
+	reviewWeight *float64,
+) (*entity.TeamWithMembers, error) {
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.ensureTeamWritable(ctx, teamName); err != nil {
+			return err
+		}
+
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, domainErrors.ErrNotFound) {
+				return domainErrors.NewDomainError(
+					"NOT_FOUND",
+					"user not found",
+					domainErrors.ErrNotFound,
+				)
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		now := time.Now()
+		if user.TeamName == "" {
+			user.TeamName = teamName
+			user.UpdatedAt = now
+			if err := uc.userRepo.Update(ctx, user); err != nil {
+				return fmt.Errorf("failed to update user: %w", err)
+			}
+		}
+
+		return uc.membershipRepo.Upsert(ctx, &entity.TeamMembership{
+			TeamName:     teamName,
+			UserID:       userID,
+			Role:         role,
+			ReviewWeight: reviewWeight,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetTeamWithMembers(ctx, teamName)
+}
+
+// AddExistingUsers добавляет существующих пользователей в команду с ролью по умолчанию.
+// Пользователи, уже состоящие в команде, сохраняют роль и вес.
+func (uc *TeamUseCase) AddExistingUsers(ctx context.Context, teamName string, userIDs []string) (*entity.TeamWithMembers, error) {
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		return uc.addExistingUsers(ctx, teamName, userIDs)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetTeamWithMembers(ctx, teamName)
+}
+
+// TeamMembersPatch вид изменения участников в PatchTeam
+type TeamMembersPatch string
+
+const (
+	TeamMembersAdd     TeamMembersPatch = "ADD"
+	TeamMembersRemove  TeamMembersPatch = "REMOVE"
+	TeamMembersReplace TeamMembersPatch = "REPLACE"
+)
+
+// TeamPatchOperation одна операция PatchTeam: переименование в NewName и изменение участников UserIDs.
+// Пустые NewName и MembersPatch не меняют соответствующую часть команды.
+type TeamPatchOperation struct {
+	NewName      string
+	MembersPatch TeamMembersPatch
+	UserIDs      []string
+}
+
+// PatchTeam последовательно применяет операции к команде в одной транзакции: ошибка любой откатывает все.
+// Исключённые участники передают открытые ревью команды оставшимся, как RemoveTeamMember.
+func (uc *TeamUseCase) PatchTeam(ctx context.Context, teamName string, ops []TeamPatchOperation) (*entity.TeamWithMembers, error) {
+	var team *entity.TeamWithMembers
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if team, err = uc.GetTeamWithMembers(ctx, teamName); err != nil {
+			return err
+		}
+
+		for _, op := range ops {
+			if op.NewName != "" && op.NewName != team.TeamName {
+				if team, err = uc.RenameTeam(ctx, team.TeamName, op.NewName); err != nil {
+					return err
+				}
+			}
+
+			if op.MembersPatch != "" {
+				if team, err = uc.patchTeamMembers(ctx, team, op.MembersPatch, op.UserIDs); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// patchTeamMembers добавляет, исключает или заменяет участников команды
+func (uc *TeamUseCase) patchTeamMembers(
+	ctx context.Context,
+	team *entity.TeamWithMembers,
+	patch TeamMembersPatch,
+	userIDs []string,
+) (*entity.TeamWithMembers, error) {
+	current := make(map[string]bool, len(team.Members))
+	for _, m := range team.Members {
+		current[m.UserID] = true
+	}
+
+	var toRemove []string
+	switch patch {
+	case TeamMembersAdd:
+		if err := uc.addExistingUsers(ctx, team.TeamName, userIDs); err != nil {
+			return nil, err
+		}
+	case TeamMembersRemove:
+		for _, userID := range userIDs {
+			if current[userID] {
+				toRemove = append(toRemove, userID)
+			}
+		}
+	case TeamMembersReplace:
+		keep := make(map[string]bool, len(userIDs))
+		for _, userID := range userIDs {
+			keep[userID] = true
+		}
+		for _, m := range team.Members {
+			if !keep[m.UserID] {
+				toRemove = append(toRemove, m.UserID)
+			}
+		}
+		if err := uc.addExistingUsers(ctx, team.TeamName, userIDs); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, invalidInput("unsupported members patch: " + string(patch))
+	}
+
+	for _, userID := range toRemove {
+		if _, err := uc.RemoveTeamMember(ctx, team.TeamName, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.GetTeamWithMembers(ctx, team.TeamName)
+}
+
+// CreateTeamWithUsers создает команду из существующих пользователей.
+// Для пользователей без основной команды она становится основной, для остальных — дополнительной.
+func (uc *TeamUseCase) CreateTeamWithUsers(ctx context.Context, teamName string, userIDs []string) (*entity.TeamWithMembers, error) {
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.CreateTeam(ctx, &entity.TeamWithMembers{TeamName: teamName}); err != nil {
+			return err
+		}
+		return uc.addExistingUsers(ctx, teamName, userIDs)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetTeamWithMembers(ctx, teamName)
+}
+
+// addExistingUsers добавляет в команду пользователей, которые ещё в ней не состоят
+func (uc *TeamUseCase) addExistingUsers(ctx context.Context, teamName string, userIDs []string) error {
+	for _, userID := range userIDs {
+		_, err := uc.membershipRepo.Get(ctx, teamName, userID)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, domainErrors.ErrNotFound) {
+			return fmt.Errorf("failed to get team membership: %w", err)
+		}
+
+		if _, err := uc.SetMembership(ctx, teamName, userID, entity.TeamRoleMember, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MoveTeamMember переводит пользователя из команды fromTeam в toTeam
+func (uc *TeamUseCase) MoveTeamMember(
+	ctx context.Context,
+	userID, fromTeam, toTeam string,
+	policy ReviewHandOffPolicy,
+) (*MembershipChangeResult, error) {
+	if fromTeam == toTeam {
+		return nil, invalidInput("target team must differ from the current team")
+	}
+
+	return uc.changeMembership(ctx, userID, fromTeam, toTeam, policy, "user transfer to team "+toTeam)
+}
+
+// TransferUser переводит пользователя из его текущей команды в toTeam.
+// Открытые ревью PR старой команды остаются за ним или передаются согласно policy.
+func (uc *TeamUseCase) TransferUser(
+	ctx context.Context,
+	userID, toTeam string,
+	policy ReviewHandOffPolicy,
+) (*MembershipChangeResult, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.NewDomainError(
+				"NOT_FOUND",
+				"user not found",
+				domainErrors.ErrNotFound,
+			)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return uc.MoveTeamMember(ctx, userID, user.TeamName, toTeam, policy)
+}
+
+// membersToUsers готовит участников к записи в команду teamName,
+// отклоняя пользователей, которые уже состоят в другой команде.
+// Не переданный kind сохраняется у существующего пользователя и равен HUMAN у нового.
+func (uc *TeamUseCase) membersToUsers(ctx context.Context, teamName string, members []entity.TeamMember) ([]*entity.User, error) {
+	now := time.Now()
+	users := make([]*entity.User, 0, len(members))
+	for i := range members {
+		member := &members[i]
+		existing, err := uc.userRepo.GetByID(ctx, member.UserID)
+		if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+
+		createdAt := now
+		profile := member.UserProfile
+		if member.Kind == "" {
+			member.Kind = entity.UserKindHuman
+			if existing != nil {
+				member.Kind = existing.Kind
+			}
+		}
+		if existing != nil {
+			if existing.TeamName != "" && existing.TeamName != teamName {
+				return nil, invalidInput("user " + member.UserID + " belongs to team " + existing.TeamName + ", transfer the user instead")
+			}
+			createdAt = existing.CreatedAt
+			// Поля профиля, не переданные в запросе, сохраняются
+			profile = existing.UserProfile.Merge(member.UserProfile)
+		}
+
+		users = append(users, &entity.User{
+			UserID:      member.UserID,
+			Username:    member.Username,
+			TeamName:    teamName,
+			IsActive:    member.IsActive,
+			Kind:        member.Kind,
+			UserProfile: profile,
+			CreatedAt:   createdAt,
+			UpdatedAt:   now,
+		})
+	}
+
+	return users, nil
+}
+
+// removeSecondaryMembership исключает пользователя из неосновной команды
+// и передаёт его открытые ревью PR этой команды оставшимся участникам
+func (uc *TeamUseCase) removeSecondaryMembership(ctx context.Context, teamName, userID string) (*MembershipChangeResult, error) {
+	result := &MembershipChangeResult{
+		UserID:   userID,
+		FromTeam: teamName,
+		Policy:   ReviewHandOffReassign,
+		HandOffs: make([]ReviewHandOff, 0),
+	}
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.ensureTeamWritable(ctx, teamName); err != nil {
+			return err
+		}
+
+		if err := uc.membershipRepo.Remove(ctx, teamName, userID); err != nil {
+			if errors.Is(err, domainErrors.ErrNotFound) {
+				return domainErrors.NewDomainError(
+					"NOT_FOUND",
+					"user is not a member of team "+teamName,
+					domainErrors.ErrNotFound,
+				)
+			}
+			return fmt.Errorf("failed to remove team membership: %w", err)
+		}
+
+		return uc.handOffTeamReviews(ctx, userID, teamName, ReviewHandOffReassign, "team member removal", result)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// saveMemberRoles сохраняет роли и веса участников, для которых они указаны
+func (uc *TeamUseCase) saveMemberRoles(ctx context.Context, teamName string, members []entity.TeamMember) error {
+	now := time.Now()
+	for _, member := range members {
+		if member.Role == "" && member.ReviewWeight == nil {
+			continue
+		}
+
+		if err := uc.membershipRepo.Upsert(ctx, &entity.TeamMembership{
+			TeamName:     teamName,
+			UserID:       member.UserID,
+			Role:         member.Role,
+			ReviewWeight: member.ReviewWeight,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}); err != nil {
+			return fmt.Errorf("failed to save membership of user %s: %w", member.UserID, err)
+		}
+	}
+
+	return nil
+}
+
+// changeMembership переносит пользователя из команды fromTeam в toTeam (пустое имя — без команды)
+// и обрабатывает его открытые ревью PR команды fromTeam согласно policy
+func (uc *TeamUseCase) changeMembership(
+	ctx context.Context,
+	userID, fromTeam, toTeam string,
+	policy ReviewHandOffPolicy,
+	reason string,
+) (*MembershipChangeResult, error) {
+	if policy != ReviewHandOffKeep && policy != ReviewHandOffReassign {
+		return nil, invalidInput("review_policy must be KEEP or REASSIGN")
+	}
+
+	result := &MembershipChangeResult{
+		UserID:   userID,
+		FromTeam: fromTeam,
+		ToTeam:   toTeam,
+		Policy:   policy,
+		HandOffs: make([]ReviewHandOff, 0),
+	}
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		for _, teamName := range []string{fromTeam, toTeam} {
+			if teamName == "" {
+				continue
+			}
+			if err := uc.ensureTeamWritable(ctx, teamName); err != nil {
+				return err
+			}
+		}
+
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, domainErrors.ErrNotFound) {
+				return domainErrors.NewDomainError(
+					"NOT_FOUND",
+					"user not found",
+					domainErrors.ErrNotFound,
+				)
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		if user.TeamName != fromTeam {
+			return domainErrors.NewDomainError(
+				"NOT_FOUND",
+				"user is not a member of team "+fromTeam,
+				domainErrors.ErrNotFound,
+			)
+		}
+
+		user.TeamName = toTeam
+		user.UpdatedAt = time.Now()
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+
+		// Пользователь без команды не имел ревью от своей команды
+		if fromTeam == "" {
+			return nil
+		}
+
+		return uc.handOffTeamReviews(ctx, userID, fromTeam, policy, reason, result)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// handOffTeamReviews обрабатывает открытые ревью пользователя на PR команды teamName согласно policy
+func (uc *TeamUseCase) handOffTeamReviews(
+	ctx context.Context,
+	userID, teamName string,
+	policy ReviewHandOffPolicy,
+	reason string,
+	result *MembershipChangeResult,
+) error {
+	prs, err := uc.prRepo.GetByReviewer(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get PRs for user %s: %w", userID, err)
+	}
+
+	for _, prShort := range prs {
+		if prShort.Status != entity.PRStatusOpen {
+			continue
+		}
+
+		// Обрабатываем только ревью PR этой команды
+		author, err := uc.userRepo.GetByID(ctx, prShort.AuthorID)
+		if err != nil {
+			return fmt.Errorf("failed to get PR author %s: %w", prShort.AuthorID, err)
+		}
+		if author.TeamName != teamName {
+			continue
+		}
+
+		handOff, err := uc.handOffOnMembershipChange(ctx, prShort.PullRequestID, userID, teamName, policy, reason)
+		if err != nil {
+			return err
+		}
+		if handOff == nil {
+			continue
+		}
+
+		switch handOff.Action {
+		case ReviewHandOffKept:
+			result.KeptPRs++
+		case ReviewHandOffUnassigned:
+			result.UnassignedPRs++
+		case ReviewHandOffReassigned:
+			result.ReassignedPRs++
+			if handOff.Escalated {
+				result.EscalatedPRs++
+			}
+		}
+		result.HandOffs = append(result.HandOffs, *handOff)
+	}
+
+	return nil
+}
+
+// handOffOnMembershipChange оставляет или передаёт ревью одного PR ушедшего из команды пользователя.
+// Возвращает nil, если пользователь уже не назначен на PR.
+func (uc *TeamUseCase) handOffOnMembershipChange(
+	ctx context.Context,
+	prID, userID, fromTeam string,
+	policy ReviewHandOffPolicy,
+	reason string,
+) (*ReviewHandOff, error) {
+	if policy == ReviewHandOffKeep {
+		if err := uc.historyRepo.Add(ctx, &entity.PRHistoryEntry{
+			PullRequestID: prID,
+			EventType:     entity.PRHistoryReviewerKept,
+			OldReviewerID: userID,
+			NewReviewerID: userID,
+			Reason:        reason,
+			CreatedAt:     time.Now(),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to write PR history: %w", err)
+		}
+		return &ReviewHandOff{PullRequestID: prID, OldReviewerID: userID, Action: ReviewHandOffKept}, nil
+	}
+
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR %s: %w", prID, err)
+	}
+
+	outcome, err := uc.handOffReview(ctx, pr, userID, fromTeam, nil, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return outcome.handOff(prID, userID), nil
+}
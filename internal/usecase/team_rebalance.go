@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+)
+
+// RebalanceMove одно перемещение назначения между ревьюверами
+type RebalanceMove struct {
+	PullRequestID string
+	FromUserID    string
+	ToUserID      string
+}
+
+// RebalanceTeamResult результат перераспределения ревью в команде
+type RebalanceTeamResult struct {
+	TeamName   string
+	DryRun     bool
+	Moves      []RebalanceMove
+	LoadBefore map[string]int
+	LoadAfter  map[string]int
+	Rules      []*entity.ReviewRule
+}
+
+// RebalanceTeam выравнивает количество открытых ревью между активными участниками команды
+// пропорционально их весу ревью.
+// В режиме dryRun возвращает план без изменений, иначе применяет его в одной транзакции.
+func (uc *TeamUseCase) RebalanceTeam(ctx context.Context, teamName string, dryRun bool) (*RebalanceTeamResult, error) {
+	var result *RebalanceTeamResult
+
+	plan := func(ctx context.Context) error {
+		if err := uc.ensureTeamWritable(ctx, teamName); err != nil {
+			return err
+		}
+
+		users, err := uc.userRepo.GetActiveByTeam(ctx, teamName)
+		if err != nil {
+			return fmt.Errorf("failed to get active team members: %w", err)
+		}
+
+		reviewers := filterCandidates(users, "", nil, nil)
+		reviewerIDs := make([]string, 0, len(reviewers))
+		for _, reviewer := range reviewers {
+			reviewerIDs = append(reviewerIDs, reviewer.UserID)
+		}
+
+		prs, err := uc.prRepo.GetOpenPRsByReviewers(ctx, reviewerIDs)
+		if err != nil {
+			return fmt.Errorf("failed to get open PRs: %w", err)
+		}
+
+		authorIDs := make([]string, 0, len(prs))
+		for _, pr := range prs {
+			authorIDs = append(authorIDs, pr.AuthorID)
+		}
+
+		rules, err := loadReviewRules(ctx, uc.ruleRepo, authorIDs...)
+		if err != nil {
+			return err
+		}
+
+		weights, err := loadReviewWeights(ctx, uc.membershipRepo, teamName)
+		if err != nil {
+			return err
+		}
+
+		moves, loadBefore, loadAfter, changed := planRebalance(reviewerIDs, prs, rules, weights)
+
+		if !dryRun {
+			for _, pr := range changed {
+				if err := uc.prRepo.Update(ctx, pr); err != nil {
+					return fmt.Errorf("failed to update PR %s: %w", pr.PullRequestID, err)
+				}
+			}
+
+			authors := make(map[string]string, len(changed))
+			for _, pr := range changed {
+				authors[pr.PullRequestID] = pr.AuthorID
+			}
+
+			now := time.Now()
+			for _, move := range moves {
+				if err := uc.historyRepo.Add(ctx, &entity.PRHistoryEntry{
+					PullRequestID: move.PullRequestID,
+					EventType:     entity.PRHistoryReviewerReassigned,
+					OldReviewerID: move.FromUserID,
+					NewReviewerID: move.ToUserID,
+					Reason:        "team rebalance",
+					CreatedAt:     now,
+				}); err != nil {
+					return fmt.Errorf("failed to write PR history: %w", err)
+				}
+
+				if err := enqueueReviewerRequest(ctx, uc.prLinkRepo, uc.reviewerReqRepo, move.PullRequestID,
+					[]string{move.ToUserID}, []string{move.FromUserID}); err != nil {
+					return err
+				}
+
+				authorTeamName, err := authorTeam(ctx, uc.userRepo, authors[move.PullRequestID])
+				if err != nil {
+					return err
+				}
+
+				if err := publishReassignedEvent(ctx, uc.events, authorTeamName, move.PullRequestID, move.FromUserID, move.ToUserID,
+					"team rebalance", false); err != nil {
+					return err
+				}
+			}
+		}
+
+		result = &RebalanceTeamResult{
+			TeamName:   teamName,
+			DryRun:     dryRun,
+			Moves:      moves,
+			LoadBefore: loadBefore,
+			LoadAfter:  loadAfter,
+			Rules:      rules.rulesFor(authorIDs...),
+		}
+		return nil
+	}
+
+	var err error
+	if dryRun {
+		err = plan(ctx)
+	} else {
+		err = uc.txManager.RunInTransaction(ctx, plan)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// planRebalance жадно переносит назначения с самого загруженного ревьювера на наименее загруженного
+// (нагрузка нормируется на вес), пока перенос уменьшает сумму квадратов нагрузки, делённых на вес.
+// При равных весах это прежнее правило: разница нагрузки больше одного.
+// Существующие назначения сохраняются, если перенос не нужен;
+// автор PR, уже назначенные и запрещённые правилами ревьюверы не получают перенесённое ревью,
+// а обязательная пара автора со своего PR не снимается.
+func planRebalance(
+	reviewerIDs []string,
+	prs []*entity.PullRequest,
+	rules *reviewRuleSet,
+	weights map[string]float64,
+) ([]RebalanceMove, map[string]int, map[string]int, []*entity.PullRequest) {
+	load := make(map[string]int, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		load[id] = 0
+	}
+
+	// Открытые PR каждого ревьювера, новые первыми: их переносить дешевле всего
+	assignments := make(map[string][]*entity.PullRequest, len(reviewerIDs))
+	for i := len(prs) - 1; i >= 0; i-- {
+		pr := prs[i]
+		for _, reviewerID := range pr.AssignedReviewers {
+			if _, ok := load[reviewerID]; ok {
+				load[reviewerID]++
+				assignments[reviewerID] = append(assignments[reviewerID], pr)
+			}
+		}
+	}
+
+	loadBefore := make(map[string]int, len(load))
+	for id, count := range load {
+		loadBefore[id] = count
+	}
+
+	moves := make([]RebalanceMove, 0)
+	changed := make(map[string]*entity.PullRequest)
+	exhausted := make(map[string]bool)
+
+	for {
+		// Ревьюверы по возрастанию нормированной нагрузки (id для детерминированности)
+		ordered := make([]string, len(reviewerIDs))
+		copy(ordered, reviewerIDs)
+		normalized := func(id string) float64 {
+			return float64(load[id]) / reviewWeight(weights, id)
+		}
+		sort.Slice(ordered, func(i, j int) bool {
+			if normalized(ordered[i]) != normalized(ordered[j]) {
+				return normalized(ordered[i]) < normalized(ordered[j])
+			}
+			return ordered[i] < ordered[j]
+		})
+
+		// Самый загруженный ревьювер, у которого ещё можно что-то забрать
+		from := ""
+		for i := len(ordered) - 1; i >= 0; i-- {
+			if !exhausted[ordered[i]] {
+				from = ordered[i]
+				break
+			}
+		}
+		if from == "" {
+			break
+		}
+
+		moved := false
+		for _, to := range ordered {
+			if !rebalanceImproves(load[from], load[to], reviewWeight(weights, from), reviewWeight(weights, to)) {
+				continue
+			}
+
+			for i, pr := range assignments[from] {
+				if rules.isPartner(pr.AuthorID, from) || !canTakeReview(pr, to, rules) {
+					continue
+				}
+
+				replaceReviewer(pr, from, to)
+				changed[pr.PullRequestID] = pr
+
+				assignments[from] = append(assignments[from][:i], assignments[from][i+1:]...)
+				assignments[to] = append(assignments[to], pr)
+				load[from]--
+				load[to]++
+
+				moves = append(moves, RebalanceMove{
+					PullRequestID: pr.PullRequestID,
+					FromUserID:    from,
+					ToUserID:      to,
+				})
+				moved = true
+				break
+			}
+
+			if moved {
+				break
+			}
+		}
+
+		if !moved {
+			exhausted[from] = true
+		}
+	}
+
+	changedPRs := make([]*entity.PullRequest, 0, len(changed))
+	for _, pr := range prs {
+		if _, ok := changed[pr.PullRequestID]; ok {
+			changedPRs = append(changedPRs, pr)
+		}
+	}
+
+	return moves, loadBefore, load, changedPRs
+}
+
+// rebalanceImproves сообщает, уменьшит ли перенос одного ревью с from на to
+// сумму квадратов нагрузки, делённых на вес
+func rebalanceImproves(fromLoad, toLoad int, fromWeight, toWeight float64) bool {
+	return float64(2*toLoad+1)/toWeight < float64(2*fromLoad-1)/fromWeight
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+)
+
+// GetSizePolicy возвращает таблицу классов размера PR, действующую для команды
+func (uc *TeamUseCase) GetSizePolicy(ctx context.Context, teamName string) (*entity.SizePolicy, error) {
+	if err := uc.ensureTeamExists(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	return loadSizePolicy(ctx, uc.teamRepo, uc.sizePolicyRepo, teamName)
+}
+
+// SetSizePolicy заменяет таблицу классов размера PR команды
+func (uc *TeamUseCase) SetSizePolicy(ctx context.Context, teamName string, buckets []*entity.SizeBucket) (*entity.SizePolicy, error) {
+	if err := validateSizeBuckets(buckets); err != nil {
+		return nil, err
+	}
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.ensureTeamWritable(ctx, teamName); err != nil {
+			return err
+		}
+
+		if err := uc.sizePolicyRepo.ReplaceForTeam(ctx, teamName, buckets); err != nil {
+			return fmt.Errorf("failed to replace size buckets: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.SizePolicy{
+		TeamName: teamName,
+		Buckets:  buckets,
+	}, nil
+}
+
+// ensureTeamExists возвращает NOT_FOUND, если команды не существует
+func (uc *TeamUseCase) ensureTeamExists(ctx context.Context, teamName string) error {
+	_, err := uc.getTeam(ctx, teamName)
+	return err
+}
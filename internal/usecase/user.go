@@ -13,23 +13,67 @@ import (
 
 // UserUseCase реализует бизнес-логику для пользователей
 type UserUseCase struct {
-	userRepo repository.UserRepository
-	prRepo   repository.PullRequestRepository
+	userRepo  repository.UserRepository
+	prRepo    repository.PullRequestRepository
+	teamRepo  repository.TeamRepository
+	txManager repository.TransactionManager
+	teamUC    *TeamUseCase
 }
 
 // NewUserUseCase создает новый usecase для пользователей
 func NewUserUseCase(
 	userRepo repository.UserRepository,
 	prRepo repository.PullRequestRepository,
+	teamRepo repository.TeamRepository,
+	txManager repository.TransactionManager,
+	teamUC *TeamUseCase,
 ) *UserUseCase {
 	return &UserUseCase{
-		userRepo: userRepo,
-		prRepo:   prRepo,
+		userRepo:  userRepo,
+		prRepo:    prRepo,
+		teamRepo:  teamRepo,
+		txManager: txManager,
+		teamUC:    teamUC,
 	}
 }
 
-// SetIsActive устанавливает флаг активности пользователя
-func (uc *UserUseCase) SetIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
+// SetIsActive устанавливает флаг активности пользователя.
+// При деактивации с handOff открытые ревью передаются преемнику successorID или участникам основной команды,
+// как при деактивации команды; отчёт о передаче возвращается вторым значением.
+func (uc *UserUseCase) SetIsActive(
+	ctx context.Context,
+	userID string,
+	isActive, handOff bool,
+	successorID string,
+) (*entity.User, *ActivationChangeResult, error) {
+	if successorID != "" {
+		handOff = true
+	}
+
+	if handOff {
+		if isActive {
+			return nil, nil, invalidInput("reviews are handed off only on deactivation")
+		}
+
+		report, err := uc.teamUC.DeactivateUser(ctx, userID, successorID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get user: %w", err)
+		}
+
+		return user, report, nil
+	}
+
+	user, err := uc.setIsActive(ctx, userID, isActive)
+	return user, nil, err
+}
+
+// setIsActive меняет только флаг активности, не трогая назначения
+func (uc *UserUseCase) setIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
 	user, err := uc.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, domainErrors.ErrNotFound) {
@@ -52,6 +96,200 @@ func (uc *UserUseCase) SetIsActive(ctx context.Context, userID string, isActive
 	return user, nil
 }
 
+// UserUpdate изменения пользователя: nil-поле не меняется, пустая строка очищает поле профиля
+type UserUpdate struct {
+	Username    *string
+	DisplayName *string
+	Email       *string
+	ChatHandle  *string
+	Timezone    *string
+}
+
+// GetUser возвращает пользователя по ID с числом его открытых ревью
+func (uc *UserUseCase) GetUser(ctx context.Context, userID string) (*entity.UserWithOpenReviews, error) {
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	openReviews, err := uc.prRepo.CountOpenByReviewer(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count open reviews: %w", err)
+	}
+
+	return &entity.UserWithOpenReviews{
+		User:        *user,
+		OpenReviews: openReviews,
+	}, nil
+}
+
+// Границы размера страницы списка пользователей
+const (
+	defaultUserPageSize = 50
+	maxUserPageSize     = 200
+)
+
+// UserPage страница списка пользователей
+type UserPage struct {
+	Users  []*entity.UserWithOpenReviews
+	Total  int
+	Limit  int
+	Offset int
+}
+
+// ListUsers возвращает страницу пользователей по фильтру и общее число подходящих.
+// Нулевой Limit заменяется размером страницы по умолчанию.
+func (uc *UserUseCase) ListUsers(ctx context.Context, filter entity.UserFilter) (*UserPage, error) {
+	if filter.Limit < 0 || filter.Limit > maxUserPageSize {
+		return nil, invalidInput("limit must be between 1 and 200")
+	}
+	if filter.Offset < 0 {
+		return nil, invalidInput("offset must not be negative")
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultUserPageSize
+	}
+
+	users, total, err := uc.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	if users == nil {
+		users = []*entity.UserWithOpenReviews{}
+	}
+
+	return &UserPage{
+		Users:  users,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+// getUser возвращает пользователя, отображая отсутствие в NOT_FOUND
+func (uc *UserUseCase) getUser(ctx context.Context, userID string) (*entity.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.NewDomainError(
+				"NOT_FOUND",
+				"user not found",
+				domainErrors.ErrNotFound,
+			)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// CreateUser создает пользователя без команды; команды назначаются членствами
+func (uc *UserUseCase) CreateUser(
+	ctx context.Context,
+	userID, username string,
+	isActive bool,
+	profile entity.UserProfile,
+) (*entity.User, error) {
+	_, err := uc.userRepo.GetByID(ctx, userID)
+	if err == nil {
+		return nil, domainErrors.NewDomainError(
+			"USER_EXISTS",
+			"user "+userID+" already exists",
+			domainErrors.ErrUserExists,
+		)
+	}
+	if !errors.Is(err, domainErrors.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	now := time.Now()
+	user := &entity.User{
+		UserID:      userID,
+		Username:    username,
+		IsActive:    isActive,
+		Kind:        entity.UserKindHuman,
+		UserProfile: profile,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
+
+// UpdateUser меняет имя и профиль пользователя
+func (uc *UserUseCase) UpdateUser(ctx context.Context, userID string, update UserUpdate) (*entity.User, error) {
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Username != nil {
+		if *update.Username == "" {
+			return nil, invalidInput("username must not be empty")
+		}
+		user.Username = *update.Username
+	}
+
+	if update.DisplayName != nil {
+		user.DisplayName = *update.DisplayName
+	}
+	if update.Email != nil {
+		user.Email = *update.Email
+	}
+	if update.ChatHandle != nil {
+		user.ChatHandle = *update.ChatHandle
+	}
+	if update.Timezone != nil {
+		user.Timezone = *update.Timezone
+	}
+
+	user.UpdatedAt = time.Now()
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return user, nil
+}
+
+// PatchUser применяет изменения профиля и активности пользователя в одной транзакции.
+// nil update не меняет профиль, nil active — активность; деактивация передаёт открытые ревью, как SetIsActive с handOff.
+func (uc *UserUseCase) PatchUser(ctx context.Context, userID string, update *UserUpdate, active *bool) (*entity.User, error) {
+	var user *entity.User
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if user, err = uc.getUser(ctx, userID); err != nil {
+			return err
+		}
+
+		if update != nil {
+			if user, err = uc.UpdateUser(ctx, userID, *update); err != nil {
+				return err
+			}
+		}
+
+		if active != nil && *active != user.IsActive {
+			if user, _, err = uc.SetIsActive(ctx, userID, *active, !*active, ""); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
 // GetUserReviews возвращает список Pов, где пользователь назначен ревьювером
 func (uc *UserUseCase) GetUserReviews(ctx context.Context, userID string) ([]*entity.PullRequestShort, error) {
 	// Проверяем существование пользователя
@@ -80,3 +318,71 @@ func (uc *UserUseCase) GetUserReviews(ctx context.Context, userID string) ([]*en
 
 	return prs, nil
 }
+
+// RegisterBot регистрирует бота или сервисный аккаунт и привязывает его к команде-владельцу.
+// PR бота получают ревьюверов из команды-владельца, сам бот ревьювером не назначается.
+func (uc *UserUseCase) RegisterBot(ctx context.Context, userID, username, owningTeam string) (*entity.User, error) {
+	team, err := uc.teamRepo.GetByName(ctx, owningTeam)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.NewDomainError(
+				"NOT_FOUND",
+				"owning team not found",
+				domainErrors.ErrNotFound,
+			)
+		}
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	if team.IsArchived() {
+		return nil, domainErrors.NewDomainError(
+			"TEAM_ARCHIVED",
+			"owning team is archived",
+			domainErrors.ErrTeamArchived,
+		)
+	}
+
+	now := time.Now()
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Новый сервисный аккаунт
+	if user == nil {
+		user = &entity.User{
+			UserID:    userID,
+			Username:  username,
+			TeamName:  owningTeam,
+			IsActive:  true,
+			Kind:      entity.UserKindBot,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to create bot: %w", err)
+		}
+
+		return user, nil
+	}
+
+	if !user.IsBot() {
+		return nil, domainErrors.NewDomainError(
+			"INVALID_INPUT",
+			"user already exists and is not a bot",
+			domainErrors.ErrInvalidInput,
+		)
+	}
+
+	// Перепривязываем существующего бота
+	user.Username = username
+	user.TeamName = owningTeam
+	user.UpdatedAt = now
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update bot: %w", err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+	"github.com/StepanK17/pr-reviewer-service/internal/repository"
+)
+
+// maxPullRequestNameLength длина pull_request_name в БД
+const maxPullRequestNameLength = 255
+
+// VCSEventOutcome результат обработки события хостинга кода
+type VCSEventOutcome string
+
+const (
+	VCSEventCreated   VCSEventOutcome = "CREATED"
+	VCSEventMerged    VCSEventOutcome = "MERGED"
+	VCSEventClosed    VCSEventOutcome = "CLOSED"
+	VCSEventReopened  VCSEventOutcome = "REOPENED"
+	VCSEventIgnored   VCSEventOutcome = "IGNORED"
+	VCSEventDuplicate VCSEventOutcome = "DUPLICATE"
+)
+
+// VCSEventResult результат обработки события; PR равен nil, если событие не изменило PR
+type VCSEventResult struct {
+	DeliveryID    string
+	Outcome       VCSEventOutcome
+	PullRequestID string
+	PR            *entity.PullRequest
+}
+
+// VCSUseCase связывает события хостингов кода с жизненным циклом PR
+type VCSUseCase struct {
+	identityRepo    repository.VCSIdentityRepository
+	deliveryRepo    repository.VCSDeliveryRepository
+	prLinkRepo      repository.VCSPullRequestRepository
+	reviewerReqRepo repository.VCSReviewerRequestRepository
+	userRepo        repository.UserRepository
+	prRepo          repository.PullRequestRepository
+	txManager       repository.TransactionManager
+	prUC            *PullRequestUseCase
+}
+
+// NewVCSUseCase создает новый usecase интеграции с хостингами кода
+func NewVCSUseCase(
+	identityRepo repository.VCSIdentityRepository,
+	deliveryRepo repository.VCSDeliveryRepository,
+	prLinkRepo repository.VCSPullRequestRepository,
+	reviewerReqRepo repository.VCSReviewerRequestRepository,
+	userRepo repository.UserRepository,
+	prRepo repository.PullRequestRepository,
+	txManager repository.TransactionManager,
+	prUC *PullRequestUseCase,
+) *VCSUseCase {
+	return &VCSUseCase{
+		identityRepo:    identityRepo,
+		deliveryRepo:    deliveryRepo,
+		prLinkRepo:      prLinkRepo,
+		reviewerReqRepo: reviewerReqRepo,
+		userRepo:        userRepo,
+		prRepo:          prRepo,
+		txManager:       txManager,
+		prUC:            prUC,
+	}
+}
+
+// HandlePullRequestEvent применяет событие PR из вебхука.
+// Доставка записывается в той же транзакции, поэтому повтор после ошибки обрабатывается заново,
+// а повтор успешной доставки возвращает DUPLICATE. Черновики не создаются до готовности к ревью,
+// события для PR, которых нет в сервисе, игнорируются.
+func (uc *VCSUseCase) HandlePullRequestEvent(ctx context.Context, event *entity.VCSPullRequestEvent) (*VCSEventResult, error) {
+	if event.DeliveryID == "" {
+		return nil, invalidInput("delivery id is required")
+	}
+	if event.Repository == "" || event.Number <= 0 {
+		return nil, invalidInput("repository and pull request number are required")
+	}
+
+	result := &VCSEventResult{
+		DeliveryID:    event.DeliveryID,
+		PullRequestID: event.PullRequestID(),
+	}
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		recorded, err := uc.deliveryRepo.Record(ctx, event.Provider, event.DeliveryID, "pull_request:"+string(event.Action), time.Now())
+		if err != nil {
+			return err
+		}
+
+		if !recorded {
+			result.Outcome = VCSEventDuplicate
+			return nil
+		}
+
+		exists, err := uc.prRepo.Exists(ctx, result.PullRequestID)
+		if err != nil {
+			return fmt.Errorf("failed to check PR existence: %w", err)
+		}
+
+		switch {
+		case event.Action == entity.VCSPullRequestOpened || event.Action == entity.VCSPullRequestReadyForReview,
+			event.Action == entity.VCSPullRequestReopened && !exists:
+			if exists || event.Draft {
+				result.Outcome = VCSEventIgnored
+				return nil
+			}
+			result.PR, err = uc.createPullRequest(ctx, event, result.PullRequestID)
+			result.Outcome = VCSEventCreated
+
+		case !exists:
+			result.Outcome = VCSEventIgnored
+
+		case event.Action == entity.VCSPullRequestReopened:
+			result.PR, err = uc.prUC.ReopenPullRequest(ctx, result.PullRequestID)
+			result.Outcome = VCSEventReopened
+
+		case event.Action == entity.VCSPullRequestMerged:
+			result.PR, err = uc.prUC.MergePullRequest(ctx, result.PullRequestID)
+			result.Outcome = VCSEventMerged
+
+		case event.Action == entity.VCSPullRequestClosed:
+			result.PR, err = uc.prUC.ClosePullRequest(ctx, result.PullRequestID)
+			result.Outcome = VCSEventClosed
+
+		default:
+			return invalidInput("unsupported pull request action: " + string(event.Action))
+		}
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// createPullRequest создает PR от пользователя, сопоставленного автору, запоминает ссылку на хостинг
+// и ставит назначенных ревьюверов в очередь отправки
+func (uc *VCSUseCase) createPullRequest(ctx context.Context, event *entity.VCSPullRequestEvent, prID string) (*entity.PullRequest, error) {
+	authorID, err := uc.resolveUser(ctx, event.Provider, event.AuthorLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	name := event.Title
+	if runes := []rune(name); len(runes) > maxPullRequestNameLength {
+		name = string(runes[:maxPullRequestNameLength])
+	}
+
+	pr, err := uc.prUC.CreatePullRequest(ctx, prID, name, authorID, event.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.prLinkRepo.Upsert(ctx, &entity.VCSPullRequestLink{
+		PullRequestID: prID,
+		Provider:      event.Provider,
+		Repository:    event.Repository,
+		Number:        event.Number,
+		URL:           event.URL,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := enqueueReviewerRequest(ctx, uc.prLinkRepo, uc.reviewerReqRepo, prID, pr.AssignedReviewers, nil); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// resolveUser находит пользователя по логину: сначала по привязке, затем по совпадению с user_id
+func (uc *VCSUseCase) resolveUser(ctx context.Context, provider entity.VCSProvider, login string) (string, error) {
+	identity, err := uc.identityRepo.Get(ctx, provider, strings.ToLower(login))
+	if err == nil {
+		return identity.UserID, nil
+	}
+	if !errors.Is(err, domainErrors.ErrNotFound) {
+		return "", fmt.Errorf("failed to get vcs identity: %w", err)
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, login)
+	if err == nil {
+		return user.UserID, nil
+	}
+	if !errors.Is(err, domainErrors.ErrNotFound) {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return "", domainErrors.NewDomainError(
+		"NOT_FOUND",
+		fmt.Sprintf("%s login %q is not mapped to a user", strings.ToLower(string(provider)), login),
+		domainErrors.ErrNotFound,
+	)
+}
+
+// SetIdentity привязывает логин на хостинге кода к пользователю
+func (uc *VCSUseCase) SetIdentity(ctx context.Context, provider entity.VCSProvider, login, userID string) (*entity.VCSIdentity, error) {
+	if !provider.IsValid() {
+		return nil, invalidInput("unsupported provider: " + string(provider))
+	}
+	if login == "" || userID == "" {
+		return nil, invalidInput("login and user_id are required")
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.NewDomainError(
+				"NOT_FOUND",
+				"user not found",
+				domainErrors.ErrNotFound,
+			)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	identity := &entity.VCSIdentity{
+		Provider:  provider,
+		Login:     strings.ToLower(login),
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}
+
+	if err := uc.identityRepo.Upsert(ctx, identity); err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+// DeleteIdentity удаляет привязку логина
+func (uc *VCSUseCase) DeleteIdentity(ctx context.Context, provider entity.VCSProvider, login string) error {
+	err := uc.identityRepo.Delete(ctx, provider, strings.ToLower(login))
+	if errors.Is(err, domainErrors.ErrNotFound) {
+		return domainErrors.NewDomainError(
+			"NOT_FOUND",
+			"vcs identity not found",
+			domainErrors.ErrNotFound,
+		)
+	}
+
+	return err
+}
+
+// ListIdentities возвращает привязки логинов; пустые provider и userID не фильтруют
+func (uc *VCSUseCase) ListIdentities(ctx context.Context, provider entity.VCSProvider, userID string) ([]*entity.VCSIdentity, error) {
+	if provider != "" && !provider.IsValid() {
+		return nil, invalidInput("unsupported provider: " + string(provider))
+	}
+
+	identities, err := uc.identityRepo.List(ctx, provider, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if identities == nil {
+		identities = []*entity.VCSIdentity{}
+	}
+
+	return identities, nil
+}
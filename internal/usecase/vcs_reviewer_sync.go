@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+	"github.com/StepanK17/pr-reviewer-service/internal/repository"
+	"github.com/StepanK17/pr-reviewer-service/internal/vcs"
+)
+
+// maxRetryBackoff верхняя граница паузы между попытками отправки
+const maxRetryBackoff = time.Hour
+
+// claimLease на сколько откладывается запись, захваченная для отправки вне транзакции.
+// Если обработчик упадёт во время отправки, запись снова станет готовой по истечении этого времени.
+const claimLease = 5 * time.Minute
+
+// VCSReviewerSyncUseCase передает назначенных ревьюверов на хостинг кода
+type VCSReviewerSyncUseCase struct {
+	requestRepo  repository.VCSReviewerRequestRepository
+	prLinkRepo   repository.VCSPullRequestRepository
+	identityRepo repository.VCSIdentityRepository
+	txManager    repository.TransactionManager
+	clients      map[entity.VCSProvider]vcs.Client
+	retryBackoff time.Duration
+	maxAttempts  int
+}
+
+// NewVCSReviewerSyncUseCase создает usecase отправки ревьюверов.
+// Запросы для провайдеров без клиента в clients помечаются SKIPPED.
+func NewVCSReviewerSyncUseCase(
+	requestRepo repository.VCSReviewerRequestRepository,
+	prLinkRepo repository.VCSPullRequestRepository,
+	identityRepo repository.VCSIdentityRepository,
+	txManager repository.TransactionManager,
+	clients map[entity.VCSProvider]vcs.Client,
+	retryBackoff time.Duration,
+	maxAttempts int,
+) *VCSReviewerSyncUseCase {
+	return &VCSReviewerSyncUseCase{
+		requestRepo:  requestRepo,
+		prLinkRepo:   prLinkRepo,
+		identityRepo: identityRepo,
+		txManager:    txManager,
+		clients:      clients,
+		retryBackoff: retryBackoff,
+		maxAttempts:  maxAttempts,
+	}
+}
+
+// enqueueReviewerRequest ставит изменение ревьюверов в очередь отправки в текущей транзакции.
+// PR без ссылки на хостинг кода и пустые изменения пропускаются.
+func enqueueReviewerRequest(
+	ctx context.Context,
+	prLinkRepo repository.VCSPullRequestRepository,
+	requestRepo repository.VCSReviewerRequestRepository,
+	prID string,
+	added, removed []string,
+) error {
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	if _, err := prLinkRepo.GetByPullRequest(ctx, prID); err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get vcs pull request link: %w", err)
+	}
+
+	now := time.Now().UTC()
+	return requestRepo.Create(ctx, &entity.VCSReviewerRequest{
+		PullRequestID:    prID,
+		AddedReviewers:   added,
+		RemovedReviewers: removed,
+		Status:           entity.VCSReviewerRequestPending,
+		NextAttemptAt:    now,
+		CreatedAt:        now,
+	})
+}
+
+// PushDueReviewerRequests отправляет все готовые запросы и возвращает число обработанных.
+// Запрос захватывается в короткой транзакции и отправляется после её коммита, чтобы медленный
+// хостинг не держал соединение с базой; неудачная попытка откладывается с удвоением паузы,
+// после maxAttempts или неповторяемой ошибки запрос получает статус FAILED.
+func (uc *VCSReviewerSyncUseCase) PushDueReviewerRequests(ctx context.Context, now time.Time) (int, error) {
+	processed := 0
+
+	for {
+		request, err := uc.claimNextDue(ctx, now)
+		if err != nil {
+			return processed, err
+		}
+
+		if request == nil {
+			return processed, nil
+		}
+
+		uc.push(ctx, request, now)
+
+		if err := uc.requestRepo.Update(ctx, request); err != nil {
+			return processed, err
+		}
+
+		processed++
+	}
+}
+
+// claimNextDue захватывает готовый запрос, откладывая его на claimLease, чтобы другие обработчики
+// не взяли его во время отправки. Возвращает nil, если готовых запросов нет.
+func (uc *VCSReviewerSyncUseCase) claimNextDue(ctx context.Context, now time.Time) (*entity.VCSReviewerRequest, error) {
+	var request *entity.VCSReviewerRequest
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		locked, err := uc.requestRepo.LockNextDue(ctx, now)
+		if err != nil || locked == nil {
+			return err
+		}
+
+		locked.NextAttemptAt = now.Add(claimLease)
+		if err := uc.requestRepo.Update(ctx, locked); err != nil {
+			return err
+		}
+
+		request = locked
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// push выполняет одну попытку отправки и записывает результат в request
+func (uc *VCSReviewerSyncUseCase) push(ctx context.Context, request *entity.VCSReviewerRequest, now time.Time) {
+	link, err := uc.prLinkRepo.GetByPullRequest(ctx, request.PullRequestID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			uc.skip(request, "pull request is not linked to a code host")
+			return
+		}
+		uc.fail(request, fmt.Errorf("failed to get vcs pull request link: %w", err), now)
+		return
+	}
+
+	client := uc.clients[link.Provider]
+	if client == nil {
+		uc.skip(request, "no client configured for provider "+string(link.Provider))
+		return
+	}
+
+	added, unmappedAdded, err := uc.resolveLogins(ctx, link.Provider, request.AddedReviewers)
+	if err != nil {
+		uc.fail(request, err, now)
+		return
+	}
+
+	removed, unmappedRemoved, err := uc.resolveLogins(ctx, link.Provider, request.RemovedReviewers)
+	if err != nil {
+		uc.fail(request, err, now)
+		return
+	}
+
+	unmappedReason := ""
+	if unmapped := append(unmappedAdded, unmappedRemoved...); len(unmapped) > 0 {
+		unmappedReason = "reviewers are not mapped to logins: " + strings.Join(unmapped, ", ")
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		uc.skip(request, unmappedReason)
+		return
+	}
+
+	if len(added) > 0 {
+		if err := client.RequestReviewers(ctx, link.Repository, link.Number, added); err != nil {
+			uc.fail(request, fmt.Errorf("request reviewers: %w", err), now)
+			return
+		}
+	}
+
+	if len(removed) > 0 {
+		if err := client.RemoveReviewers(ctx, link.Repository, link.Number, removed); err != nil {
+			uc.fail(request, fmt.Errorf("remove reviewers: %w", err), now)
+			return
+		}
+	}
+
+	request.Attempts++
+	request.Status = entity.VCSReviewerRequestSent
+	// Пропущенные без привязки логина ревьюверы остаются видны в отправленном запросе
+	request.LastError = unmappedReason
+	request.SentAt = &now
+}
+
+// resolveLogins возвращает логины пользователей у провайдера и пользователей без привязки
+func (uc *VCSReviewerSyncUseCase) resolveLogins(
+	ctx context.Context,
+	provider entity.VCSProvider,
+	userIDs []string,
+) (logins, unmapped []string, err error) {
+	for _, userID := range userIDs {
+		identities, err := uc.identityRepo.List(ctx, provider, userID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list vcs identities: %w", err)
+		}
+
+		if len(identities) == 0 {
+			unmapped = append(unmapped, userID)
+			continue
+		}
+
+		logins = append(logins, identities[0].Login)
+	}
+
+	return logins, unmapped, nil
+}
+
+// skip помечает запрос как не требующий отправки
+func (uc *VCSReviewerSyncUseCase) skip(request *entity.VCSReviewerRequest, reason string) {
+	request.Status = entity.VCSReviewerRequestSkipped
+	request.LastError = reason
+}
+
+// fail записывает неудачную попытку и планирует повтор, если он возможен
+func (uc *VCSReviewerSyncUseCase) fail(request *entity.VCSReviewerRequest, err error, now time.Time) {
+	request.Attempts++
+	request.LastError = err.Error()
+
+	if !vcs.IsRetryable(err) || request.Attempts >= uc.maxAttempts {
+		request.Status = entity.VCSReviewerRequestFailed
+		return
+	}
+
+	request.NextAttemptAt = now.Add(retryDelay(uc.retryBackoff, request.Attempts))
+}
+
+// retryDelay возвращает паузу после attempts неудачных попыток: base, удвоенная с каждой попыткой,
+// но не больше maxRetryBackoff
+func retryDelay(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+	return delay
+}
+
+// ListReviewerRequests возвращает запросы отправки ревьюверов; пустые prID и status не фильтруют
+func (uc *VCSReviewerSyncUseCase) ListReviewerRequests(
+	ctx context.Context,
+	prID string,
+	status entity.VCSReviewerRequestStatus,
+) ([]*entity.VCSReviewerRequest, error) {
+	requests, err := uc.requestRepo.List(ctx, prID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if requests == nil {
+		requests = []*entity.VCSReviewerRequest{}
+	}
+
+	return requests, nil
+}
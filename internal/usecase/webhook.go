@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/domain/entity"
+	domainErrors "github.com/StepanK17/pr-reviewer-service/internal/domain/errors"
+	"github.com/StepanK17/pr-reviewer-service/internal/repository"
+	"github.com/StepanK17/pr-reviewer-service/internal/webhook"
+)
+
+// WebhookUseCase управляет подписками на события и их доставкой
+type WebhookUseCase struct {
+	subscriptionRepo repository.WebhookSubscriptionRepository
+	deliveryRepo     repository.WebhookDeliveryRepository
+	teamRepo         repository.TeamRepository
+	txManager        repository.TransactionManager
+	sender           webhook.Sender
+	retryBackoff     time.Duration
+	maxAttempts      int
+}
+
+// NewWebhookUseCase создает usecase исходящих вебхуков
+func NewWebhookUseCase(
+	subscriptionRepo repository.WebhookSubscriptionRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+	teamRepo repository.TeamRepository,
+	txManager repository.TransactionManager,
+	sender webhook.Sender,
+	retryBackoff time.Duration,
+	maxAttempts int,
+) *WebhookUseCase {
+	return &WebhookUseCase{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		teamRepo:         teamRepo,
+		txManager:        txManager,
+		sender:           sender,
+		retryBackoff:     retryBackoff,
+		maxAttempts:      maxAttempts,
+	}
+}
+
+// Publish ставит событие в очередь доставки каждой подходящей подписке в одной транзакции
+func (uc *WebhookUseCase) Publish(ctx context.Context, event *entity.DomainEvent) error {
+	subscriptions, err := uc.subscriptionRepo.ListMatching(ctx, event.Type, event.TeamName)
+	if err != nil {
+		return err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := EncodeEvent(event)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	return uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		for _, subscription := range subscriptions {
+			if err := uc.deliveryRepo.Create(ctx, &entity.WebhookDelivery{
+				SubscriptionID: subscription.ID,
+				EventID:        event.ID,
+				EventType:      event.Type,
+				PullRequestID:  event.PullRequestID,
+				Payload:        payload,
+				Status:         entity.WebhookDeliveryPending,
+				NextAttemptAt:  now,
+				CreatedAt:      now,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CreateSubscription регистрирует подписку. Если secret пуст, он генерируется.
+func (uc *WebhookUseCase) CreateSubscription(
+	ctx context.Context,
+	rawURL, secret string,
+	eventTypes []entity.EventType,
+	teamName string,
+) (*entity.WebhookSubscription, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, invalidInput("url must be an absolute http or https URL")
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, invalidInput("event_types must not be empty")
+	}
+
+	seen := make(map[entity.EventType]bool, len(eventTypes))
+	unique := make([]entity.EventType, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !eventType.IsValid() {
+			return nil, invalidInput("unsupported event type: " + string(eventType))
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			unique = append(unique, eventType)
+		}
+	}
+
+	if teamName != "" {
+		exists, err := uc.teamRepo.Exists(ctx, teamName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check team existence: %w", err)
+		}
+		if !exists {
+			return nil, domainErrors.NewDomainError(
+				"NOT_FOUND",
+				"team not found",
+				domainErrors.ErrNotFound,
+			)
+		}
+	}
+
+	if secret == "" {
+		secret, err = randomHex(32)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	subscription := &entity.WebhookSubscription{
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: unique,
+		TeamName:   teamName,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := uc.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// ListSubscriptions возвращает все подписки
+func (uc *WebhookUseCase) ListSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	subscriptions, err := uc.subscriptionRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscriptions == nil {
+		subscriptions = []*entity.WebhookSubscription{}
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription удаляет подписку вместе с журналом доставок
+func (uc *WebhookUseCase) DeleteSubscription(ctx context.Context, subscriptionID int64) error {
+	err := uc.subscriptionRepo.Delete(ctx, subscriptionID)
+	if errors.Is(err, domainErrors.ErrNotFound) {
+		return domainErrors.NewDomainError(
+			"NOT_FOUND",
+			"webhook subscription not found",
+			domainErrors.ErrNotFound,
+		)
+	}
+
+	return err
+}
+
+// ListDeliveries возвращает журнал доставок; нулевой subscriptionID и пустой status не фильтруют
+func (uc *WebhookUseCase) ListDeliveries(
+	ctx context.Context,
+	subscriptionID int64,
+	status entity.WebhookDeliveryStatus,
+) ([]*entity.WebhookDelivery, error) {
+	deliveries, err := uc.deliveryRepo.List(ctx, subscriptionID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if deliveries == nil {
+		deliveries = []*entity.WebhookDelivery{}
+	}
+
+	return deliveries, nil
+}
+
+// Redeliver ставит в очередь новую доставку того же события; исходная запись журнала не меняется
+func (uc *WebhookUseCase) Redeliver(ctx context.Context, deliveryID int64) (*entity.WebhookDelivery, error) {
+	original, err := uc.deliveryRepo.GetByID(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.NewDomainError(
+				"NOT_FOUND",
+				"webhook delivery not found",
+				domainErrors.ErrNotFound,
+			)
+		}
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	delivery := &entity.WebhookDelivery{
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		PullRequestID:  original.PullRequestID,
+		Payload:        original.Payload,
+		Status:         entity.WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+
+	if err := uc.deliveryRepo.Create(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// PushDueDeliveries отправляет все готовые доставки и возвращает число обработанных.
+// Доставка захватывается в короткой транзакции и отправляется после её коммита, чтобы медленный
+// подписчик не держал соединение с базой; события одного PR доходят до подписчика по порядку.
+// Любой ответ вне 2xx или сетевая ошибка откладывают доставку с удвоением паузы,
+// после maxAttempts доставка получает статус FAILED.
+func (uc *WebhookUseCase) PushDueDeliveries(ctx context.Context, now time.Time) (int, error) {
+	processed := 0
+
+	for {
+		delivery, subscription, err := uc.claimNextDue(ctx, now)
+		if err != nil {
+			return processed, err
+		}
+
+		if delivery == nil {
+			return processed, nil
+		}
+
+		delivery.Attempts++
+		delivery.ResponseStatus, err = uc.sender.Send(ctx, subscription.URL, subscription.Secret, delivery)
+		if err != nil {
+			delivery.LastError = err.Error()
+			if delivery.Attempts >= uc.maxAttempts {
+				delivery.Status = entity.WebhookDeliveryFailed
+			} else {
+				delivery.NextAttemptAt = now.Add(retryDelay(uc.retryBackoff, delivery.Attempts))
+			}
+		} else {
+			delivery.Status = entity.WebhookDeliveryDelivered
+			delivery.LastError = ""
+			delivery.DeliveredAt = &now
+		}
+
+		// Подписку могли удалить во время отправки вместе с её доставками
+		if err := uc.deliveryRepo.Update(ctx, delivery); err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+			return processed, err
+		}
+
+		processed++
+	}
+}
+
+// claimNextDue захватывает готовую доставку вместе с её подпиской, откладывая доставку на claimLease,
+// чтобы другие обработчики не взяли её во время отправки. Возвращает nil, если готовых доставок нет.
+func (uc *WebhookUseCase) claimNextDue(
+	ctx context.Context,
+	now time.Time,
+) (*entity.WebhookDelivery, *entity.WebhookSubscription, error) {
+	var (
+		delivery     *entity.WebhookDelivery
+		subscription *entity.WebhookSubscription
+	)
+
+	err := uc.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		locked, err := uc.deliveryRepo.LockNextDue(ctx, now)
+		if err != nil || locked == nil {
+			return err
+		}
+
+		subscription, err = uc.subscriptionRepo.GetByID(ctx, locked.SubscriptionID)
+		if err != nil {
+			return err
+		}
+
+		locked.NextAttemptAt = now.Add(claimLease)
+		if err := uc.deliveryRepo.Update(ctx, locked); err != nil {
+			return err
+		}
+
+		delivery = locked
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return delivery, subscription, nil
+}
@@ -0,0 +1,39 @@
+package vcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Client исходящие вызовы к хостингу кода
+type Client interface {
+	// RequestReviewers запрашивает ревью у логинов в PR репозитория
+	RequestReviewers(ctx context.Context, repository string, number int, logins []string) error
+	// RemoveReviewers отзывает запрос ревью у логинов
+	RemoveReviewers(ctx context.Context, repository string, number int, logins []string) error
+}
+
+// StatusError неуспешный ответ API хостинга кода
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// IsRetryable сообщает, имеет ли смысл повторить вызов.
+// Повторяются сетевые ошибки, 429 и 5xx; остальные ответы 4xx считаются окончательными.
+func IsRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+	return err != nil
+}
@@ -0,0 +1,80 @@
+package vcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxErrorBodySize сколько байт тела ошибки сохраняется в StatusError
+const maxErrorBodySize = 1 << 10
+
+// GitHubClient клиент GitHub REST API
+type GitHubClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewGitHubClient создает клиент GitHub; baseURL позволяет указать GitHub Enterprise или тестовую заглушку
+func NewGitHubClient(baseURL, token string, timeout time.Duration) *GitHubClient {
+	return &GitHubClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// RequestReviewers вызывает POST /repos/{repository}/pulls/{number}/requested_reviewers
+func (c *GitHubClient) RequestReviewers(ctx context.Context, repository string, number int, logins []string) error {
+	return c.requestedReviewers(ctx, http.MethodPost, repository, number, logins)
+}
+
+// RemoveReviewers вызывает DELETE /repos/{repository}/pulls/{number}/requested_reviewers
+func (c *GitHubClient) RemoveReviewers(ctx context.Context, repository string, number int, logins []string) error {
+	return c.requestedReviewers(ctx, http.MethodDelete, repository, number, logins)
+}
+
+// requestedReviewers отправляет список логинов в эндпоинт запрошенных ревьюверов
+func (c *GitHubClient) requestedReviewers(ctx context.Context, method, repository string, number int, logins []string) error {
+	body, err := json.Marshal(map[string][]string{"reviewers": logins})
+	if err != nil {
+		return fmt.Errorf("failed to encode reviewers: %w", err)
+	}
+
+	url := c.baseURL + "/repos/" + repository + "/pulls/" + strconv.Itoa(number) + "/requested_reviewers"
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build github request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call github: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		Body:       strings.TrimSpace(string(respBody)),
+	}
+}
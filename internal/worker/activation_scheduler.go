@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// ActivationScheduler периодически применяет наступившие изменения активности пользователей
+type ActivationScheduler struct {
+	scheduleUC *usecase.ActivationScheduleUseCase
+	interval   time.Duration
+}
+
+// NewActivationScheduler создает планировщик изменений активности
+func NewActivationScheduler(scheduleUC *usecase.ActivationScheduleUseCase, interval time.Duration) *ActivationScheduler {
+	return &ActivationScheduler{
+		scheduleUC: scheduleUC,
+		interval:   interval,
+	}
+}
+
+// Run обрабатывает изменения до отмены контекста
+func (s *ActivationScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick применяет все изменения, срок которых наступил
+func (s *ActivationScheduler) tick(ctx context.Context) {
+	processed, err := s.scheduleUC.ApplyDueActivations(ctx, time.Now().UTC())
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Failed to apply scheduled activation changes: %v", err)
+		}
+		return
+	}
+
+	if processed > 0 {
+		log.Printf("Applied %d scheduled activation changes", processed)
+	}
+}
@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// OutboxDispatcher периодически публикует события из outbox
+type OutboxDispatcher struct {
+	outboxUC *usecase.OutboxUseCase
+	interval time.Duration
+}
+
+// NewOutboxDispatcher создает обработчик outbox
+func NewOutboxDispatcher(outboxUC *usecase.OutboxUseCase, interval time.Duration) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		outboxUC: outboxUC,
+		interval: interval,
+	}
+}
+
+// Run публикует события до отмены контекста
+func (s *OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick публикует все события, срок которых наступил
+func (s *OutboxDispatcher) tick(ctx context.Context) {
+	processed, err := s.outboxUC.DispatchDue(ctx, time.Now().UTC())
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Failed to dispatch outbox events: %v", err)
+		}
+		return
+	}
+
+	if processed > 0 {
+		log.Printf("Published %d outbox events", processed)
+	}
+}
@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// VCSReviewerSync периодически отправляет назначенных ревьюверов на хостинги кода
+type VCSReviewerSync struct {
+	syncUC   *usecase.VCSReviewerSyncUseCase
+	interval time.Duration
+}
+
+// NewVCSReviewerSync создает обработчик очереди отправки ревьюверов
+func NewVCSReviewerSync(syncUC *usecase.VCSReviewerSyncUseCase, interval time.Duration) *VCSReviewerSync {
+	return &VCSReviewerSync{
+		syncUC:   syncUC,
+		interval: interval,
+	}
+}
+
+// Run обрабатывает очередь до отмены контекста
+func (s *VCSReviewerSync) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick отправляет все запросы, срок которых наступил
+func (s *VCSReviewerSync) tick(ctx context.Context) {
+	processed, err := s.syncUC.PushDueReviewerRequests(ctx, time.Now().UTC())
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Failed to push reviewer requests: %v", err)
+		}
+		return
+	}
+
+	if processed > 0 {
+		log.Printf("Processed %d reviewer requests", processed)
+	}
+}
@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/StepanK17/pr-reviewer-service/internal/usecase"
+)
+
+// WebhookDispatcher периодически доставляет исходящие вебхуки
+type WebhookDispatcher struct {
+	webhookUC *usecase.WebhookUseCase
+	interval  time.Duration
+}
+
+// NewWebhookDispatcher создает обработчик очереди доставок
+func NewWebhookDispatcher(webhookUC *usecase.WebhookUseCase, interval time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookUC: webhookUC,
+		interval:  interval,
+	}
+}
+
+// Run обрабатывает очередь до отмены контекста
+func (s *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick отправляет все доставки, срок которых наступил
+func (s *WebhookDispatcher) tick(ctx context.Context) {
+	processed, err := s.webhookUC.PushDueDeliveries(ctx, time.Now().UTC())
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Failed to deliver webhooks: %v", err)
+		}
+		return
+	}
+
+	if processed > 0 {
+		log.Printf("Processed %d webhook deliveries", processed)
+	}
+}
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_pull_requests_size_class;
+DROP TABLE IF EXISTS team_size_buckets;
+
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS review_due_at,
+    DROP COLUMN IF EXISTS size_class,
+    DROP COLUMN IF EXISTS files_changed,
+    DROP COLUMN IF EXISTS lines_deleted,
+    DROP COLUMN IF EXISTS lines_added;
@@ -0,0 +1,18 @@
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS lines_added INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS lines_deleted INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS files_changed INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS size_class VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS review_due_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS team_size_buckets (
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    size_class VARCHAR(20) NOT NULL,
+    max_lines INTEGER CHECK (max_lines >= 0),
+    max_files INTEGER CHECK (max_files >= 0),
+    reviewer_count INTEGER NOT NULL CHECK (reviewer_count >= 0),
+    sla_hours INTEGER NOT NULL CHECK (sla_hours > 0),
+    PRIMARY KEY (team_name, size_class)
+);
+
+CREATE INDEX idx_pull_requests_size_class ON pull_requests(size_class);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_kind;
+
+ALTER TABLE users DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS kind VARCHAR(20) NOT NULL DEFAULT 'HUMAN' CHECK (kind IN ('HUMAN', 'BOT'));
+
+CREATE INDEX idx_users_kind ON users(kind);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pr_history;
+DROP TABLE IF EXISTS team_escalation_contacts;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS team_escalation_contacts (
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('LEAD', 'ESCALATION')),
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (team_name, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS pr_history (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    old_reviewer_id VARCHAR(255),
+    new_reviewer_id VARCHAR(255),
+    escalated BOOLEAN NOT NULL DEFAULT false,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_pr_history_pull_request_id ON pr_history(pull_request_id);
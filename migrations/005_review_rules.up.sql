@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS review_rules (
+    id BIGSERIAL PRIMARY KEY,
+    rule_type VARCHAR(20) NOT NULL CHECK (rule_type IN ('NEVER_REVIEWS', 'ALWAYS_PAIR')),
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    other_user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (user_id <> other_user_id),
+    UNIQUE (rule_type, user_id, other_user_id)
+);
+
+CREATE INDEX idx_review_rules_user_id ON review_rules(user_id);
+CREATE INDEX idx_review_rules_other_user_id ON review_rules(other_user_id);
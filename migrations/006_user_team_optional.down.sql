@@ -0,0 +1,2 @@
+DELETE FROM users WHERE team_name IS NULL;
+ALTER TABLE users ALTER COLUMN team_name SET NOT NULL;
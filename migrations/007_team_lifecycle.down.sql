@@ -0,0 +1,16 @@
+ALTER TABLE team_escalation_contacts
+    DROP CONSTRAINT IF EXISTS team_escalation_contacts_team_name_fkey,
+    ADD CONSTRAINT team_escalation_contacts_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams(team_name) ON DELETE CASCADE;
+
+ALTER TABLE team_size_buckets
+    DROP CONSTRAINT IF EXISTS team_size_buckets_team_name_fkey,
+    ADD CONSTRAINT team_size_buckets_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams(team_name) ON DELETE CASCADE;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_team_name_fkey,
+    ADD CONSTRAINT users_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams(team_name) ON DELETE CASCADE;
+
+ALTER TABLE teams DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,16 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_team_name_fkey,
+    ADD CONSTRAINT users_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE SET NULL;
+
+ALTER TABLE team_size_buckets
+    DROP CONSTRAINT IF EXISTS team_size_buckets_team_name_fkey,
+    ADD CONSTRAINT team_size_buckets_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE team_escalation_contacts
+    DROP CONSTRAINT IF EXISTS team_escalation_contacts_team_name_fkey,
+    ADD CONSTRAINT team_escalation_contacts_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE;
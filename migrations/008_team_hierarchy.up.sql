@@ -0,0 +1,6 @@
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS parent_team_name VARCHAR(255)
+        REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE SET NULL,
+    ADD CONSTRAINT teams_parent_not_self CHECK (parent_team_name <> team_name);
+
+CREATE INDEX idx_teams_parent_team_name ON teams(parent_team_name);
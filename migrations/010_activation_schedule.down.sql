@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS activation_audit_log;
+DROP TABLE IF EXISTS scheduled_activation_changes;
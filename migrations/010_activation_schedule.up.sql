@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS scheduled_activation_changes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    is_active BOOLEAN NOT NULL,
+    apply_at TIMESTAMP NOT NULL,
+    hand_off_reviews BOOLEAN NOT NULL DEFAULT false,
+    successor_id VARCHAR(255) REFERENCES users(user_id) ON DELETE SET NULL,
+    reverse_of_id BIGINT REFERENCES scheduled_activation_changes(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPLIED', 'FAILED', 'CANCELLED')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP
+);
+
+CREATE INDEX idx_scheduled_activation_changes_due ON scheduled_activation_changes(apply_at) WHERE status = 'PENDING';
+CREATE INDEX idx_scheduled_activation_changes_user_id ON scheduled_activation_changes(user_id);
+CREATE INDEX idx_scheduled_activation_changes_reverse_of_id ON scheduled_activation_changes(reverse_of_id);
+
+CREATE TABLE IF NOT EXISTS activation_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    change_id BIGINT REFERENCES scheduled_activation_changes(id) ON DELETE SET NULL,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    is_active BOOLEAN NOT NULL,
+    outcome VARCHAR(20) NOT NULL CHECK (outcome IN ('APPLIED', 'FAILED')),
+    reassigned_prs INTEGER NOT NULL DEFAULT 0,
+    unassigned_prs INTEGER NOT NULL DEFAULT 0,
+    failed_prs INTEGER NOT NULL DEFAULT 0,
+    error_code VARCHAR(50),
+    error_message TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_activation_audit_log_user_id ON activation_audit_log(user_id);
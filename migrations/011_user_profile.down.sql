@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_users_email;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS chat_handle,
+    DROP COLUMN IF EXISTS email,
+    DROP COLUMN IF EXISTS display_name;
@@ -0,0 +1,7 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS display_name VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS email VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS chat_handle VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
+
+CREATE INDEX idx_users_email ON users(email);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS vcs_pull_requests;
+DROP TABLE IF EXISTS vcs_deliveries;
+DROP TABLE IF EXISTS vcs_identities;
+
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'CLOSED';
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED'));
@@ -0,0 +1,29 @@
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED', 'CLOSED'));
+
+CREATE TABLE IF NOT EXISTS vcs_identities (
+    provider VARCHAR(20) NOT NULL,
+    login VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, login)
+);
+
+CREATE INDEX idx_vcs_identities_user_id ON vcs_identities(user_id);
+
+CREATE TABLE IF NOT EXISTS vcs_deliveries (
+    provider VARCHAR(20) NOT NULL,
+    delivery_id VARCHAR(255) NOT NULL,
+    event VARCHAR(50) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, delivery_id)
+);
+
+CREATE TABLE IF NOT EXISTS vcs_pull_requests (
+    pull_request_id VARCHAR(255) PRIMARY KEY REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL,
+    repository VARCHAR(255) NOT NULL,
+    number INTEGER NOT NULL,
+    url TEXT NOT NULL DEFAULT ''
+);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS vcs_reviewer_requests (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    added_reviewers TEXT[] NOT NULL DEFAULT '{}',
+    removed_reviewers TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'SENT', 'FAILED', 'SKIPPED')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP
+);
+
+CREATE INDEX idx_vcs_reviewer_requests_due ON vcs_reviewer_requests(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX idx_vcs_reviewer_requests_pull_request_id ON vcs_reviewer_requests(pull_request_id);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(64) NOT NULL UNIQUE,
+    event_type VARCHAR(64) NOT NULL,
+    team_name VARCHAR(255) NOT NULL DEFAULT '',
+    pull_request_id VARCHAR(255) NOT NULL DEFAULT '',
+    data JSONB NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'PUBLISHED', 'FAILED')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    published_sinks TEXT[] NOT NULL DEFAULT '{}',
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_due ON outbox(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX idx_outbox_pending_pull_request_id ON outbox(pull_request_id, id) WHERE status = 'PENDING';